@@ -14,6 +14,7 @@ import (
 	"github.com/muzaffertuna/football-league-sim/internal/app/handlers"
 	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
 	"github.com/muzaffertuna/football-league-sim/internal/app/services"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
 	"github.com/muzaffertuna/football-league-sim/internal/database"
 	"github.com/muzaffertuna/football-league-sim/internal/pkg/logger"
 	"github.com/muzaffertuna/football-league-sim/internal/platform"
@@ -69,25 +70,52 @@ func main() {
 	// Repository'leri oluştur
 	teamRepo := repositories.NewTeamRepository(db)
 	matchRepo := repositories.NewMatchRepository(db)
-	// leagueRepo := repositories.NewLeagueRepository(teamRepo, matchRepo) // Bu satır artık kullanılmıyor ve yorum satırı yapılmalı veya silinmeli
+	leagueRepo := repositories.NewLeagueRepository(db)
+	seasonRepo := repositories.NewSeasonRepository(db)
+	cupRepo := repositories.NewCupRepository(db)
+	tournamentRepo := repositories.NewTournamentRepository(db)
+	uow := repositories.NewUnitOfWork(db)
 
 	// Servisleri oluştur
-	teamSvc := services.NewTeamService(teamRepo)
-	matchSvc := services.NewMatchService(matchRepo, teamRepo)
-
-	// SADECE BU KISIM GÜNCELLENDİ:
-	// services.NewLeagueService artık leagueRepo almıyor ve bir hata döndürüyor.
-	leagueSvc, err := services.NewLeagueService(matchRepo, matchSvc, teamRepo, teamSvc)
+	teamSvc := services.NewTeamService(teamRepo, uow)
+	matchEngine, err := services.NewMatchEngine(cfg.MatchEngine)
+	if err != nil {
+		logger.Error("Failed to create match engine: " + err.Error())
+		return
+	}
+	matchSvc := services.NewMatchService(matchRepo, matchEngine)
+
+	var tiebreakers []standings.Tiebreaker
+	if cfg.Tiebreakers != "" {
+		tiebreakers, err = standings.ParseTiebreakers(cfg.Tiebreakers)
+		if err != nil {
+			logger.Error("Invalid tiebreakers: " + err.Error())
+			return
+		}
+	}
+	leagueSettings := services.LeagueSettings{
+		Seed:                 cfg.SimulationSeed,
+		Tiebreakers:          tiebreakers,
+		MaterializeStandings: cfg.MaterializeStandings,
+	}
+	leagueSvc, err := services.NewLeagueService(matchRepo, matchSvc, teamRepo, teamSvc, leagueRepo, seasonRepo, cupRepo, uow, leagueSettings)
 	if err != nil {
 		logger.Error("Failed to initialize league service: " + err.Error())
 		return
 	}
 
+	cupSvc := services.NewCupService(cupRepo, matchRepo, teamRepo, matchSvc, uow)
+	tournamentSvc := services.NewTournamentService(tournamentRepo, cupRepo, matchRepo, teamRepo, matchSvc, uow)
+
 	// Handler'ları oluştur
 	leagueHandler := handlers.NewLeagueHandler(leagueSvc, logger)
+	teamHandler := handlers.NewTeamHandler(teamSvc, logger)
+	cupHandler := handlers.NewCupHandler(cupSvc, logger)
+	tournamentHandler := handlers.NewTournamentHandler(tournamentSvc, logger)
+	problemHandler := handlers.NewProblemHandler(logger)
 
 	// Router'ı oluştur
-	router := platform.NewRouter(leagueHandler)
+	router := platform.NewRouter(leagueHandler, cupHandler, tournamentHandler, teamHandler, problemHandler)
 
 	// Sunucuyu başlat
 	logger.Info("Starting server on " + cfg.ServerAddress)
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +11,11 @@ import (
 type Config struct {
 	DBConnectionString string
 	ServerAddress      string
+	MatchEngine        string
+	SimulationSeed     *int64 // Ligin varsayılan rastgele sayı tohumu; boşsa her simülasyon yeni tohum alır
+	Tiebreakers        string // Puan eşitliğinde sırayla uygulanan ölçütler (virgülle ayrılmış)
+	// MaterializeStandings true ise maçlardan hesaplanan puan durumu Teams tablosuna da yazılır
+	MaterializeStandings bool
 }
 
 func LoadConfig() Config {
@@ -20,6 +26,8 @@ func LoadConfig() Config {
 	cfg := Config{
 		DBConnectionString: os.Getenv("DB_CONNECTION_STRING"),
 		ServerAddress:      os.Getenv("SERVER_ADDRESS"),
+		MatchEngine:        os.Getenv("MATCH_ENGINE"),
+		Tiebreakers:        os.Getenv("TIEBREAKERS"),
 	}
 
 	if cfg.DBConnectionString == "" {
@@ -28,6 +36,20 @@ func LoadConfig() Config {
 	if cfg.ServerAddress == "" {
 		log.Fatal("SERVER_ADDRESS is required")
 	}
+	if raw := os.Getenv("SIMULATION_SEED"); raw != "" {
+		seed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			log.Fatalf("SIMULATION_SEED must be an integer: %v", err)
+		}
+		cfg.SimulationSeed = &seed
+	}
+	if raw := os.Getenv("MATERIALIZE_STANDINGS"); raw != "" {
+		materialize, err := strconv.ParseBool(raw)
+		if err != nil {
+			log.Fatalf("MATERIALIZE_STANDINGS must be a boolean: %v", err)
+		}
+		cfg.MaterializeStandings = materialize
+	}
 
 	return cfg
 }
@@ -15,191 +15,3267 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/league-table": {
+        "/cups": {
             "get": {
-                "description": "Mevcut lig tablosunu puan sırasına göre döndürür",
+                "description": "Kayıtlı tüm kupaları güncel tur, durum ve kazananlarıyla birlikte döndürür",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "cups"
                 ],
-                "summary": "Lig tablosunu getirir",
+                "summary": "Kupaları listeler",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.League"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Cup"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Verilen takımlarla eleme usulü bir kupa oluşturur. Takımlar seri başı sırasıyla verilir; takım sayısı ikinin kuvveti değilse ilk turda en iyi seri başları bay geçer. legs 1 ya da 2 olabilir (final her zaman tek maçtır), draw seeded, random ya da fixed (kurasız sabit ağaç) olabilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cups"
+                ],
+                "summary": "Yeni kupa oluşturur",
+                "parameters": [
+                    {
+                        "description": "Kupa bilgileri",
+                        "name": "cup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createCupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid cup",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     }
                 }
             }
         },
-        "/play-week": {
-            "post": {
-                "description": "Ligin güncel haftasını simüle eder ve ligi günceller",
+        "/cups/{cupID}": {
+            "get": {
+                "description": "Kupanın kurası çekilmiş tüm turlarını eşleşmeler, maç skorları, uzatma ve penaltı sonuçlarıyla birlikte döndürür",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "cups"
+                ],
+                "summary": "Kupanın eleme ağacını getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kupa kimliği",
+                        "name": "cupID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Mevcut haftayı oynatır",
                 "responses": {
                     "200": {
-                        "description": "Week played successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.CupBracket"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cup ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cup not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     }
                 }
             }
         },
-        "/reset-league": {
+        "/cups/{cupID}/draw": {
             "post": {
-                "description": "Tüm takımları ve maçları sıfırlayarak ligi yeniden başlatır",
+                "description": "Güncel turun kazananları (ilk turda tüm takımlar) arasında sonraki turun kurasını çeker ve eşleşmeleri döndürür. Güncel turun tüm eşleşmeleri oynanmış olmalıdır",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "cups"
+                ],
+                "summary": "Sonraki turun kurasını çeker",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kupa kimliği",
+                        "name": "cupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Kura için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Ligi sıfırlar",
                 "responses": {
                     "200": {
-                        "description": "League reset successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.CupRound"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cup not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Current round is not played, cup is completed, is played by its league or tournament or was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     }
                 }
             }
         },
-        "/simulate-all-weeks": {
+        "/cups/{cupID}/play-round": {
             "post": {
-                "description": "Ligdeki kalan tüm haftaları otomatik olarak simüle eder ve sonuçları döndürür",
+                "description": "Kurası çekilmiş turun eşleşmelerini simüle eder. Toplam skor eşitse uzatma, o da eşit biterse penaltı atışları oynanır. Final oynandığında kupa tamamlanır",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "cups"
+                ],
+                "summary": "Güncel turu oynatır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kupa kimliği",
+                        "name": "cupID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Turun maçları için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Tüm ligi simüle eder",
                 "responses": {
                     "200": {
-                        "description": "Tüm simüle edilmiş maçların sonuçları",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Match"
-                            }
+                            "$ref": "#/definitions/models.CupRound"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cup not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Round is not drawn, cup is completed, is played by its league or tournament or was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handlers.problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.League": {
-            "type": "object",
-            "properties": {
-                "championshipPredictions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Prediction"
-                    }
-                },
-                "current_week": {
-                    "type": "integer"
-                },
-                "matches": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Match"
+        },
+        "/fixtures": {
+            "get": {
+                "description": "Ligin güncel (ya da season ile seçilen) sezonunun maçlarını takım adlarıyla birlikte döndürür. Maçlar haftaya, takıma, iç saha ya da deplasmana ve oynanıp oynanmadığına göre süzülebilir. Sonuçlar sayfalanır; total ölçütlere uyan tüm maçların sayısıdır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Fikstürü getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sezon numarası (varsayılan: güncel sezon)",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Yalnızca bu haftanın maçları",
+                        "name": "week",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Yalnızca bu takımın maçları",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "team ile birlikte: home ya da away",
+                        "name": "venue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Yalnızca oynanmış (true) ya da oynanmamış (false) maçlar",
+                        "name": "played",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sıralama ölçütü: week (varsayılan) ya da id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sıralama yönü: asc (varsayılan) ya da desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sayfadaki en fazla maç sayısı (varsayılan 50, en fazla 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Atlanacak maç sayısı",
+                        "name": "offset",
+                        "in": "query"
                     }
-                },
-                "teams": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Team"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Fixtures"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League or season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fixture query",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
                     }
                 }
             }
         },
-        "models.Match": {
-            "type": "object",
-            "properties": {
-                "away_goals": {
-                    "type": "integer"
-                },
-                "away_team_id": {
-                    "type": "integer"
-                },
-                "home_goals": {
-                    "type": "integer"
-                },
-                "home_team_id": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "played": {
-                    "type": "boolean"
-                },
-                "week": {
-                    "type": "integer"
+        "/league-table": {
+            "get": {
+                "description": "Lig tablosunu kayıtlı maç sonuçlarından hesaplayarak puan sırasına göre döndürür. week verilirse tablo o haftanın sonundaki haliyle döner. Şampiyonluk tahminleri yalnızca include=predictions ile istenirse hesaplanır ve lig durumu değişene kadar önbellekten sunulur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Lig tablosunu getirir",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ek veriler (virgülle ayrılmış): predictions",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Şampiyonluk tahminleri için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Puan durumunun hesaplanacağı hafta (varsayılan: son oynanan hafta)",
+                        "name": "week",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.League"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
                 }
             }
         },
-        "models.Prediction": {
-            "type": "object",
-            "properties": {
-                "championship_likelihood": {
-                    "description": "Şampiyonluk olasılığı (%)",
-                    "type": "number"
-                },
-                "team_id": {
-                    "type": "integer"
-                },
-                "team_name": {
-                    "type": "string"
+        "/leagues": {
+            "get": {
+                "description": "Kayıtlı tüm ligleri güncel hafta, sezon durumu ve kurallarıyla birlikte döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligleri listeler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LeagueState"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
                 }
-            }
-        },
-        "models.Team": {
-            "type": "object",
-            "properties": {
-                "draws": {
-                    "description": "Yeni eklendi",
-                    "type": "integer"
-                },
-                "goals_against": {
-                    "type": "integer"
+            },
+            "post": {
+                "description": "Verilen takımlarla yeni bir lig oluşturur ve çift devreli fikstürünü hazırlar. Eşitlik ölçütleri ya da tohum verilmezse sunucunun varsayılanları kullanılır. playoffs verilirse normal sezonun sonunda play-off oynanır. swiss verilirse fikstür İsviçre usulüyle üretilir: her takım her torbadan opponents_per_pot rakiple bir kez karşılaşır, aynı ülkeden takımlar eşleşmez ve iç saha ile deplasman maçları dengelenir. Bu durumda her takımın pot değeri olmalı, torbalar eşit büyüklükte ve takım sayısı çift olmalıdır; eşitlik ölçütleri verilmezse UEFA'nın lig aşaması ölçütleri kullanılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Yeni lig oluşturur",
+                "parameters": [
+                    {
+                        "description": "Lig bilgileri",
+                        "name": "league",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createLeagueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid league",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/all-time-table": {
+            "get": {
+                "description": "Ligin tüm sezonlarındaki oynanmış maçlardan toplam puan durumunu, takımların sezon ve şampiyonluk sayılarıyla birlikte döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Tüm zamanlar puan durumunu getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AllTimeTable"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/engine": {
+            "put": {
+                "description": "Ligin maçlarını ve tahminlerini simüle eden motoru seçer: legacy, poisson ya da dixon-coles. match_engine boşsa lig sunucunun varsayılan motorunu (MATCH_ENGINE) kullanır. Yeni motor sonraki maçlarda ve tahminlerde kullanılır; oynanmış maçlar değişmez",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligin maç motorunu ayarlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Maç motoru",
+                        "name": "engine",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.leagueEngineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unknown match engine",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/fixtures": {
+            "get": {
+                "description": "Ligin güncel (ya da season ile seçilen) sezonunun maçlarını takım adlarıyla birlikte döndürür. Maçlar haftaya, takıma, iç saha ya da deplasmana ve oynanıp oynanmadığına göre süzülebilir. Sonuçlar sayfalanır; total ölçütlere uyan tüm maçların sayısıdır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Fikstürü getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sezon numarası (varsayılan: güncel sezon)",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Yalnızca bu haftanın maçları",
+                        "name": "week",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Yalnızca bu takımın maçları",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "team ile birlikte: home ya da away",
+                        "name": "venue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Yalnızca oynanmış (true) ya da oynanmamış (false) maçlar",
+                        "name": "played",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sıralama ölçütü: week (varsayılan) ya da id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sıralama yönü: asc (varsayılan) ya da desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sayfadaki en fazla maç sayısı (varsayılan 50, en fazla 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Atlanacak maç sayısı",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Fixtures"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League or season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid fixture query",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/play-week": {
+            "post": {
+                "description": "Ligin güncel haftasını simüle eder ve haftanın maçlarını takım adları ve skorlarıyla, ligin güncel puan durumuyla birlikte döndürür. Play-off haftalarında maçlar yerine play-off'un eleme ağacı döner. Accept: text/plain ile eski düz metin sonuç listesi döner (play-off haftalarında oynanan turun eşleşmeleri); bu durumda tamamlanmış bir lig için de 200 döner",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Mevcut haftayı oynatır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Haftanın maçları için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WeekResults"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid seed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League has already completed or week was played by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/playoffs": {
+            "put": {
+                "description": "Normal sezonun sonunda first_place ile last_place arasındaki sıralarda bitiren takımların oynayacağı eleme usulü play-off'u ayarlar. Sıralamada üstte bitiren takım seri başıdır ve en alttaki takımla eşleşir; takım sayısı ikinin kuvveti değilse en iyi seri başları ilk turu bay geçer. prize \"title\" ise play-off şampiyonu belirler, \"promotion\" ise son üst lige çıkma yerini belirler (ilk promotion_places-1 takım doğrudan çıkar). legs 1 ya da 2 olabilir (final her zaman tek maçtır). Yeni biçim, play-off'u başlamamış sezonun sonunda uygulanır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligin play-off biçimini ayarlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Play-off biçimi",
+                        "name": "playoffs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PlayoffFormat"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid playoffs",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Lig, play-off'u başlamamış sezonun sonunda play-off oynamadan tamamlanır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligin play-off'unu kaldırır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/predictions": {
+            "get": {
+                "description": "Kalan haftaları Monte Carlo yöntemiyle simüle ederek her takımın her sırada bitirme olasılığını, ilk N, küme düşme ve beklenen puan değerlerini döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Sezon sonu tahminlerini getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simülasyon sayısı (varsayılan 10000, en fazla 100000)",
+                        "name": "simulations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "İlk N olasılığı için N",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Küme düşme hattındaki takım sayısı",
+                        "name": "relegation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PredictionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/reset": {
+            "post": {
+                "description": "Yeni bir sezon başlatır. Güncel sezonda maç oynandıysa sezon arşivlenir ve önceki sezonların fikstürü, tabloları ve şampiyonları korunur. Ligin sonraki kadrosu ayarlandıysa yeni sezon bu kadroyla başlar",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligi sıfırlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "League reset successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Next roster is no longer valid",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/rollover": {
+            "post": {
+                "description": "Ligin bulunduğu piramidin tüm liglerinde sezonu kapatır, takımları üst lige çıkarır ya da alt lige düşürür ve her lig için yeni sezonun fikstürünü oluşturur. Sonraki kadrosu ayarlanan liglerde yeni sezon bu kadroyla başlar. Piramitteki tüm liglerin sezonu tamamlanmış olmalıdır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pyramid"
+                ],
+                "summary": "Piramitte sezon geçişi yapar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Piramitteki herhangi bir ligin kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Rollover"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Season is not completed or league was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid pyramid configuration or next roster",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/roster": {
+            "put": {
+                "description": "Ligin bir sonraki sıfırlamadan itibaren oynayacağı takımları ayarlar. Güncel sezonun fikstürü ve puan durumu değişmez; kadroda olmayan takımlar sıfırlamada ligden ayrılır, kadrodaki bağımsız takımlar lige katılır. Takımlar bağımsız olmalı ya da zaten bu ligde oynamalıdır; İsviçre usulü liglerde her takımın torbası olmalıdır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligin sonraki kadrosunu ayarlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kadrodaki takımların kimlikleri",
+                        "name": "roster",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.leagueRosterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid roster",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Lig, sıfırlamada mevcut takımlarıyla devam eder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligin bekleyen kadro değişikliğini iptal eder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons": {
+            "get": {
+                "description": "Ligin tüm sezonlarını durumları (not_started, in_progress, completed, abandoned) ve şampiyonlarıyla birlikte döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Ligin sezonlarını listeler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Season"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{season}/table": {
+            "get": {
+                "description": "Ligin verilen numaralı sezonunun puan durumunu döndürür. Arşivlenmiş sezonlar kapandıkları andaki eşitlik ölçütleriyle sıralanır; şampiyonluk tahminleri yalnızca güncel sezon için hesaplanır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Bir sezonun puan durumunu getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sezon numarası",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Puan durumunun hesaplanacağı hafta (varsayılan: son oynanan hafta)",
+                        "name": "week",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.League"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League or season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/simulate-all-weeks": {
+            "post": {
+                "description": "Ligdeki kalan tüm haftaları otomatik olarak simüle eder ve sonuçları döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Tüm ligi simüle eder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Kalan haftalar için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tüm simüle edilmiş maçların sonuçları",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid seed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League has already completed or was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/table": {
+            "get": {
+                "description": "Lig tablosunu kayıtlı maç sonuçlarından hesaplayarak puan sırasına göre döndürür. week verilirse tablo o haftanın sonundaki haliyle döner. Şampiyonluk tahminleri yalnızca include=predictions ile istenirse hesaplanır ve lig durumu değişene kadar önbellekten sunulur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Lig tablosunu getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ek veriler (virgülle ayrılmış): predictions",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Şampiyonluk tahminleri için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Puan durumunun hesaplanacağı hafta (varsayılan: son oynanan hafta)",
+                        "name": "week",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.League"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/tier": {
+            "put": {
+                "description": "Ligi bir üst lige bağlar (parent_league_id verilmezse bağlantıyı kaldırır) ve sezon sonunda üst lige çıkan ve alt lige düşen takım sayılarını ayarlar. Her ligin en fazla bir alt ligi olabilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pyramid"
+                ],
+                "summary": "Ligin piramitteki yerini ayarlar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lig kimliği",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Piramit ayarları",
+                        "name": "tier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.leagueTierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeagueState"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid tier",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{matchID}/result": {
+            "put": {
+                "description": "Bir lig maçının gerçek skorunu kaydeder ya da oynanmış bir maçın skorunu düzeltir. Puan durumu yeni sonuca göre yeniden hesaplanır (eski sonuç geri alınır) ve şampiyonluk tahminleri geçersiz kılınır. Sonucu girilen maç, haftası oynatılırken simüle edilmez. Yalnızca güncel sezonun normal sezon maçları değiştirilebilir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Maç sonucunu kaydeder ya da düzeltir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maç kimliği",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Skor",
+                        "name": "result",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.matchResultRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Match"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid match ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Result can no longer be changed or league was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid result",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/play-week": {
+            "post": {
+                "description": "Ligin güncel haftasını simüle eder ve haftanın maçlarını takım adları ve skorlarıyla, ligin güncel puan durumuyla birlikte döndürür. Play-off haftalarında maçlar yerine play-off'un eleme ağacı döner. Accept: text/plain ile eski düz metin sonuç listesi döner (play-off haftalarında oynanan turun eşleşmeleri); bu durumda tamamlanmış bir lig için de 200 döner",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Mevcut haftayı oynatır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Haftanın maçları için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WeekResults"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid seed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League has already completed or week was played by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/predictions": {
+            "get": {
+                "description": "Kalan haftaları Monte Carlo yöntemiyle simüle ederek her takımın her sırada bitirme olasılığını, ilk N, küme düşme ve beklenen puan değerlerini döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Sezon sonu tahminlerini getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simülasyon sayısı (varsayılan 10000, en fazla 100000)",
+                        "name": "simulations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "İlk N olasılığı için N",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Küme düşme hattındaki takım sayısı",
+                        "name": "relegation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PredictionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reset-league": {
+            "post": {
+                "description": "Yeni bir sezon başlatır. Güncel sezonda maç oynandıysa sezon arşivlenir ve önceki sezonların fikstürü, tabloları ve şampiyonları korunur. Ligin sonraki kadrosu ayarlandıysa yeni sezon bu kadroyla başlar",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Ligi sıfırlar",
+                "responses": {
+                    "200": {
+                        "description": "League reset successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Next roster is no longer valid",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/simulate-all-weeks": {
+            "post": {
+                "description": "Ligdeki kalan tüm haftaları otomatik olarak simüle eder ve sonuçları döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "league"
+                ],
+                "summary": "Tüm ligi simüle eder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kalan haftalar için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tüm simüle edilmiş maçların sonuçları",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid seed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "League has already completed or was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "get": {
+                "description": "Kayıtlı tüm takımları ligleri, güçleri ve puan durumu sayaçlarıyla birlikte döndürür. Bir lige bağlı olmayan takımların league_id değeri 0'dır",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Takımları listeler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Team"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Bir lige bağlı olmayan yeni bir takım oluşturur. Takım kupalarda ve turnuvalarda hemen kullanılabilir; bir lige PUT /leagues/{leagueID}/roster ile katılır. Takım adları benzersizdir; strength 1-100 arasında olmalıdır, attack ve defense verilmezse strength kullanılır",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Yeni takım oluşturur",
+                "parameters": [
+                    {
+                        "description": "Takım bilgileri",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid team",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamID}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Takımı getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Takım kimliği",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid team ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Takımın adını, güçlerini, torbasını ve ülkesini günceller. Yeni güçler sonraki maçlardan ve tahminlerden itibaren kullanılır; takımın ligi ve puan durumu değişmez",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Takımı günceller",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Takım kimliği",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Takım bilgileri",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createTeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid team ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid team",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Fikstürde maçı olan, bir ligde oynayan ya da bir ligin sonraki kadrosunda, bir kupada veya turnuvada yer alan takımlar silinemez",
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Takımı siler",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Takım kimliği",
+                        "name": "teamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid team ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Team not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Team is in use",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments": {
+            "get": {
+                "description": "Kayıtlı tüm turnuvaları aşama, durum ve kazananlarıyla birlikte döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Turnuvaları listeler",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tournament"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Verilen gruplarla bir turnuva oluşturur ve grup fikstürlerini hazırlar. Grup sayısı 2 ile 16 arasında ikinin kuvveti olmalıdır; her gruptan birinci ve ikinci eleme aşamasına kalır. group_legs ve knockout_legs 1 ya da 2 olabilir (final her zaman tek maçtır)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Yeni turnuva oluşturur",
+                "parameters": [
+                    {
+                        "description": "Turnuva bilgileri",
+                        "name": "tournament",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createTournamentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tournament"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid tournament",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}": {
+            "get": {
+                "description": "Turnuvayı grupların puan durumları ve maçları ile (grup aşaması bittiyse) eleme ağacıyla birlikte döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Turnuvanın ayrıntılarını getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Turnuva kimliği",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tournament"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tournament ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/play-round": {
+            "post": {
+                "description": "Grup aşamasında tüm grupların sıradaki maç gününü oynatır; son maç günüyle birlikte grup birincileri ve ikincileri arasında eleme kurası çekilir (ilk turda aynı gruptan takımlar eşleşmez). Eleme aşamasında güncel tur oynatılır ve sonraki turun kurası çekilir",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Turnuvanın sıradaki turunu oynatır",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Turnuva kimliği",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Turun maçları ve kura için rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tournament"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Tournament is completed or was changed by another request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments/{tournamentID}/predictions": {
+            "get": {
+                "description": "Turnuvanın kalanını Monte Carlo yöntemiyle simüle ederek her takımın grup aşamasından sonraki her tura ulaşma ve turnuvayı kazanma olasılıklarını döndürür",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournaments"
+                ],
+                "summary": "Turnuva tahminlerini getirir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Turnuva kimliği",
+                        "name": "tournamentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simülasyon sayısı (varsayılan 10000, en fazla 100000)",
+                        "name": "simulations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rastgele sayı tohumu",
+                        "name": "seed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TournamentPrediction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Tournament not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.createCupRequest": {
+            "type": "object",
+            "properties": {
+                "draw": {
+                    "type": "string",
+                    "example": "seeded"
+                },
+                "legs": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Türkiye Kupası"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "team_ids": {
+                    "description": "Seri başı sırasıyla; ilk takım birinci seri başıdır",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.createLeagueRequest": {
+            "type": "object",
+            "properties": {
+                "match_engine": {
+                    "type": "string",
+                    "example": "poisson"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Süper Lig"
+                },
+                "parent_league_id": {
+                    "type": "integer"
+                },
+                "playoffs": {
+                    "$ref": "#/definitions/models.PlayoffFormat"
+                },
+                "promotion_places": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "relegation_places": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "swiss": {
+                    "$ref": "#/definitions/models.SwissFormat"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.createTeamRequest"
+                    }
+                },
+                "tiebreakers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "head_to_head_points",
+                        "goal_difference"
+                    ]
+                }
+            }
+        },
+        "handlers.createTeamRequest": {
+            "type": "object",
+            "properties": {
+                "attack": {
+                    "type": "integer"
+                },
+                "country": {
+                    "description": "İsviçre usulü liglerde aynı ülkeden takımlar eşleşmez",
+                    "type": "string",
+                    "example": "TUR"
+                },
+                "defense": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Galatasaray"
+                },
+                "pot": {
+                    "description": "İsviçre usulü liglerde zorunludur",
+                    "type": "integer",
+                    "example": 1
+                },
+                "strength": {
+                    "type": "integer",
+                    "example": 85
+                }
+            }
+        },
+        "handlers.createTournamentRequest": {
+            "type": "object",
+            "properties": {
+                "group_legs": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "groups": {
+                    "description": "Her grubun takım kimlikleri; grup sayısı ikinin kuvveti olmalıdır",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "knockout_legs": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dünya Kupası"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "tiebreakers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "goal_difference",
+                        "goals_for",
+                        "head_to_head"
+                    ]
+                }
+            }
+        },
+        "handlers.leagueEngineRequest": {
+            "type": "object",
+            "properties": {
+                "match_engine": {
+                    "type": "string",
+                    "example": "dixon-coles"
+                }
+            }
+        },
+        "handlers.leagueRosterRequest": {
+            "type": "object",
+            "properties": {
+                "team_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.leagueTierRequest": {
+            "type": "object",
+            "properties": {
+                "parent_league_id": {
+                    "type": "integer"
+                },
+                "promotion_places": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "relegation_places": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.matchResultRequest": {
+            "type": "object",
+            "properties": {
+                "away_goals": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "home_goals": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Alan hatalarında services.Error.Code",
+                    "type": "string",
+                    "example": "league_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "league not found: 7"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/leagues/7/table"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "models.AllTimeRow": {
+            "type": "object",
+            "properties": {
+                "draws": {
+                    "type": "integer"
+                },
+                "goal_difference": {
+                    "type": "integer"
+                },
+                "goals_against": {
+                    "type": "integer"
+                },
+                "goals_for": {
+                    "type": "integer"
+                },
+                "losses": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "seasons": {
+                    "description": "Takımın maç oynadığı sezon sayısı",
+                    "type": "integer"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "titles": {
+                    "description": "Kazanılan şampiyonluk sayısı",
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AllTimeTable": {
+            "type": "object",
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seasons": {
+                    "type": "integer"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AllTimeRow"
+                    }
+                }
+            }
+        },
+        "models.Cup": {
+            "type": "object",
+            "properties": {
+                "current_round": {
+                    "description": "Kurası çekilmiş son tur; 0 ise henüz kura çekilmemiştir",
+                    "type": "integer"
+                },
+                "draw": {
+                    "$ref": "#/definitions/models.CupDraw"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "legs": {
+                    "description": "Eşleşme başına maç sayısı (1 ya da 2); final her zaman tek maçtır",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "Boşsa kupa bağımsızdır ve kupa uç noktalarıyla oynanır",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CupOwner"
+                        }
+                    ]
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LeagueStatus"
+                },
+                "team_ids": {
+                    "description": "Seri başı sırasına göre katılımcılar",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "total_rounds": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "İyimser eşzamanlılık denetimi için; her güncellemede bir artar",
+                    "type": "integer"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CupBracket": {
+            "type": "object",
+            "properties": {
+                "cup": {
+                    "$ref": "#/definitions/models.Cup"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CupRound"
+                    }
+                }
+            }
+        },
+        "models.CupDraw": {
+            "type": "string",
+            "enum": [
+                "seeded",
+                "random",
+                "fixed"
+            ],
+            "x-enum-varnames": [
+                "CupDrawSeeded",
+                "CupDrawRandom",
+                "CupDrawFixed"
+            ]
+        },
+        "models.CupOwner": {
+            "type": "string",
+            "enum": [
+                "league",
+                "tournament"
+            ],
+            "x-enum-varnames": [
+                "CupOwnerLeague",
+                "CupOwnerTournament"
+            ]
+        },
+        "models.CupRound": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Örneğin \"Quarter-finals\", \"Final\"",
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "ties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CupTie"
+                    }
+                }
+            }
+        },
+        "models.CupTie": {
+            "type": "object",
+            "properties": {
+                "away_team_id": {
+                    "type": "integer"
+                },
+                "away_team_name": {
+                    "type": "string"
+                },
+                "cup_id": {
+                    "type": "integer"
+                },
+                "extra_time_away_goals": {
+                    "type": "integer"
+                },
+                "extra_time_home_goals": {
+                    "description": "Uzatmada atılan goller (ev sahibi takımın)",
+                    "type": "integer"
+                },
+                "first_leg_match_id": {
+                    "type": "integer"
+                },
+                "home_team_id": {
+                    "type": "integer"
+                },
+                "home_team_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "description": "Eşleşmenin maçları, oynanma sırasıyla",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Match"
+                    }
+                },
+                "penalties_away": {
+                    "type": "integer"
+                },
+                "penalties_home": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "Tur içindeki sıra",
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "second_leg_match_id": {
+                    "type": "integer"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FixtureMatch": {
+            "type": "object",
+            "properties": {
+                "away_goals": {
+                    "type": "integer"
+                },
+                "away_team_id": {
+                    "type": "integer"
+                },
+                "away_team_name": {
+                    "type": "string"
+                },
+                "cup_id": {
+                    "description": "Kupa maçlarında kupanın kimliği; lig ve sezon kimlikleri 0, Week ise tur numarasıdır",
+                    "type": "integer"
+                },
+                "group_id": {
+                    "description": "Turnuva grup maçlarında grubun kimliği; Week ise maç günüdür",
+                    "type": "integer"
+                },
+                "home_goals": {
+                    "type": "integer"
+                },
+                "home_team_id": {
+                    "type": "integer"
+                },
+                "home_team_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "boolean"
+                },
+                "season_id": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "description": "Skoru üreten rastgele sayı tohumu; maçın birebir tekrarı için saklanır. Elle girilen sonuçlarda 0'dır",
+                    "type": "integer"
+                },
+                "week": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Fixtures": {
+            "type": "object",
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FixtureMatch"
+                    }
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Ölçütlere uyan tüm maçların sayısı",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.League": {
+            "type": "object",
+            "properties": {
+                "as_of_week": {
+                    "description": "Tablo geçmiş bir hafta için istendiyse o hafta",
+                    "type": "integer"
+                },
+                "championshipPredictions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Prediction"
+                    }
+                },
+                "current_week": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_engine": {
+                    "type": "string"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Match"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "playoffs": {
+                    "description": "Sezonun play-off'u başladıysa eleme ağacı",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CupBracket"
+                        }
+                    ]
+                },
+                "season": {
+                    "description": "Tablonun ait olduğu sezonun numarası",
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "split": {
+                    "description": "Sezonun tablosu bölündüyse üst grup",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeasonSplit"
+                        }
+                    ]
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LeagueStatus"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Team"
+                    }
+                },
+                "tiebreakers": {
+                    "description": "Puan eşitliğinde sırayla uygulanan ölçütler",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_weeks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LeagueState": {
+            "type": "object",
+            "properties": {
+                "current_week": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "match_engine": {
+                    "description": "MatchEngine ligin maçlarını ve tahminlerini simüle eden motordur; boşsa sunucunun varsayılan motoru kullanılır",
+                    "type": "string",
+                    "example": "poisson"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_roster": {
+                    "description": "NextRoster ligin bir sonraki sıfırlamadan ya da sezon geçişinden itibaren oynayacak takımlarıdır; nil ise kadro değişmez",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "parent_league_id": {
+                    "description": "ParentLeagueID bir üst ligin kimliğidir; ligler bu bağlantıyla bir piramit oluşturur",
+                    "type": "integer"
+                },
+                "playoffs": {
+                    "description": "Playoffs normal sezonun ardından oynanan play-off'un biçimidir; nil ise lig play-off oynamaz",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayoffFormat"
+                        }
+                    ]
+                },
+                "promotion_places": {
+                    "description": "Sezon sonunda üst lige çıkan takım sayısı",
+                    "type": "integer"
+                },
+                "relegation_places": {
+                    "description": "Sezon sonunda alt lige düşen takım sayısı",
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LeagueStatus"
+                },
+                "swiss": {
+                    "description": "Swiss ligin fikstürünün İsviçre usulüyle üretilmesini sağlar; nil ise çift devreli lig oynanır",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SwissFormat"
+                        }
+                    ]
+                },
+                "tiebreakers": {
+                    "description": "Puan eşitliğinde sırayla uygulanan ölçütler; boşsa varsayılanlar kullanılır",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "description": "İyimser eşzamanlılık denetimi için; her güncellemede bir artar",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LeagueStatus": {
+            "type": "string",
+            "enum": [
+                "not_started",
+                "in_progress",
+                "completed",
+                "abandoned"
+            ],
+            "x-enum-varnames": [
+                "LeagueStatusNotStarted",
+                "LeagueStatusInProgress",
+                "LeagueStatusCompleted",
+                "LeagueStatusAbandoned"
+            ]
+        },
+        "models.Match": {
+            "type": "object",
+            "properties": {
+                "away_goals": {
+                    "type": "integer"
+                },
+                "away_team_id": {
+                    "type": "integer"
+                },
+                "cup_id": {
+                    "description": "Kupa maçlarında kupanın kimliği; lig ve sezon kimlikleri 0, Week ise tur numarasıdır",
+                    "type": "integer"
+                },
+                "group_id": {
+                    "description": "Turnuva grup maçlarında grubun kimliği; Week ise maç günüdür",
+                    "type": "integer"
+                },
+                "home_goals": {
+                    "type": "integer"
+                },
+                "home_team_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "played": {
+                    "type": "boolean"
+                },
+                "season_id": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "description": "Skoru üreten rastgele sayı tohumu; maçın birebir tekrarı için saklanır. Elle girilen sonuçlarda 0'dır",
+                    "type": "integer"
+                },
+                "week": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PlayoffFormat": {
+            "type": "object",
+            "properties": {
+                "first_place": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "kind": {
+                    "description": "Boşsa knockout",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayoffKind"
+                        }
+                    ],
+                    "example": "knockout"
+                },
+                "last_place": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "legs": {
+                    "description": "Final dışındaki eşleşmelerin ya da bölünmede grup içindeki eşleşmelerin maç sayısı (1 ya da 2)",
+                    "type": "integer"
+                },
+                "prize": {
+                    "description": "Bölünmede boş kalır: şampiyonu ve lig değiştiren takımları final sıralaması belirler",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayoffPrize"
+                        }
+                    ],
+                    "example": "promotion"
+                },
+                "split_place": {
+                    "description": "Bölünmede üst gruba kalan son sıra",
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "models.PlayoffKind": {
+            "type": "string",
+            "enum": [
+                "knockout",
+                "split"
+            ],
+            "x-enum-varnames": [
+                "PlayoffKindKnockout",
+                "PlayoffKindSplit"
+            ]
+        },
+        "models.PlayoffPrize": {
+            "type": "string",
+            "enum": [
+                "title",
+                "promotion"
+            ],
+            "x-enum-varnames": [
+                "PlayoffPrizeTitle",
+                "PlayoffPrizePromotion"
+            ]
+        },
+        "models.Prediction": {
+            "type": "object",
+            "properties": {
+                "championship_likelihood": {
+                    "description": "Şampiyonluk olasılığı (%)",
+                    "type": "number"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PredictionResult": {
+            "type": "object",
+            "properties": {
+                "championship_predictions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Prediction"
+                    }
+                },
+                "playoffs": {
+                    "description": "Sezonun play-off'u; şampiyonluk ve üst lige çıkma olasılıkları play-off sonucunu içerir",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayoffFormat"
+                        }
+                    ]
+                },
+                "promotion_places": {
+                    "type": "integer"
+                },
+                "relegation_places": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "simulations": {
+                    "type": "integer"
+                },
+                "teams": {
+                    "description": "Beklenen sıraya göre sıralı",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamPrediction"
+                    }
+                },
+                "top_n": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Rollover": {
+            "type": "object",
+            "properties": {
+                "moves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamMove"
+                    }
+                },
+                "seasons": {
+                    "description": "Liglerin yeni sezonları, piramidin tepesinden aşağı doğru",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Season"
+                    }
+                }
+            }
+        },
+        "models.Season": {
+            "type": "object",
+            "properties": {
+                "champion_name": {
+                    "type": "string"
+                },
+                "champion_team_id": {
+                    "type": "integer"
+                },
+                "ended_at": {
+                    "description": "Sezon arşivlendiyse kapanış zamanı",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league_id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "description": "Lig içindeki sıra numarası; ilk sezon 1'dir",
+                    "type": "integer"
+                },
+                "playoff_cup_id": {
+                    "description": "Sezonun play-off'unun oynandığı kupa",
+                    "type": "integer"
+                },
+                "playoff_prize": {
+                    "description": "Play-off başladığında geçerli olan ödül",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayoffPrize"
+                        }
+                    ]
+                },
+                "split": {
+                    "description": "Sezonun tablosu bölündüyse bölünmenin haftası ve üst grubu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeasonSplit"
+                        }
+                    ]
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LeagueStatus"
+                },
+                "tiebreakers": {
+                    "description": "Sezon kapanırken geçerli olan eşitlik ölçütleri",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.SeasonSplit": {
+            "type": "object",
+            "properties": {
+                "upper_team_ids": {
+                    "description": "Üst gruptaki takımlar, bölünmedeki sıralarıyla",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "week": {
+                    "description": "Grup maçlarının oynandığı ilk hafta",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SwissFormat": {
+            "type": "object",
+            "properties": {
+                "opponents_per_pot": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Team": {
+            "type": "object",
+            "properties": {
+                "attack": {
+                    "description": "Hücum gücü, 0 ise Strength kullanılır",
+                    "type": "integer"
+                },
+                "country": {
+                    "description": "İsviçre usulü liglerde aynı ülkeden takımlar eşleşmez",
+                    "type": "string"
+                },
+                "defense": {
+                    "description": "Savunma gücü, 0 ise Strength kullanılır",
+                    "type": "integer"
+                },
+                "draws": {
+                    "description": "Yeni eklendi",
+                    "type": "integer"
+                },
+                "fair_play_points": {
+                    "description": "Disiplin puanı (kartlar); fair-play eşitlik ölçütünde düşük olan öndedir",
+                    "type": "integer"
+                },
+                "goals_against": {
+                    "type": "integer"
                 },
                 "goals_for": {
                     "type": "integer"
@@ -207,6 +3283,10 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "league_id": {
+                    "description": "0 ise takım bir lige bağlı değildir (kupa, turnuva ya da sonraki kadrolar için)",
+                    "type": "integer"
+                },
                 "loses": {
                     "description": "Yeni eklendi",
                     "type": "integer"
@@ -220,6 +3300,10 @@ const docTemplate = `{
                 "points": {
                     "type": "integer"
                 },
+                "pot": {
+                    "description": "İsviçre usulü liglerde takımın torbası",
+                    "type": "integer"
+                },
                 "strength": {
                     "type": "integer"
                 },
@@ -228,6 +3312,271 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.TeamMove": {
+            "type": "object",
+            "properties": {
+                "from_league_id": {
+                    "type": "integer"
+                },
+                "promoted": {
+                    "description": "false ise takım küme düşmüştür",
+                    "type": "boolean"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "to_league_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TeamPrediction": {
+            "type": "object",
+            "properties": {
+                "championship_likelihood": {
+                    "type": "number"
+                },
+                "expected_points": {
+                    "type": "number"
+                },
+                "expected_position": {
+                    "type": "number"
+                },
+                "playoff_likelihood": {
+                    "description": "Play-off'a kalma olasılığı (%); bölünmede üst gruba kalma olasılığı, lig play-off oynamıyorsa 0",
+                    "type": "number"
+                },
+                "playoff_win_likelihood": {
+                    "description": "Play-off'u kazanma olasılığı (%); bölünmede 0",
+                    "type": "number"
+                },
+                "position_probabilities": {
+                    "description": "i. eleman (i+1). sırada bitirme olasılığı (%)",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "promotion_likelihood": {
+                    "description": "Üst lige çıkma olasılığı (%); üst lig yoksa 0",
+                    "type": "number"
+                },
+                "relegation_likelihood": {
+                    "description": "Küme düşme hattında bitirme olasılığı (%)",
+                    "type": "number"
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "top_n_likelihood": {
+                    "description": "İlk N sırada bitirme olasılığı (%)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.TeamStagePrediction": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "stage_probabilities": {
+                    "description": "i. eleman Stages[i] aşamasına ulaşma olasılığı (%)",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "team_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "win_likelihood": {
+                    "description": "Turnuvayı kazanma olasılığı (%)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Tournament": {
+            "type": "object",
+            "properties": {
+                "cup_id": {
+                    "description": "Eleme aşamasının kupası; grup aşaması bitene kadar nil",
+                    "type": "integer"
+                },
+                "current_round": {
+                    "description": "Grup aşamasında sıradaki maç günü",
+                    "type": "integer"
+                },
+                "group_legs": {
+                    "description": "Grup içinde iki takımın kaç kez karşılaştığı (1 ya da 2)",
+                    "type": "integer"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TournamentGroup"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "knockout": {
+                    "$ref": "#/definitions/models.CupBracket"
+                },
+                "knockout_legs": {
+                    "description": "Eleme eşleşmelerinin maç sayısı (1 ya da 2); final her zaman tek maçtır",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "$ref": "#/definitions/models.TournamentStage"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LeagueStatus"
+                },
+                "tiebreakers": {
+                    "description": "Grup sıralamasında puan eşitliğinde sırayla uygulanan ölçütler",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_rounds": {
+                    "description": "Grup aşamasının maç günü sayısı",
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "İyimser eşzamanlılık denetimi için; her güncellemede bir artar",
+                    "type": "integer"
+                },
+                "winner_team_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TournamentGroup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Match"
+                    }
+                },
+                "name": {
+                    "description": "\"A\", \"B\", ...",
+                    "type": "string"
+                },
+                "table": {
+                    "description": "Grubun sıralama kurallarına göre puan durumu",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Team"
+                    }
+                },
+                "team_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "tournament_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TournamentPrediction": {
+            "type": "object",
+            "properties": {
+                "seed": {
+                    "type": "integer"
+                },
+                "simulations": {
+                    "type": "integer"
+                },
+                "stages": {
+                    "description": "Aşamalar sırasıyla: grup aşaması, eleme turları ve şampiyonluk",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "teams": {
+                    "description": "Şampiyonluk olasılığına göre sıralı",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamStagePrediction"
+                    }
+                }
+            }
+        },
+        "models.TournamentStage": {
+            "type": "string",
+            "enum": [
+                "group",
+                "knockout"
+            ],
+            "x-enum-varnames": [
+                "TournamentStageGroup",
+                "TournamentStageKnockout"
+            ]
+        },
+        "models.WeekResults": {
+            "type": "object",
+            "properties": {
+                "league_id": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "description": "Normal sezon maçları; play-off haftalarında boştur",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FixtureMatch"
+                    }
+                },
+                "playoffs": {
+                    "$ref": "#/definitions/models.CupBracket"
+                },
+                "season": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Hafta oynandıktan sonraki sezon durumu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LeagueStatus"
+                        }
+                    ]
+                },
+                "table": {
+                    "description": "Puan sırasına göre takımlar",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Team"
+                    }
+                },
+                "week": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/services"
+	"github.com/muzaffertuna/football-league-sim/internal/pkg/logger"
+)
+
+type CupHandler struct {
+	cupSvc services.CupService
+	logger *logger.Logger
+}
+
+func NewCupHandler(cupSvc services.CupService, logger *logger.Logger) *CupHandler {
+	return &CupHandler{cupSvc: cupSvc, logger: logger}
+}
+
+// createCupRequest POST /cups isteğinin gövdesidir.
+type createCupRequest struct {
+	Name    string `json:"name" example:"Türkiye Kupası"`
+	TeamIDs []int  `json:"team_ids"` // Seri başı sırasıyla; ilk takım birinci seri başıdır
+	Legs    int    `json:"legs,omitempty" example:"2"`
+	Draw    string `json:"draw,omitempty" example:"seeded"`
+	Seed    *int64 `json:"seed,omitempty"`
+}
+
+// @Summary Kupaları listeler
+// @Description Kayıtlı tüm kupaları güncel tur, durum ve kazananlarıyla birlikte döndürür
+// @Tags cups
+// @Produce json
+// @Success 200 {array} models.Cup
+// @Failure 500 {object} problem "Internal server error"
+// @Router /cups [get]
+func (h *CupHandler) GetCups(w http.ResponseWriter, r *http.Request) {
+	cups, err := h.cupSvc.GetCups()
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get cups")
+		return
+	}
+	if cups == nil {
+		cups = []models.Cup{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cups); err != nil {
+		h.logger.Error("Failed to encode cups: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Yeni kupa oluşturur
+// @Description Verilen takımlarla eleme usulü bir kupa oluşturur. Takımlar seri başı sırasıyla verilir; takım sayısı ikinin kuvveti değilse ilk turda en iyi seri başları bay geçer. legs 1 ya da 2 olabilir (final her zaman tek maçtır), draw seeded, random ya da fixed (kurasız sabit ağaç) olabilir
+// @Tags cups
+// @Accept json
+// @Produce json
+// @Param cup body createCupRequest true "Kupa bilgileri"
+// @Success 201 {object} models.Cup
+// @Failure 400 {object} problem "Invalid request body"
+// @Failure 422 {object} problem "Invalid cup"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /cups [post]
+func (h *CupHandler) CreateCup(w http.ResponseWriter, r *http.Request) {
+	var req createCupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	cup, err := h.cupSvc.CreateCup(services.NewCup{
+		Name:    req.Name,
+		TeamIDs: req.TeamIDs,
+		Legs:    req.Legs,
+		Draw:    models.CupDraw(req.Draw),
+		Seed:    req.Seed,
+	})
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to create cup")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/cups/%d", cup.ID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(cup); err != nil {
+		h.logger.Error("Failed to encode cup: " + err.Error())
+	}
+}
+
+// @Summary Kupanın eleme ağacını getirir
+// @Description Kupanın kurası çekilmiş tüm turlarını eşleşmeler, maç skorları, uzatma ve penaltı sonuçlarıyla birlikte döndürür
+// @Tags cups
+// @Produce json
+// @Param cupID path int true "Kupa kimliği"
+// @Success 200 {object} models.CupBracket
+// @Failure 400 {object} problem "Invalid cup ID"
+// @Failure 404 {object} problem "Cup not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /cups/{cupID} [get]
+func (h *CupHandler) GetBracket(w http.ResponseWriter, r *http.Request) {
+	cupID, err := cupIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	bracket, err := h.cupSvc.GetBracket(cupID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get cup bracket")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bracket); err != nil {
+		h.logger.Error("Failed to encode cup bracket: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Sonraki turun kurasını çeker
+// @Description Güncel turun kazananları (ilk turda tüm takımlar) arasında sonraki turun kurasını çeker ve eşleşmeleri döndürür. Güncel turun tüm eşleşmeleri oynanmış olmalıdır
+// @Tags cups
+// @Produce json
+// @Param cupID path int true "Kupa kimliği"
+// @Param seed query int false "Kura için rastgele sayı tohumu"
+// @Success 200 {object} models.CupRound
+// @Failure 400 {object} problem "Invalid parameter"
+// @Failure 404 {object} problem "Cup not found"
+// @Failure 409 {object} problem "Current round is not played, cup is completed, is played by its league or tournament or was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /cups/{cupID}/draw [post]
+func (h *CupHandler) DrawNextRound(w http.ResponseWriter, r *http.Request) {
+	h.writeRound(w, r, h.cupSvc.DrawNextRound, "draw cup round")
+}
+
+// @Summary Güncel turu oynatır
+// @Description Kurası çekilmiş turun eşleşmelerini simüle eder. Toplam skor eşitse uzatma, o da eşit biterse penaltı atışları oynanır. Final oynandığında kupa tamamlanır
+// @Tags cups
+// @Produce json
+// @Param cupID path int true "Kupa kimliği"
+// @Param seed query int false "Turun maçları için rastgele sayı tohumu"
+// @Success 200 {object} models.CupRound
+// @Failure 400 {object} problem "Invalid parameter"
+// @Failure 404 {object} problem "Cup not found"
+// @Failure 409 {object} problem "Round is not drawn, cup is completed, is played by its league or tournament or was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /cups/{cupID}/play-round [post]
+func (h *CupHandler) PlayRound(w http.ResponseWriter, r *http.Request) {
+	h.writeRound(w, r, h.cupSvc.PlayRound, "play cup round")
+}
+
+// writeRound kupanın turunu değiştiren bir işlemi çalıştırır ve turun son halini yazar.
+func (h *CupHandler) writeRound(w http.ResponseWriter, r *http.Request, action func(cupID int, seed *int64) (*models.CupRound, error), name string) {
+	cupID, err := cupIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	round, err := action(cupID, seed)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to "+name)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(round); err != nil {
+		h.logger.Error("Failed to encode cup round: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// cupIDParam isteğin yolundaki "cupID" parametresini okur.
+func cupIDParam(r *http.Request) (int, error) {
+	raw := chi.URLParam(r, "cupID")
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid cup ID %q: must be a positive integer", raw)
+	}
+	return id, nil
+}
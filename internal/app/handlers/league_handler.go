@@ -2,10 +2,17 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
 	"github.com/muzaffertuna/football-league-sim/internal/app/services"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
 	"github.com/muzaffertuna/football-league-sim/internal/pkg/logger" // <--- Düzeltildi: Yeni logger paketi
 
 	// _ "github.com/muzaffertuna/football-league-sim/internal/platform" // <--- Kaldırıldı
@@ -13,6 +20,11 @@ import (
 	_ "github.com/muzaffertuna/football-league-sim/docs" // Swagger dokümantasyonu için
 )
 
+const (
+	defaultPredictionSimulations = 10000
+	maxPredictionSimulations     = 100000
+)
+
 // @title Football League Simulation API
 // @version 1.0
 // @description Premier Lig simülasyonu için REST API
@@ -28,93 +40,220 @@ func NewLeagueHandler(leagueSvc services.LeagueService, logger *logger.Logger) *
 }
 
 // @Summary Lig tablosunu getirir
-// @Description Mevcut lig tablosunu puan sırasına göre döndürür
+// @Description Lig tablosunu kayıtlı maç sonuçlarından hesaplayarak puan sırasına göre döndürür. week verilirse tablo o haftanın sonundaki haliyle döner. Şampiyonluk tahminleri yalnızca include=predictions ile istenirse hesaplanır ve lig durumu değişene kadar önbellekten sunulur
 // @Tags league
 // @Produce json
+// @Param leagueID path int true "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)"
+// @Param include query string false "Ek veriler (virgülle ayrılmış): predictions"
+// @Param seed query int false "Şampiyonluk tahminleri için rastgele sayı tohumu"
+// @Param week query int false "Puan durumunun hesaplanacağı hafta (varsayılan: son oynanan hafta)"
 // @Success 200 {object} models.League
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} problem "Invalid query parameter"
+// @Failure 404 {object} problem "League not found"
+// @Failure 500 {object} problem "Internal server error"
 // @Router /league-table [get]
+// @Router /leagues/{leagueID}/table [get]
 func (h *LeagueHandler) GetLeagueTable(w http.ResponseWriter, r *http.Request) {
-	league, err := h.leagueSvc.GetLeagueTable()
+	h.writeLeagueTable(w, r, 0)
+}
+
+// @Summary Bir sezonun puan durumunu getirir
+// @Description Ligin verilen numaralı sezonunun puan durumunu döndürür. Arşivlenmiş sezonlar kapandıkları andaki eşitlik ölçütleriyle sıralanır; şampiyonluk tahminleri yalnızca güncel sezon için hesaplanır
+// @Tags seasons
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Param season path int true "Sezon numarası"
+// @Param week query int false "Puan durumunun hesaplanacağı hafta (varsayılan: son oynanan hafta)"
+// @Success 200 {object} models.League
+// @Failure 400 {object} problem "Invalid parameter"
+// @Failure 404 {object} problem "League or season not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{season}/table [get]
+func (h *LeagueHandler) GetSeasonTable(w http.ResponseWriter, r *http.Request) {
+	season, err := strconv.Atoi(chi.URLParam(r, "season"))
+	if err != nil || season <= 0 {
+		badRequest(w, r, fmt.Errorf("invalid season %q: must be a positive integer", chi.URLParam(r, "season")))
+		return
+	}
+	h.writeLeagueTable(w, r, season)
+}
+
+// writeLeagueTable ligin verilen sezonunun (0 ise güncel sezonun) puan durumunu yazar.
+func (h *LeagueHandler) writeLeagueTable(w http.ResponseWriter, r *http.Request, season int) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	asOfWeek, err := parseIntQuery(r, "week", 0, 1, math.MaxInt32)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	opts := services.LeagueTableOptions{
+		IncludePredictions: hasInclude(r, "predictions"),
+		Seed:               seed,
+		AsOfWeek:           asOfWeek,
+		Season:             season,
+	}
+	league, err := h.leagueSvc.GetLeagueTable(r.Context(), leagueID, opts)
 	if err != nil {
-		h.logger.Error("Failed to get league table: " + err.Error())
-		http.Error(w, "Failed to get league table", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Failed to get league table")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(league); err != nil {
 		h.logger.Error("Failed to encode league table: " + err.Error())
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
 	}
 }
 
 // @Summary Mevcut haftayı oynatır
-// @Description Ligin güncel haftasını simüle eder ve ligi günceller
+// @Description Ligin güncel haftasını simüle eder ve haftanın maçlarını takım adları ve skorlarıyla, ligin güncel puan durumuyla birlikte döndürür. Play-off haftalarında maçlar yerine play-off'un eleme ağacı döner. Accept: text/plain ile eski düz metin sonuç listesi döner (play-off haftalarında oynanan turun eşleşmeleri); bu durumda tamamlanmış bir lig için de 200 döner
 // @Tags league
-// @Produce plain
-// @Success 200 {string} string "Week played successfully"
-// @Failure 500 {string} string "Internal server error"
+// @Produce json,plain
+// @Param leagueID path int true "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)"
+// @Param seed query int false "Haftanın maçları için rastgele sayı tohumu"
+// @Success 200 {object} models.WeekResults
+// @Failure 400 {object} problem "Invalid seed"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League has already completed or week was played by another request"
+// @Failure 500 {object} problem "Internal server error"
 // @Router /play-week [post]
+// @Router /leagues/{leagueID}/play-week [post]
 func (h *LeagueHandler) PlayWeek(w http.ResponseWriter, r *http.Request) {
-	week, err := h.leagueSvc.GetCurrentWeek()
+	leagueID, err := leagueIDParam(r)
 	if err != nil {
-		h.logger.Error("Failed to get current week: " + err.Error())
-		http.Error(w, "Failed to get current week", http.StatusInternalServerError)
+		badRequest(w, r, err)
 		return
 	}
 
-	if week > 6 {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("League has already completed"))
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
 		return
 	}
+	plainText := prefersPlainText(r)
 
-	if err := h.leagueSvc.PlayWeek(week); err != nil {
-		h.logger.Error("Failed to play week: " + err.Error())
-		http.Error(w, "Failed to play week", http.StatusInternalServerError)
+	week, err := h.leagueSvc.GetCurrentWeek(leagueID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get current week")
 		return
 	}
 
-	matches, err := h.leagueSvc.GetMatchesByWeek(week)
+	if err := h.leagueSvc.PlayWeek(leagueID, week, seed); err != nil {
+		// Düz metin isteyen betikler tamamlanmış lig için 200 bekler.
+		if plainText && errors.Is(err, services.ErrLeagueCompleted) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("League has already completed"))
+			return
+		}
+		writeError(w, r, h.logger, err, "Failed to play week")
+		return
+	}
+
+	results, err := h.leagueSvc.GetWeekResults(r.Context(), leagueID, week)
 	if err != nil {
-		h.logger.Error("Failed to get matches: " + err.Error())
-		http.Error(w, "Failed to get matches", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Failed to get week results")
 		return
 	}
 
-	result := fmt.Sprintf("Week %d Results:\n", week)
-	for _, match := range matches {
-		homeTeam, err := h.leagueSvc.GetTeamByID(match.HomeTeamID)
-		if err != nil {
-			h.logger.Error("Failed to get home team: " + err.Error())
-			http.Error(w, "Failed to get home team", http.StatusInternalServerError)
-			return
+	if plainText {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Week %d Results:\n", week)
+		for _, match := range results.Matches {
+			fmt.Fprintf(&sb, "%s vs %s: %d - %d\n", match.HomeTeamName, match.AwayTeamName, match.HomeGoals, match.AwayGoals)
 		}
-		awayTeam, err := h.leagueSvc.GetTeamByID(match.AwayTeamID)
-		if err != nil {
-			h.logger.Error("Failed to get away team: " + err.Error())
-			http.Error(w, "Failed to get away team", http.StatusInternalServerError)
-			return
+		// Play-off haftalarında normal sezon maçı yoktur; haftada oynanan turun eşleşmeleri yazılır.
+		if len(results.Matches) == 0 && results.Playoffs != nil {
+			if round := lastPlayedRound(results.Playoffs); round != nil {
+				writePlayoffRound(&sb, round)
+			}
 		}
-		result += fmt.Sprintf("%s vs %s: %d - %d\n", homeTeam.Name, awayTeam.Name, match.HomeGoals, match.AwayGoals)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(sb.String()))
+		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(result))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		h.logger.Error("Failed to encode week results: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// lastPlayedRound eleme ağacında tüm eşleşmeleri sonuçlanmış son turu döndürür; hiçbir tur oynanmadıysa nil döner.
+func lastPlayedRound(bracket *models.CupBracket) *models.CupRound {
+	for i := len(bracket.Rounds) - 1; i >= 0; i-- {
+		round := &bracket.Rounds[i]
+		played := len(round.Ties) > 0
+		for _, tie := range round.Ties {
+			if tie.WinnerTeamID == nil {
+				played = false
+				break
+			}
+		}
+		if played {
+			return round
+		}
+	}
+	return nil
+}
+
+// writePlayoffRound play-off turunun eşleşmelerini düz metin olarak yazar: her maçın skoru, gerekirse uzatma
+// ve penaltılar (eşleşmenin ev sahibinin bakış açısıyla) ve turu geçen takım. Bay geçen takımlar yazılmaz.
+func writePlayoffRound(sb *strings.Builder, round *models.CupRound) {
+	fmt.Fprintf(sb, "%s:\n", round.Name)
+	for _, tie := range round.Ties {
+		if tie.AwayTeamID == nil {
+			continue
+		}
+		names := map[int]string{tie.HomeTeamID: tie.HomeTeamName, *tie.AwayTeamID: tie.AwayTeamName}
+		for _, match := range tie.Matches {
+			fmt.Fprintf(sb, "%s vs %s: %d - %d\n", names[match.HomeTeamID], names[match.AwayTeamID], match.HomeGoals, match.AwayGoals)
+		}
+		if tie.ExtraTimeHomeGoals != nil && tie.ExtraTimeAwayGoals != nil {
+			fmt.Fprintf(sb, "Extra time, %s vs %s: %d - %d\n", tie.HomeTeamName, tie.AwayTeamName, *tie.ExtraTimeHomeGoals, *tie.ExtraTimeAwayGoals)
+		}
+		if tie.PenaltiesHome != nil && tie.PenaltiesAway != nil {
+			fmt.Fprintf(sb, "Penalties, %s vs %s: %d - %d\n", tie.HomeTeamName, tie.AwayTeamName, *tie.PenaltiesHome, *tie.PenaltiesAway)
+		}
+		fmt.Fprintf(sb, "Winner: %s\n", names[*tie.WinnerTeamID])
+	}
 }
 
 // @Summary Ligi sıfırlar
-// @Description Tüm takımları ve maçları sıfırlayarak ligi yeniden başlatır
+// @Description Yeni bir sezon başlatır. Güncel sezonda maç oynandıysa sezon arşivlenir ve önceki sezonların fikstürü, tabloları ve şampiyonları korunur. Ligin sonraki kadrosu ayarlandıysa yeni sezon bu kadroyla başlar
 // @Tags league
 // @Produce plain
+// @Param leagueID path int true "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)"
 // @Success 200 {string} string "League reset successfully"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} problem "Invalid league ID"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 422 {object} problem "Next roster is no longer valid"
+// @Failure 500 {object} problem "Internal server error"
 // @Router /reset-league [post]
+// @Router /leagues/{leagueID}/reset [post]
 func (h *LeagueHandler) ResetLeague(w http.ResponseWriter, r *http.Request) {
-	if err := h.leagueSvc.ResetLeague(); err != nil {
-		h.logger.Error("Failed to reset league: " + err.Error())
-		http.Error(w, "Failed to reset league", http.StatusInternalServerError)
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	if err := h.leagueSvc.ResetLeague(leagueID); err != nil {
+		writeError(w, r, h.logger, err, "Failed to reset league")
 		return
 	}
 
@@ -126,25 +265,734 @@ func (h *LeagueHandler) ResetLeague(w http.ResponseWriter, r *http.Request) {
 // @Description Ligdeki kalan tüm haftaları otomatik olarak simüle eder ve sonuçları döndürür
 // @Tags league
 // @Produce json
+// @Param leagueID path int true "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)"
+// @Param seed query int false "Kalan haftalar için rastgele sayı tohumu"
 // @Success 200 {array} models.Match "Tüm simüle edilmiş maçların sonuçları"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 400 {object} problem "Invalid seed"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League has already completed or was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
 // @Router /simulate-all-weeks [post]
+// @Router /leagues/{leagueID}/simulate-all-weeks [post]
 func (h *LeagueHandler) SimulateAllWeeks(w http.ResponseWriter, r *http.Request) {
-	simulatedMatches, err := h.leagueSvc.SimulateAllWeeks()
+	leagueID, err := leagueIDParam(r)
 	if err != nil {
-		if err.Error() == "league has already completed. current week: 7" {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("League simulation completed previously. Use /reset-league to start a new season."))
-			return
-		}
-		h.logger.Error("Failed to simulate all weeks: " + err.Error())
-		http.Error(w, "Failed to simulate all weeks", http.StatusInternalServerError)
+		badRequest(w, r, err)
+		return
+	}
+
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	simulatedMatches, err := h.leagueSvc.SimulateAllWeeks(leagueID, seed)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to simulate all weeks")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(simulatedMatches); err != nil {
 		h.logger.Error("Failed to encode simulated matches: " + err.Error())
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Sezon sonu tahminlerini getirir
+// @Description Kalan haftaları Monte Carlo yöntemiyle simüle ederek her takımın her sırada bitirme olasılığını, ilk N, küme düşme ve beklenen puan değerlerini döndürür
+// @Tags league
+// @Produce json
+// @Param leagueID path int true "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)"
+// @Param simulations query int false "Simülasyon sayısı (varsayılan 10000, en fazla 100000)"
+// @Param seed query int false "Rastgele sayı tohumu"
+// @Param top query int false "İlk N olasılığı için N"
+// @Param relegation query int false "Küme düşme hattındaki takım sayısı"
+// @Success 200 {object} models.PredictionResult
+// @Failure 400 {object} problem "Invalid query parameter"
+// @Failure 404 {object} problem "League not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /predictions [get]
+// @Router /leagues/{leagueID}/predictions [get]
+func (h *LeagueHandler) GetPredictions(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	opts := services.PredictionOptions{Simulations: defaultPredictionSimulations, Seed: seed}
+	if opts.Simulations, err = parseIntQuery(r, "simulations", defaultPredictionSimulations, 1, maxPredictionSimulations); err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	if opts.TopN, err = parseIntQuery(r, "top", 0, 1, math.MaxInt32); err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	if opts.RelegationPlaces, err = parseIntQuery(r, "relegation", 0, 1, math.MaxInt32); err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	predictions, err := h.leagueSvc.PredictOutcomes(r.Context(), leagueID, opts)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to predict outcomes")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(predictions); err != nil {
+		h.logger.Error("Failed to encode predictions: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Ligin sezonlarını listeler
+// @Description Ligin tüm sezonlarını durumları (not_started, in_progress, completed, abandoned) ve şampiyonlarıyla birlikte döndürür
+// @Tags seasons
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Success 200 {array} models.Season
+// @Failure 400 {object} problem "Invalid league ID"
+// @Failure 404 {object} problem "League not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/seasons [get]
+func (h *LeagueHandler) GetSeasons(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	seasons, err := h.leagueSvc.GetSeasons(leagueID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get seasons")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(seasons); err != nil {
+		h.logger.Error("Failed to encode seasons: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Tüm zamanlar puan durumunu getirir
+// @Description Ligin tüm sezonlarındaki oynanmış maçlardan toplam puan durumunu, takımların sezon ve şampiyonluk sayılarıyla birlikte döndürür
+// @Tags seasons
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Success 200 {object} models.AllTimeTable
+// @Failure 400 {object} problem "Invalid league ID"
+// @Failure 404 {object} problem "League not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/all-time-table [get]
+func (h *LeagueHandler) GetAllTimeTable(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	table, err := h.leagueSvc.GetAllTimeTable(leagueID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get all-time table")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(table); err != nil {
+		h.logger.Error("Failed to encode all-time table: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Fikstürü getirir
+// @Description Ligin güncel (ya da season ile seçilen) sezonunun maçlarını takım adlarıyla birlikte döndürür. Maçlar haftaya, takıma, iç saha ya da deplasmana ve oynanıp oynanmadığına göre süzülebilir. Sonuçlar sayfalanır; total ölçütlere uyan tüm maçların sayısıdır
+// @Tags league
+// @Produce json
+// @Param leagueID path int true "Lig kimliği (lig kimliği içermeyen eski uç noktada varsayılan lig)"
+// @Param season query int false "Sezon numarası (varsayılan: güncel sezon)"
+// @Param week query int false "Yalnızca bu haftanın maçları"
+// @Param team query int false "Yalnızca bu takımın maçları"
+// @Param venue query string false "team ile birlikte: home ya da away"
+// @Param played query bool false "Yalnızca oynanmış (true) ya da oynanmamış (false) maçlar"
+// @Param sort query string false "Sıralama ölçütü: week (varsayılan) ya da id"
+// @Param order query string false "Sıralama yönü: asc (varsayılan) ya da desc"
+// @Param limit query int false "Sayfadaki en fazla maç sayısı (varsayılan 50, en fazla 500)"
+// @Param offset query int false "Atlanacak maç sayısı"
+// @Success 200 {object} models.Fixtures
+// @Failure 400 {object} problem "Invalid query parameter"
+// @Failure 404 {object} problem "League or season not found"
+// @Failure 422 {object} problem "Invalid fixture query"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /fixtures [get]
+// @Router /leagues/{leagueID}/fixtures [get]
+func (h *LeagueHandler) GetFixtures(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	query, err := parseFixtureQuery(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	fixtures, err := h.leagueSvc.GetFixtures(leagueID, query)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get fixtures")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(fixtures); err != nil {
+		h.logger.Error("Failed to encode fixtures: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// parseFixtureQuery fikstür isteğinin sorgu parametrelerini okur. Değerlerin geçerliliği serviste denetlenir.
+func parseFixtureQuery(r *http.Request) (services.FixtureQuery, error) {
+	var (
+		query services.FixtureQuery
+		err   error
+	)
+	if query.Season, err = parseIntQuery(r, "season", 0, 1, math.MaxInt32); err != nil {
+		return query, err
+	}
+	if query.Week, err = parseIntQuery(r, "week", 0, 1, math.MaxInt32); err != nil {
+		return query, err
+	}
+	if query.TeamID, err = parseIntQuery(r, "team", 0, 1, math.MaxInt32); err != nil {
+		return query, err
+	}
+	if query.Limit, err = parseIntQuery(r, "limit", 0, 1, math.MaxInt32); err != nil {
+		return query, err
+	}
+	if query.Offset, err = parseIntQuery(r, "offset", 0, 0, math.MaxInt32); err != nil {
+		return query, err
+	}
+	if raw := r.URL.Query().Get("played"); raw != "" {
+		played, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, fmt.Errorf("invalid played %q: must be true or false", raw)
+		}
+		query.Played = &played
+	}
+	query.Venue = models.MatchVenue(r.URL.Query().Get("venue"))
+	query.Sort = models.MatchSort(r.URL.Query().Get("sort"))
+	switch order := r.URL.Query().Get("order"); order {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, fmt.Errorf("invalid order %q: must be asc or desc", order)
+	}
+	return query, nil
+}
+
+// matchResultRequest PUT /matches/{matchID}/result isteğinin gövdesidir.
+type matchResultRequest struct {
+	HomeGoals *int `json:"home_goals" example:"2"`
+	AwayGoals *int `json:"away_goals" example:"1"`
+}
+
+// @Summary Maç sonucunu kaydeder ya da düzeltir
+// @Description Bir lig maçının gerçek skorunu kaydeder ya da oynanmış bir maçın skorunu düzeltir. Puan durumu yeni sonuca göre yeniden hesaplanır (eski sonuç geri alınır) ve şampiyonluk tahminleri geçersiz kılınır. Sonucu girilen maç, haftası oynatılırken simüle edilmez. Yalnızca güncel sezonun normal sezon maçları değiştirilebilir
+// @Tags league
+// @Accept json
+// @Produce json
+// @Param matchID path int true "Maç kimliği"
+// @Param result body matchResultRequest true "Skor"
+// @Success 200 {object} models.Match
+// @Failure 400 {object} problem "Invalid match ID or request body"
+// @Failure 422 {object} problem "Invalid result"
+// @Failure 404 {object} problem "Match not found"
+// @Failure 409 {object} problem "Result can no longer be changed or league was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /matches/{matchID}/result [put]
+func (h *LeagueHandler) SetMatchResult(w http.ResponseWriter, r *http.Request) {
+	matchID, err := strconv.Atoi(chi.URLParam(r, "matchID"))
+	if err != nil || matchID <= 0 {
+		badRequest(w, r, fmt.Errorf("invalid match ID %q: must be a positive integer", chi.URLParam(r, "matchID")))
+		return
+	}
+	var req matchResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.HomeGoals == nil || req.AwayGoals == nil {
+		writeError(w, r, h.logger, fmt.Errorf("%w: home_goals and away_goals are required", services.ErrInvalidResult), "Failed to set match result")
+		return
+	}
+
+	match, err := h.leagueSvc.SetMatchResult(matchID, *req.HomeGoals, *req.AwayGoals)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to set match result")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(match); err != nil {
+		h.logger.Error("Failed to encode match: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// createLeagueRequest POST /leagues isteğinin gövdesidir.
+type createLeagueRequest struct {
+	Name        string                `json:"name" example:"Süper Lig"`
+	Teams       []createTeamRequest   `json:"teams"`
+	Tiebreakers []string              `json:"tiebreakers,omitempty" example:"head_to_head_points,goal_difference"`
+	Seed        *int64                `json:"seed,omitempty"`
+	Playoffs    *models.PlayoffFormat `json:"playoffs,omitempty"`
+	Swiss       *models.SwissFormat   `json:"swiss,omitempty"`
+	MatchEngine string                `json:"match_engine,omitempty" example:"poisson"`
+	leagueTierRequest
+}
+
+// leagueTierRequest bir ligin piramitteki yerini belirten istek alanlarıdır.
+type leagueTierRequest struct {
+	ParentLeagueID   *int `json:"parent_league_id,omitempty"`
+	PromotionPlaces  int  `json:"promotion_places,omitempty" example:"2"`
+	RelegationPlaces int  `json:"relegation_places,omitempty" example:"3"`
+}
+
+func (r leagueTierRequest) tier() services.LeagueTier {
+	return services.LeagueTier{ParentLeagueID: r.ParentLeagueID, PromotionPlaces: r.PromotionPlaces, RelegationPlaces: r.RelegationPlaces}
+}
+
+type createTeamRequest struct {
+	Name     string `json:"name" example:"Galatasaray"`
+	Strength int    `json:"strength" example:"85"`
+	Attack   int    `json:"attack,omitempty"`
+	Defense  int    `json:"defense,omitempty"`
+	Pot      int    `json:"pot,omitempty" example:"1"`       // İsviçre usulü liglerde zorunludur
+	Country  string `json:"country,omitempty" example:"TUR"` // İsviçre usulü liglerde aynı ülkeden takımlar eşleşmez
+}
+
+func (t createTeamRequest) newTeam() services.NewTeam {
+	return services.NewTeam{Name: t.Name, Strength: t.Strength, Attack: t.Attack, Defense: t.Defense, Pot: t.Pot, Country: t.Country}
+}
+
+// @Summary Ligleri listeler
+// @Description Kayıtlı tüm ligleri güncel hafta, sezon durumu ve kurallarıyla birlikte döndürür
+// @Tags league
+// @Produce json
+// @Success 200 {array} models.LeagueState
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues [get]
+func (h *LeagueHandler) GetLeagues(w http.ResponseWriter, r *http.Request) {
+	leagues, err := h.leagueSvc.GetLeagues()
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get leagues")
+		return
+	}
+	if leagues == nil {
+		leagues = []models.LeagueState{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(leagues); err != nil {
+		h.logger.Error("Failed to encode leagues: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Yeni lig oluşturur
+// @Description Verilen takımlarla yeni bir lig oluşturur ve çift devreli fikstürünü hazırlar. Eşitlik ölçütleri ya da tohum verilmezse sunucunun varsayılanları kullanılır. playoffs verilirse normal sezonun sonunda play-off oynanır. swiss verilirse fikstür İsviçre usulüyle üretilir: her takım her torbadan opponents_per_pot rakiple bir kez karşılaşır, aynı ülkeden takımlar eşleşmez ve iç saha ile deplasman maçları dengelenir. Bu durumda her takımın pot değeri olmalı, torbalar eşit büyüklükte ve takım sayısı çift olmalıdır; eşitlik ölçütleri verilmezse UEFA'nın lig aşaması ölçütleri kullanılır
+// @Tags league
+// @Accept json
+// @Produce json
+// @Param league body createLeagueRequest true "Lig bilgileri"
+// @Success 201 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid request body"
+// @Failure 422 {object} problem "Invalid league"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues [post]
+func (h *LeagueHandler) CreateLeague(w http.ResponseWriter, r *http.Request) {
+	var req createLeagueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	input := services.NewLeague{Name: req.Name, Seed: req.Seed, Tier: req.tier(), Playoffs: req.Playoffs, Swiss: req.Swiss, MatchEngine: req.MatchEngine}
+	if req.Tiebreakers != nil {
+		tiebreakers, err := standings.ParseTiebreakers(strings.Join(req.Tiebreakers, ","))
+		if err != nil {
+			writeError(w, r, h.logger, fmt.Errorf("%w: %v", services.ErrInvalidLeague, err), "Failed to create league")
+			return
+		}
+		input.Tiebreakers = tiebreakers
+	}
+	for _, t := range req.Teams {
+		input.Teams = append(input.Teams, t.newTeam())
+	}
+
+	league, err := h.leagueSvc.CreateLeague(input)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to create league")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/leagues/%d/table", league.ID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		h.logger.Error("Failed to encode league: " + err.Error())
+	}
+}
+
+// @Summary Ligin piramitteki yerini ayarlar
+// @Description Ligi bir üst lige bağlar (parent_league_id verilmezse bağlantıyı kaldırır) ve sezon sonunda üst lige çıkan ve alt lige düşen takım sayılarını ayarlar. Her ligin en fazla bir alt ligi olabilir
+// @Tags pyramid
+// @Accept json
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Param tier body leagueTierRequest true "Piramit ayarları"
+// @Success 200 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid league ID or request body"
+// @Failure 422 {object} problem "Invalid tier"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/tier [put]
+func (h *LeagueHandler) SetLeagueTier(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	var req leagueTierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	league, err := h.leagueSvc.SetLeagueTier(leagueID, req.tier())
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to set league tier")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		h.logger.Error("Failed to encode league: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Ligin play-off biçimini ayarlar
+// @Description Normal sezonun sonunda first_place ile last_place arasındaki sıralarda bitiren takımların oynayacağı eleme usulü play-off'u ayarlar. Sıralamada üstte bitiren takım seri başıdır ve en alttaki takımla eşleşir; takım sayısı ikinin kuvveti değilse en iyi seri başları ilk turu bay geçer. prize "title" ise play-off şampiyonu belirler, "promotion" ise son üst lige çıkma yerini belirler (ilk promotion_places-1 takım doğrudan çıkar). legs 1 ya da 2 olabilir (final her zaman tek maçtır). Yeni biçim, play-off'u başlamamış sezonun sonunda uygulanır
+// @Tags league
+// @Accept json
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Param playoffs body models.PlayoffFormat true "Play-off biçimi"
+// @Success 200 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid league ID or request body"
+// @Failure 422 {object} problem "Invalid playoffs"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/playoffs [put]
+func (h *LeagueHandler) SetLeaguePlayoffs(w http.ResponseWriter, r *http.Request) {
+	var format models.PlayoffFormat
+	if err := json.NewDecoder(r.Body).Decode(&format); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	h.writeLeaguePlayoffs(w, r, &format)
+}
+
+// @Summary Ligin play-off'unu kaldırır
+// @Description Lig, play-off'u başlamamış sezonun sonunda play-off oynamadan tamamlanır
+// @Tags league
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Success 200 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid league ID"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/playoffs [delete]
+func (h *LeagueHandler) DeleteLeaguePlayoffs(w http.ResponseWriter, r *http.Request) {
+	h.writeLeaguePlayoffs(w, r, nil)
+}
+
+// writeLeaguePlayoffs play-off uç noktalarının ortak gövdesidir; format nil ise play-off kaldırılır.
+func (h *LeagueHandler) writeLeaguePlayoffs(w http.ResponseWriter, r *http.Request, format *models.PlayoffFormat) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	league, err := h.leagueSvc.SetLeaguePlayoffs(leagueID, format)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to set league playoffs")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		h.logger.Error("Failed to encode league: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// leagueRosterRequest PUT /leagues/{leagueID}/roster isteğinin gövdesidir.
+type leagueRosterRequest struct {
+	TeamIDs []int `json:"team_ids"`
+}
+
+// @Summary Ligin sonraki kadrosunu ayarlar
+// @Description Ligin bir sonraki sıfırlamadan itibaren oynayacağı takımları ayarlar. Güncel sezonun fikstürü ve puan durumu değişmez; kadroda olmayan takımlar sıfırlamada ligden ayrılır, kadrodaki bağımsız takımlar lige katılır. Takımlar bağımsız olmalı ya da zaten bu ligde oynamalıdır; İsviçre usulü liglerde her takımın torbası olmalıdır
+// @Tags league
+// @Accept json
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Param roster body leagueRosterRequest true "Kadrodaki takımların kimlikleri"
+// @Success 200 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid league ID or request body"
+// @Failure 422 {object} problem "Invalid roster"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/roster [put]
+func (h *LeagueHandler) SetLeagueRoster(w http.ResponseWriter, r *http.Request) {
+	var req leagueRosterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.TeamIDs == nil {
+		req.TeamIDs = []int{}
+	}
+	h.writeLeagueRoster(w, r, req.TeamIDs)
+}
+
+// @Summary Ligin bekleyen kadro değişikliğini iptal eder
+// @Description Lig, sıfırlamada mevcut takımlarıyla devam eder
+// @Tags league
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Success 200 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid league ID"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/roster [delete]
+func (h *LeagueHandler) DeleteLeagueRoster(w http.ResponseWriter, r *http.Request) {
+	h.writeLeagueRoster(w, r, nil)
+}
+
+// writeLeagueRoster kadro uç noktalarının ortak gövdesidir; teamIDs nil ise bekleyen kadro iptal edilir.
+func (h *LeagueHandler) writeLeagueRoster(w http.ResponseWriter, r *http.Request, teamIDs []int) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	league, err := h.leagueSvc.SetLeagueRoster(leagueID, teamIDs)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to set league roster")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		h.logger.Error("Failed to encode league: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// leagueEngineRequest PUT /leagues/{leagueID}/engine isteğinin gövdesidir.
+type leagueEngineRequest struct {
+	MatchEngine string `json:"match_engine" example:"dixon-coles"`
+}
+
+// @Summary Ligin maç motorunu ayarlar
+// @Description Ligin maçlarını ve tahminlerini simüle eden motoru seçer: legacy, poisson ya da dixon-coles. match_engine boşsa lig sunucunun varsayılan motorunu (MATCH_ENGINE) kullanır. Yeni motor sonraki maçlarda ve tahminlerde kullanılır; oynanmış maçlar değişmez
+// @Tags league
+// @Accept json
+// @Produce json
+// @Param leagueID path int true "Lig kimliği"
+// @Param engine body leagueEngineRequest true "Maç motoru"
+// @Success 200 {object} models.LeagueState
+// @Failure 400 {object} problem "Invalid league ID or request body"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "League was changed by another request"
+// @Failure 422 {object} problem "Unknown match engine"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/engine [put]
+func (h *LeagueHandler) SetLeagueMatchEngine(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	var req leagueEngineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	league, err := h.leagueSvc.SetLeagueMatchEngine(leagueID, req.MatchEngine)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to set league match engine")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		h.logger.Error("Failed to encode league: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Piramitte sezon geçişi yapar
+// @Description Ligin bulunduğu piramidin tüm liglerinde sezonu kapatır, takımları üst lige çıkarır ya da alt lige düşürür ve her lig için yeni sezonun fikstürünü oluşturur. Sonraki kadrosu ayarlanan liglerde yeni sezon bu kadroyla başlar. Piramitteki tüm liglerin sezonu tamamlanmış olmalıdır
+// @Tags pyramid
+// @Produce json
+// @Param leagueID path int true "Piramitteki herhangi bir ligin kimliği"
+// @Success 200 {object} models.Rollover
+// @Failure 400 {object} problem "Invalid league ID"
+// @Failure 422 {object} problem "Invalid pyramid configuration or next roster"
+// @Failure 404 {object} problem "League not found"
+// @Failure 409 {object} problem "Season is not completed or league was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /leagues/{leagueID}/rollover [post]
+func (h *LeagueHandler) RolloverSeason(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := leagueIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	rollover, err := h.leagueSvc.RolloverSeason(leagueID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to roll over season")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rollover); err != nil {
+		h.logger.Error("Failed to encode rollover: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// leagueIDParam isteğin yolundaki "leagueID" parametresini okur. Lig kimliği içermeyen eski uç noktalarda
+// services.DefaultLeagueID döner.
+func leagueIDParam(r *http.Request) (int, error) {
+	raw := chi.URLParam(r, "leagueID")
+	if raw == "" {
+		return services.DefaultLeagueID, nil
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid league ID %q: must be a positive integer", raw)
+	}
+	return id, nil
+}
+
+// parseSeed isteğin "seed" sorgu parametresini okur. Parametre yoksa nil döner.
+func parseSeed(r *http.Request) (*int64, error) {
+	raw := r.URL.Query().Get("seed")
+	if raw == "" {
+		return nil, nil
+	}
+	seed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed %q: must be an integer", raw)
+	}
+	return &seed, nil
+}
+
+// parseIntQuery bir tamsayı sorgu parametresini okur ve [minValue, maxValue] aralığında olduğunu doğrular.
+// Parametre yoksa defaultValue döner.
+func parseIntQuery(r *http.Request, name string, defaultValue, minValue, maxValue int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < minValue || value > maxValue {
+		return 0, fmt.Errorf("invalid %s %q: must be an integer between %d and %d", name, raw, minValue, maxValue)
+	}
+	return value, nil
+}
+
+// prefersPlainText isteğin Accept başlığı düz metni JSON'dan daha yüksek bir öncelikle istiyorsa true döner.
+// Accept başlığı yoksa ya da iki tür eşit öncelikliyse JSON seçilir.
+func prefersPlainText(r *http.Request) bool {
+	return acceptQuality(r, "text/plain") > acceptQuality(r, "application/json")
+}
+
+// acceptQuality Accept başlığında mediaType'a uyan en belirgin aralığın q değerini döndürür; uyan bir aralık
+// yoksa 0 döner.
+func acceptQuality(r *http.Request, mediaType string) float64 {
+	typ, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, -1
+	for _, header := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(header, ",") {
+			params := strings.Split(part, ";")
+			accepted := strings.ToLower(strings.TrimSpace(params[0]))
+
+			var s int
+			switch accepted {
+			case mediaType:
+				s = 2
+			case typ + "/*":
+				s = 1
+			case "*/*":
+				s = 0
+			default:
+				continue
+			}
+			if s < specificity {
+				continue
+			}
+
+			q := 1.0
+			for _, param := range params[1:] {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(name, "q") {
+					if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+			quality, specificity = q, s
+		}
+	}
+	return quality
+}
+
+// hasInclude virgülle ayrılmış "include" sorgu parametresinde verilen değerin olup olmadığını kontrol eder.
+func hasInclude(r *http.Request, value string) bool {
+	for _, v := range r.URL.Query()["include"] {
+		for _, part := range strings.Split(v, ",") {
+			if strings.TrimSpace(part) == value {
+				return true
+			}
+		}
 	}
+	return false
 }
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/services"
+	"github.com/muzaffertuna/football-league-sim/internal/pkg/logger"
+)
+
+// problem RFC 7807 hata gövdesidir. Type her zaman "about:blank" olduğundan Title durum kodunun adıdır;
+// hatanın türü Code ile ayırt edilir.
+type problem struct {
+	Type     string `json:"type" example:"about:blank"`
+	Title    string `json:"title" example:"Not Found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail,omitempty" example:"league not found: 7"`
+	Instance string `json:"instance,omitempty" example:"/leagues/7/table"`
+	Code     string `json:"code,omitempty" example:"league_not_found"` // Alan hatalarında services.Error.Code
+}
+
+// Servis hatası olmayan hataların kodları.
+const (
+	codeBadRequest       = "bad_request"
+	codeInternalError    = "internal_error"
+	codeNotFound         = "not_found"
+	codeMethodNotAllowed = "method_not_allowed"
+)
+
+// writeProblem hatayı application/problem+json olarak yazar.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Code:     code,
+	})
+}
+
+// badRequest ayrıştırılamayan bir isteği (geçersiz yol ya da sorgu parametresi, bozuk gövde) 400 ile yanıtlar.
+func badRequest(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, http.StatusBadRequest, codeBadRequest, err.Error())
+}
+
+// writeError servisin döndürdüğü hatayı yanıtlar. Alan hataları türlerine göre 404, 422 ya da 409 ile ve
+// hatanın ayrıntılı mesajıyla yanıtlanır. Diğer hatalar loglanır ve ayrıntı verilmeden 500 ile yanıtlanır;
+// action hem logun hem yanıtın mesajıdır (örneğin "Failed to play week").
+func writeError(w http.ResponseWriter, r *http.Request, log *logger.Logger, err error, action string) {
+	var svcErr *services.Error
+	if !errors.As(err, &svcErr) {
+		log.Error(action + ": " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, action)
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch svcErr.Kind {
+	case services.KindNotFound:
+		status = http.StatusNotFound
+	case services.KindInvalid:
+		status = http.StatusUnprocessableEntity
+	case services.KindConflict:
+		status = http.StatusConflict
+	}
+	writeProblem(w, r, status, svcErr.Code, err.Error())
+}
+
+// ProblemHandler yönlendiricinin kendi ürettiği hataları (bilinmeyen yol, desteklenmeyen metot, panik) da
+// diğer hatalar gibi application/problem+json olarak yanıtlar.
+type ProblemHandler struct {
+	logger *logger.Logger
+}
+
+func NewProblemHandler(logger *logger.Logger) *ProblemHandler {
+	return &ProblemHandler{logger: logger}
+}
+
+// NotFound hiçbir yola uymayan istekleri 404 ile yanıtlar.
+func (h *ProblemHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusNotFound, codeNotFound, fmt.Sprintf("no endpoint at %s", r.URL.Path))
+}
+
+// MethodNotAllowed yolu bulunan ama metodu desteklenmeyen istekleri 405 ile yanıtlar. Allow başlığını
+// yönlendirici yazar.
+func (h *ProblemHandler) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusMethodNotAllowed, codeMethodNotAllowed, fmt.Sprintf("method %s is not allowed at %s", r.Method, r.URL.Path))
+}
+
+// Recoverer bir handler'da oluşan paniği loglar ve isteği ayrıntı vermeden 500 ile yanıtlar. İstemci
+// bağlantıyı kapattığı için kesilen istekler (http.ErrAbortHandler) ve bağlantısı yükseltilen istekler
+// yanıtlanmaz.
+func (h *ProblemHandler) Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+			if rvr == http.ErrAbortHandler {
+				panic(rvr)
+			}
+			h.logger.Error(fmt.Sprintf("Panic serving %s %s: %v\n%s", r.Method, r.URL.Path, rvr, debug.Stack()))
+			if r.Header.Get("Connection") != "Upgrade" {
+				writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Internal server error")
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
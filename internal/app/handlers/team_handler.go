@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/services"
+	"github.com/muzaffertuna/football-league-sim/internal/pkg/logger"
+)
+
+type TeamHandler struct {
+	teamSvc services.TeamService
+	logger  *logger.Logger
+}
+
+func NewTeamHandler(teamSvc services.TeamService, logger *logger.Logger) *TeamHandler {
+	return &TeamHandler{teamSvc: teamSvc, logger: logger}
+}
+
+// @Summary Takımları listeler
+// @Description Kayıtlı tüm takımları ligleri, güçleri ve puan durumu sayaçlarıyla birlikte döndürür. Bir lige bağlı olmayan takımların league_id değeri 0'dır
+// @Tags teams
+// @Produce json
+// @Success 200 {array} models.Team
+// @Failure 500 {object} problem "Internal server error"
+// @Router /teams [get]
+func (h *TeamHandler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.teamSvc.GetAllTeams()
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get teams")
+		return
+	}
+	if teams == nil {
+		teams = []models.Team{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(teams); err != nil {
+		h.logger.Error("Failed to encode teams: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Yeni takım oluşturur
+// @Description Bir lige bağlı olmayan yeni bir takım oluşturur. Takım kupalarda ve turnuvalarda hemen kullanılabilir; bir lige PUT /leagues/{leagueID}/roster ile katılır. Takım adları benzersizdir; strength 1-100 arasında olmalıdır, attack ve defense verilmezse strength kullanılır
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param team body createTeamRequest true "Takım bilgileri"
+// @Success 201 {object} models.Team
+// @Failure 400 {object} problem "Invalid request body"
+// @Failure 422 {object} problem "Invalid team"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /teams [post]
+func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req createTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	team, err := h.teamSvc.CreateTeam(req.newTeam())
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to create team")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/teams/%d", team.ID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		h.logger.Error("Failed to encode team: " + err.Error())
+	}
+}
+
+// @Summary Takımı getirir
+// @Tags teams
+// @Produce json
+// @Param teamID path int true "Takım kimliği"
+// @Success 200 {object} models.Team
+// @Failure 400 {object} problem "Invalid team ID"
+// @Failure 404 {object} problem "Team not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /teams/{teamID} [get]
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	teamID, err := teamIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	team, err := h.teamSvc.GetTeamByID(teamID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get team")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		h.logger.Error("Failed to encode team: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Takımı günceller
+// @Description Takımın adını, güçlerini, torbasını ve ülkesini günceller. Yeni güçler sonraki maçlardan ve tahminlerden itibaren kullanılır; takımın ligi ve puan durumu değişmez
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param teamID path int true "Takım kimliği"
+// @Param team body createTeamRequest true "Takım bilgileri"
+// @Success 200 {object} models.Team
+// @Failure 400 {object} problem "Invalid team ID or request body"
+// @Failure 422 {object} problem "Invalid team"
+// @Failure 404 {object} problem "Team not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /teams/{teamID} [put]
+func (h *TeamHandler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	teamID, err := teamIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+	var req createTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	team, err := h.teamSvc.UpdateTeam(teamID, req.newTeam())
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to update team")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		h.logger.Error("Failed to encode team: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Takımı siler
+// @Description Fikstürde maçı olan, bir ligde oynayan ya da bir ligin sonraki kadrosunda, bir kupada veya turnuvada yer alan takımlar silinemez
+// @Tags teams
+// @Param teamID path int true "Takım kimliği"
+// @Success 204
+// @Failure 400 {object} problem "Invalid team ID"
+// @Failure 404 {object} problem "Team not found"
+// @Failure 409 {object} problem "Team is in use"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /teams/{teamID} [delete]
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	teamID, err := teamIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	if err := h.teamSvc.DeleteTeam(teamID); err != nil {
+		writeError(w, r, h.logger, err, "Failed to delete team")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// teamIDParam isteğin yolundaki "teamID" parametresini okur.
+func teamIDParam(r *http.Request) (int, error) {
+	raw := chi.URLParam(r, "teamID")
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid team ID %q: must be a positive integer", raw)
+	}
+	return id, nil
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/services"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+	"github.com/muzaffertuna/football-league-sim/internal/pkg/logger"
+)
+
+type TournamentHandler struct {
+	tournamentSvc services.TournamentService
+	logger        *logger.Logger
+}
+
+func NewTournamentHandler(tournamentSvc services.TournamentService, logger *logger.Logger) *TournamentHandler {
+	return &TournamentHandler{tournamentSvc: tournamentSvc, logger: logger}
+}
+
+// createTournamentRequest POST /tournaments isteğinin gövdesidir.
+type createTournamentRequest struct {
+	Name         string   `json:"name" example:"Dünya Kupası"`
+	Groups       [][]int  `json:"groups"` // Her grubun takım kimlikleri; grup sayısı ikinin kuvveti olmalıdır
+	GroupLegs    int      `json:"group_legs,omitempty" example:"1"`
+	KnockoutLegs int      `json:"knockout_legs,omitempty" example:"1"`
+	Tiebreakers  []string `json:"tiebreakers,omitempty" example:"goal_difference,goals_for,head_to_head"`
+	Seed         *int64   `json:"seed,omitempty"`
+}
+
+// @Summary Turnuvaları listeler
+// @Description Kayıtlı tüm turnuvaları aşama, durum ve kazananlarıyla birlikte döndürür
+// @Tags tournaments
+// @Produce json
+// @Success 200 {array} models.Tournament
+// @Failure 500 {object} problem "Internal server error"
+// @Router /tournaments [get]
+func (h *TournamentHandler) GetTournaments(w http.ResponseWriter, r *http.Request) {
+	tournaments, err := h.tournamentSvc.GetTournaments()
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get tournaments")
+		return
+	}
+	if tournaments == nil {
+		tournaments = []models.Tournament{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tournaments); err != nil {
+		h.logger.Error("Failed to encode tournaments: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Yeni turnuva oluşturur
+// @Description Verilen gruplarla bir turnuva oluşturur ve grup fikstürlerini hazırlar. Grup sayısı 2 ile 16 arasında ikinin kuvveti olmalıdır; her gruptan birinci ve ikinci eleme aşamasına kalır. group_legs ve knockout_legs 1 ya da 2 olabilir (final her zaman tek maçtır)
+// @Tags tournaments
+// @Accept json
+// @Produce json
+// @Param tournament body createTournamentRequest true "Turnuva bilgileri"
+// @Success 201 {object} models.Tournament
+// @Failure 400 {object} problem "Invalid request body"
+// @Failure 422 {object} problem "Invalid tournament"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /tournaments [post]
+func (h *TournamentHandler) CreateTournament(w http.ResponseWriter, r *http.Request) {
+	var req createTournamentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		badRequest(w, r, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	input := services.NewTournament{
+		Name:         req.Name,
+		Groups:       req.Groups,
+		GroupLegs:    req.GroupLegs,
+		KnockoutLegs: req.KnockoutLegs,
+		Seed:         req.Seed,
+	}
+	if req.Tiebreakers != nil {
+		tiebreakers, err := standings.ParseTiebreakers(strings.Join(req.Tiebreakers, ","))
+		if err != nil {
+			writeError(w, r, h.logger, fmt.Errorf("%w: %v", services.ErrInvalidTournament, err), "Failed to create tournament")
+			return
+		}
+		input.Tiebreakers = tiebreakers
+	}
+
+	tournament, err := h.tournamentSvc.CreateTournament(input)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to create tournament")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/tournaments/%d", tournament.ID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(tournament); err != nil {
+		h.logger.Error("Failed to encode tournament: " + err.Error())
+	}
+}
+
+// @Summary Turnuvanın ayrıntılarını getirir
+// @Description Turnuvayı grupların puan durumları ve maçları ile (grup aşaması bittiyse) eleme ağacıyla birlikte döndürür
+// @Tags tournaments
+// @Produce json
+// @Param tournamentID path int true "Turnuva kimliği"
+// @Success 200 {object} models.Tournament
+// @Failure 400 {object} problem "Invalid tournament ID"
+// @Failure 404 {object} problem "Tournament not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /tournaments/{tournamentID} [get]
+func (h *TournamentHandler) GetTournament(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := tournamentIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	tournament, err := h.tournamentSvc.GetTournament(tournamentID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to get tournament")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tournament); err != nil {
+		h.logger.Error("Failed to encode tournament: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Turnuvanın sıradaki turunu oynatır
+// @Description Grup aşamasında tüm grupların sıradaki maç gününü oynatır; son maç günüyle birlikte grup birincileri ve ikincileri arasında eleme kurası çekilir (ilk turda aynı gruptan takımlar eşleşmez). Eleme aşamasında güncel tur oynatılır ve sonraki turun kurası çekilir
+// @Tags tournaments
+// @Produce json
+// @Param tournamentID path int true "Turnuva kimliği"
+// @Param seed query int false "Turun maçları ve kura için rastgele sayı tohumu"
+// @Success 200 {object} models.Tournament
+// @Failure 400 {object} problem "Invalid parameter"
+// @Failure 404 {object} problem "Tournament not found"
+// @Failure 409 {object} problem "Tournament is completed or was changed by another request"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /tournaments/{tournamentID}/play-round [post]
+func (h *TournamentHandler) PlayNextRound(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := tournamentIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	tournament, err := h.tournamentSvc.PlayNextRound(tournamentID, seed)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to play tournament round")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tournament); err != nil {
+		h.logger.Error("Failed to encode tournament: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// @Summary Turnuva tahminlerini getirir
+// @Description Turnuvanın kalanını Monte Carlo yöntemiyle simüle ederek her takımın grup aşamasından sonraki her tura ulaşma ve turnuvayı kazanma olasılıklarını döndürür
+// @Tags tournaments
+// @Produce json
+// @Param tournamentID path int true "Turnuva kimliği"
+// @Param simulations query int false "Simülasyon sayısı (varsayılan 10000, en fazla 100000)"
+// @Param seed query int false "Rastgele sayı tohumu"
+// @Success 200 {object} models.TournamentPrediction
+// @Failure 400 {object} problem "Invalid query parameter"
+// @Failure 404 {object} problem "Tournament not found"
+// @Failure 500 {object} problem "Internal server error"
+// @Router /tournaments/{tournamentID}/predictions [get]
+func (h *TournamentHandler) GetPredictions(w http.ResponseWriter, r *http.Request) {
+	tournamentID, err := tournamentIDParam(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	seed, err := parseSeed(r)
+	if err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	opts := services.PredictionOptions{Seed: seed}
+	if opts.Simulations, err = parseIntQuery(r, "simulations", defaultPredictionSimulations, 1, maxPredictionSimulations); err != nil {
+		badRequest(w, r, err)
+		return
+	}
+
+	predictions, err := h.tournamentSvc.PredictStages(r.Context(), tournamentID, opts)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Failed to predict tournament")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(predictions); err != nil {
+		h.logger.Error("Failed to encode tournament predictions: " + err.Error())
+		writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "Failed to encode response")
+	}
+}
+
+// tournamentIDParam isteğin yolundaki "tournamentID" parametresini okur.
+func tournamentIDParam(r *http.Request) (int, error) {
+	raw := chi.URLParam(r, "tournamentID")
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid tournament ID %q: must be a positive integer", raw)
+	}
+	return id, nil
+}
@@ -0,0 +1,75 @@
+package models
+
+// CupDraw kupa eşleşmelerinin nasıl çekileceğidir.
+type CupDraw string
+
+const (
+	// CupDrawSeeded her eşleşmede bir seri başı (ilk yarı) takımı bir seri başı olmayan takımla eşleştirir.
+	CupDrawSeeded CupDraw = "seeded"
+	// CupDrawRandom tüm takımları serbest kurayla eşleştirir.
+	CupDrawRandom CupDraw = "random"
+	// CupDrawFixed kurasız bir ağaçtır: en iyi seri başı en kötüsüyle, ikinci en iyi sondan ikinciyle eşleşir.
+	// Play-off'larda olduğu gibi sıralamada üstte bitiren takım ilk maçı evinde oynar.
+	CupDrawFixed CupDraw = "fixed"
+)
+
+// CupOwner kupayı oynatan organizasyondur. Sahibi olan bir kupa yalnızca sahibi üzerinden oynatılır; kupa uç
+// noktaları onun kurasını çekemez ve turunu oynatamaz.
+type CupOwner string
+
+const (
+	// CupOwnerLeague ligin play-off kupasıdır; turları ligin haftalarıyla oynanır.
+	CupOwnerLeague CupOwner = "league"
+	// CupOwnerTournament turnuvanın eleme aşamasıdır; turları turnuvanın turlarıyla oynanır.
+	CupOwnerTournament CupOwner = "tournament"
+)
+
+// Cup eleme usulü oynanan bir kupa organizasyonudur.
+type Cup struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	Legs         int          `json:"legs"` // Eşleşme başına maç sayısı (1 ya da 2); final her zaman tek maçtır
+	Draw         CupDraw      `json:"draw"`
+	Seed         *int64       `json:"seed,omitempty"`
+	Owner        CupOwner     `json:"owner,omitempty"` // Boşsa kupa bağımsızdır ve kupa uç noktalarıyla oynanır
+	Status       LeagueStatus `json:"status"`
+	CurrentRound int          `json:"current_round"` // Kurası çekilmiş son tur; 0 ise henüz kura çekilmemiştir
+	TotalRounds  int          `json:"total_rounds"`
+	TeamIDs      []int        `json:"team_ids"` // Seri başı sırasına göre katılımcılar
+	WinnerTeamID *int         `json:"winner_team_id,omitempty"`
+	Version      int          `json:"version"` // İyimser eşzamanlılık denetimi için; her güncellemede bir artar
+}
+
+// CupTie bir kupa turundaki iki takım arasındaki eşleşmedir. AwayTeamID nil ise ev sahibi takım turu
+// maç oynamadan geçer (bay).
+type CupTie struct {
+	ID                 int     `json:"id"`
+	CupID              int     `json:"cup_id"`
+	Round              int     `json:"round"`
+	Position           int     `json:"position"` // Tur içindeki sıra
+	HomeTeamID         int     `json:"home_team_id"`
+	AwayTeamID         *int    `json:"away_team_id,omitempty"`
+	FirstLegMatchID    *int    `json:"first_leg_match_id,omitempty"`
+	SecondLegMatchID   *int    `json:"second_leg_match_id,omitempty"`
+	ExtraTimeHomeGoals *int    `json:"extra_time_home_goals,omitempty"` // Uzatmada atılan goller (ev sahibi takımın)
+	ExtraTimeAwayGoals *int    `json:"extra_time_away_goals,omitempty"`
+	PenaltiesHome      *int    `json:"penalties_home,omitempty"`
+	PenaltiesAway      *int    `json:"penalties_away,omitempty"`
+	WinnerTeamID       *int    `json:"winner_team_id,omitempty"`
+	HomeTeamName       string  `json:"home_team_name,omitempty"`
+	AwayTeamName       string  `json:"away_team_name,omitempty"`
+	Matches            []Match `json:"matches,omitempty"` // Eşleşmenin maçları, oynanma sırasıyla
+}
+
+// CupRound kupa ağacındaki bir turdur.
+type CupRound struct {
+	Round int      `json:"round"`
+	Name  string   `json:"name"` // Örneğin "Quarter-finals", "Final"
+	Ties  []CupTie `json:"ties"`
+}
+
+// CupBracket kupanın kurası çekilmiş tüm turlarını içeren eleme ağacıdır.
+type CupBracket struct {
+	Cup    Cup        `json:"cup"`
+	Rounds []CupRound `json:"rounds"`
+}
@@ -1,8 +1,110 @@
 package models
 
 type League struct {
+	ID                      int          `json:"id"`
+	Name                    string       `json:"name"`
+	Season                  int          `json:"season"` // Tablonun ait olduğu sezonun numarası
 	Teams                   []Team       `json:"teams"`
 	Matches                 []Match      `json:"matches"`
+	Status                  LeagueStatus `json:"status"`
 	CurrentWeek             int          `json:"current_week"`
+	TotalWeeks              int          `json:"total_weeks"`
+	MatchEngine             string       `json:"match_engine"`
+	Seed                    *int64       `json:"seed,omitempty"`
+	Tiebreakers             []string     `json:"tiebreakers"`          // Puan eşitliğinde sırayla uygulanan ölçütler
+	AsOfWeek                int          `json:"as_of_week,omitempty"` // Tablo geçmiş bir hafta için istendiyse o hafta
 	ChampionshipPredictions []Prediction `json:"championshipPredictions"`
+	Playoffs                *CupBracket  `json:"playoffs,omitempty"` // Sezonun play-off'u başladıysa eleme ağacı
+	Split                   *SeasonSplit `json:"split,omitempty"`    // Sezonun tablosu bölündüyse üst grup
+}
+
+// LeagueStatus ligin sezon içindeki durumudur.
+type LeagueStatus string
+
+const (
+	LeagueStatusNotStarted LeagueStatus = "not_started"
+	LeagueStatusInProgress LeagueStatus = "in_progress"
+	LeagueStatusCompleted  LeagueStatus = "completed"
+	// LeagueStatusAbandoned yalnızca tamamlanmadan sıfırlanarak arşivlenen sezonlar için kullanılır.
+	LeagueStatusAbandoned LeagueStatus = "abandoned"
+)
+
+// LeagueState ligin Leagues tablosunda saklanan kalıcı durumudur.
+type LeagueState struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	CurrentWeek int          `json:"current_week"`
+	Status      LeagueStatus `json:"status"`
+	Tiebreakers []string     `json:"tiebreakers"` // Puan eşitliğinde sırayla uygulanan ölçütler; boşsa varsayılanlar kullanılır
+	Seed        *int64       `json:"seed,omitempty"`
+	Version     int          `json:"version"` // İyimser eşzamanlılık denetimi için; her güncellemede bir artar
+	// ParentLeagueID bir üst ligin kimliğidir; ligler bu bağlantıyla bir piramit oluşturur
+	ParentLeagueID   *int `json:"parent_league_id,omitempty"`
+	PromotionPlaces  int  `json:"promotion_places"`  // Sezon sonunda üst lige çıkan takım sayısı
+	RelegationPlaces int  `json:"relegation_places"` // Sezon sonunda alt lige düşen takım sayısı
+	// Playoffs normal sezonun ardından oynanan play-off'un biçimidir; nil ise lig play-off oynamaz
+	Playoffs *PlayoffFormat `json:"playoffs,omitempty"`
+	// Swiss ligin fikstürünün İsviçre usulüyle üretilmesini sağlar; nil ise çift devreli lig oynanır
+	Swiss *SwissFormat `json:"swiss,omitempty"`
+	// NextRoster ligin bir sonraki sıfırlamadan ya da sezon geçişinden itibaren oynayacak takımlarıdır; nil ise kadro değişmez
+	NextRoster []int `json:"next_roster,omitempty"`
+	// MatchEngine ligin maçlarını ve tahminlerini simüle eden motordur; boşsa sunucunun varsayılan motoru kullanılır
+	MatchEngine string `json:"match_engine,omitempty" example:"poisson"`
+}
+
+// SwissFormat Şampiyonlar Ligi'nin lig aşaması gibi İsviçre usulü bir fikstürün biçimidir: her takım her
+// torbadan OpponentsPerPot rakiple bir kez karşılaşır. Takımların torbaları ve ülkeleri Team'de tutulur.
+type SwissFormat struct {
+	OpponentsPerPot int `json:"opponents_per_pot" example:"2"`
+}
+
+// PlayoffPrize play-off'un neyi belirlediğidir.
+type PlayoffPrize string
+
+const (
+	// PlayoffPrizeTitle play-off'un kazananı ligin şampiyonu olur.
+	PlayoffPrizeTitle PlayoffPrize = "title"
+	// PlayoffPrizePromotion play-off'un kazananı üst lige çıkan son takım olur; ilk PromotionPlaces-1 takım doğrudan çıkar.
+	PlayoffPrizePromotion PlayoffPrize = "promotion"
+)
+
+// PlayoffKind play-off'un nasıl oynandığıdır.
+type PlayoffKind string
+
+const (
+	// PlayoffKindKnockout normal sezonu FirstPlace ile LastPlace arasında bitiren takımlar sabit bir eleme ağacında oynar.
+	PlayoffKindKnockout PlayoffKind = "knockout"
+	// PlayoffKindSplit İskoçya ve Belçika liglerindeki gibi tablo SplitPlace'ten sonra ikiye bölünür ve her grup
+	// kendi içinde lig usulüyle oynar; normal sezonun puanları korunur.
+	PlayoffKindSplit PlayoffKind = "split"
+)
+
+// PlayoffFormat normal sezondan sonra oynanan play-off'un biçimidir. Eleme play-off'unda normal sezonu
+// FirstPlace ile LastPlace arasında bitiren takımlar sabit bir eleme ağacında oynar (örneğin 3.-6. sıralar
+// için yarı final ve final); sıralamada üstte bitiren takım seri başıdır ve takım sayısı ikinin kuvveti
+// değilse en iyi seri başları ilk turu bay geçer. Bölünmede ilk SplitPlace takım üst grupta, diğerleri alt
+// grupta birbirleriyle Legs kez karşılaşır; final sıralamasında üst gruptaki takımlar puanlarından bağımsız
+// olarak alt gruptakilerin önünde yer alır.
+type PlayoffFormat struct {
+	Kind       PlayoffKind  `json:"kind,omitempty" example:"knockout"` // Boşsa knockout
+	FirstPlace int          `json:"first_place" example:"3"`
+	LastPlace  int          `json:"last_place" example:"6"`
+	SplitPlace int          `json:"split_place,omitempty" example:"6"`   // Bölünmede üst gruba kalan son sıra
+	Legs       int          `json:"legs"`                                // Final dışındaki eşleşmelerin ya da bölünmede grup içindeki eşleşmelerin maç sayısı (1 ya da 2)
+	Prize      PlayoffPrize `json:"prize,omitempty" example:"promotion"` // Bölünmede boş kalır: şampiyonu ve lig değiştiren takımları final sıralaması belirler
+}
+
+// TeamMove sezon geçişinde lig değiştiren bir takımdır.
+type TeamMove struct {
+	TeamID       int    `json:"team_id"`
+	TeamName     string `json:"team_name"`
+	FromLeagueID int    `json:"from_league_id"`
+	ToLeagueID   int    `json:"to_league_id"`
+	Promoted     bool   `json:"promoted"` // false ise takım küme düşmüştür
+}
+
+// Rollover bir lig piramidinin sezon geçişinin sonucudur.
+type Rollover struct {
+	Seasons []Season   `json:"seasons"` // Liglerin yeni sezonları, piramidin tepesinden aşağı doğru
+	Moves   []TeamMove `json:"moves"`
 }
@@ -1,11 +1,62 @@
 package models
 
 type Match struct {
-	ID         int  `json:"id"`
-	HomeTeamID int  `json:"home_team_id"`
-	AwayTeamID int  `json:"away_team_id"`
-	HomeGoals  int  `json:"home_goals"`
-	AwayGoals  int  `json:"away_goals"`
-	Week       int  `json:"week"`
-	Played     bool `json:"played"`
+	ID         int   `json:"id"`
+	LeagueID   int   `json:"league_id"`
+	SeasonID   int   `json:"season_id"`
+	CupID      int   `json:"cup_id,omitempty"`   // Kupa maçlarında kupanın kimliği; lig ve sezon kimlikleri 0, Week ise tur numarasıdır
+	GroupID    int   `json:"group_id,omitempty"` // Turnuva grup maçlarında grubun kimliği; Week ise maç günüdür
+	HomeTeamID int   `json:"home_team_id"`
+	AwayTeamID int   `json:"away_team_id"`
+	HomeGoals  int   `json:"home_goals"`
+	AwayGoals  int   `json:"away_goals"`
+	Week       int   `json:"week"`
+	Played     bool  `json:"played"`
+	Seed       int64 `json:"seed"` // Skoru üreten rastgele sayı tohumu; maçın birebir tekrarı için saklanır. Elle girilen sonuçlarda 0'dır
+}
+
+// MatchVenue bir takımın maçlarının iç saha ya da deplasman olarak süzülmesidir.
+type MatchVenue string
+
+const (
+	MatchVenueHome MatchVenue = "home"
+	MatchVenueAway MatchVenue = "away"
+)
+
+// MatchSort maç listelerinin sıralama ölçütüdür.
+type MatchSort string
+
+const (
+	// MatchSortWeek maçları haftaya, aynı haftadakileri kimliğe göre sıralar.
+	MatchSortWeek MatchSort = "week"
+	// MatchSortID maçları kimliğe, yani fikstüre eklenme sırasına göre sıralar.
+	MatchSortID MatchSort = "id"
+)
+
+// FixtureMatch takım adlarıyla zenginleştirilmiş bir fikstür maçıdır.
+type FixtureMatch struct {
+	Match
+	HomeTeamName string `json:"home_team_name"`
+	AwayTeamName string `json:"away_team_name"`
+}
+
+// Fixtures bir lig sezonunun süzülmüş fikstürünün bir sayfasıdır.
+type Fixtures struct {
+	LeagueID int            `json:"league_id"`
+	Season   int            `json:"season"`
+	Total    int            `json:"total"` // Ölçütlere uyan tüm maçların sayısı
+	Limit    int            `json:"limit"`
+	Offset   int            `json:"offset"`
+	Matches  []FixtureMatch `json:"matches"`
+}
+
+// WeekResults oynatılan bir haftanın maçları ve haftanın ardından ligin puan durumudur.
+type WeekResults struct {
+	LeagueID int            `json:"league_id"`
+	Season   int            `json:"season"`
+	Week     int            `json:"week"`
+	Status   LeagueStatus   `json:"status"`  // Hafta oynandıktan sonraki sezon durumu
+	Matches  []FixtureMatch `json:"matches"` // Normal sezon maçları; play-off haftalarında boştur
+	Table    []Team         `json:"table"`   // Puan sırasına göre takımlar
+	Playoffs *CupBracket    `json:"playoffs,omitempty"`
 }
@@ -6,6 +6,28 @@ type Prediction struct {
 	ChampionshipLikelihood float64 `json:"championship_likelihood"` // Şampiyonluk olasılığı (%)
 }
 
+// TeamPrediction bir takımın simülasyonlar sonunda her sırada bitirme olasılıklarını ve bunlardan türetilen değerleri içerir.
+type TeamPrediction struct {
+	TeamID                 int       `json:"team_id"`
+	TeamName               string    `json:"team_name"`
+	PositionProbabilities  []float64 `json:"position_probabilities"` // i. eleman (i+1). sırada bitirme olasılığı (%)
+	ChampionshipLikelihood float64   `json:"championship_likelihood"`
+	TopNLikelihood         float64   `json:"top_n_likelihood"`       // İlk N sırada bitirme olasılığı (%)
+	RelegationLikelihood   float64   `json:"relegation_likelihood"`  // Küme düşme hattında bitirme olasılığı (%)
+	PromotionLikelihood    float64   `json:"promotion_likelihood"`   // Üst lige çıkma olasılığı (%); üst lig yoksa 0
+	PlayoffLikelihood      float64   `json:"playoff_likelihood"`     // Play-off'a kalma olasılığı (%); bölünmede üst gruba kalma olasılığı, lig play-off oynamıyorsa 0
+	PlayoffWinLikelihood   float64   `json:"playoff_win_likelihood"` // Play-off'u kazanma olasılığı (%); bölünmede 0
+	ExpectedPoints         float64   `json:"expected_points"`
+	ExpectedPosition       float64   `json:"expected_position"`
+}
+
 type PredictionResult struct {
-	ChampionshipPredictions []Prediction `json:"championship_predictions"`
+	ChampionshipPredictions []Prediction     `json:"championship_predictions"`
+	Simulations             int              `json:"simulations"`
+	Seed                    int64            `json:"seed"`
+	TopN                    int              `json:"top_n"`
+	RelegationPlaces        int              `json:"relegation_places"`
+	PromotionPlaces         int              `json:"promotion_places"`
+	Playoffs                *PlayoffFormat   `json:"playoffs,omitempty"` // Sezonun play-off'u; şampiyonluk ve üst lige çıkma olasılıkları play-off sonucunu içerir
+	Teams                   []TeamPrediction `json:"teams"`              // Beklenen sıraya göre sıralı
 }
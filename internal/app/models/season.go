@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Season bir ligin tek bir sezonudur. Ligin sıfırlanması yeni bir sezon başlatır; önceki sezonların
+// fikstürü ve sonuçları silinmez.
+type Season struct {
+	ID             int          `json:"id"`
+	LeagueID       int          `json:"league_id"`
+	Number         int          `json:"number"` // Lig içindeki sıra numarası; ilk sezon 1'dir
+	Status         LeagueStatus `json:"status"`
+	ChampionTeamID *int         `json:"champion_team_id,omitempty"`
+	ChampionName   string       `json:"champion_name,omitempty"`
+	Tiebreakers    []string     `json:"tiebreakers,omitempty"` // Sezon kapanırken geçerli olan eşitlik ölçütleri
+	StartedAt      time.Time    `json:"started_at"`
+	EndedAt        *time.Time   `json:"ended_at,omitempty"`       // Sezon arşivlendiyse kapanış zamanı
+	PlayoffCupID   *int         `json:"playoff_cup_id,omitempty"` // Sezonun play-off'unun oynandığı kupa
+	PlayoffPrize   PlayoffPrize `json:"playoff_prize,omitempty"`  // Play-off başladığında geçerli olan ödül
+	Split          *SeasonSplit `json:"split,omitempty"`          // Sezonun tablosu bölündüyse bölünmenin haftası ve üst grubu
+}
+
+// SeasonSplit bir sezonun normal sezon sonunda ikiye bölünen tablosudur.
+type SeasonSplit struct {
+	Week         int   `json:"week"`           // Grup maçlarının oynandığı ilk hafta
+	UpperTeamIDs []int `json:"upper_team_ids"` // Üst gruptaki takımlar, bölünmedeki sıralarıyla
+}
+
+// AllTimeTable bir ligin tüm sezonlarındaki sonuçlardan hesaplanan tarihsel puan durumudur.
+type AllTimeTable struct {
+	LeagueID int          `json:"league_id"`
+	Name     string       `json:"name"`
+	Seasons  int          `json:"seasons"`
+	Teams    []AllTimeRow `json:"teams"`
+}
+
+// AllTimeRow bir takımın tüm sezonlardaki toplam istatistikleridir.
+type AllTimeRow struct {
+	TeamID         int    `json:"team_id"`
+	TeamName       string `json:"team_name"`
+	Seasons        int    `json:"seasons"` // Takımın maç oynadığı sezon sayısı
+	Titles         int    `json:"titles"`  // Kazanılan şampiyonluk sayısı
+	Played         int    `json:"played"`
+	Wins           int    `json:"wins"`
+	Draws          int    `json:"draws"`
+	Losses         int    `json:"losses"`
+	GoalsFor       int    `json:"goals_for"`
+	GoalsAgainst   int    `json:"goals_against"`
+	GoalDifference int    `json:"goal_difference"`
+	Points         int    `json:"points"`
+}
@@ -1,18 +1,40 @@
 package models
 
 type Team struct {
-	ID            int    `json:"id"`
-	Name          string `json:"name"`
-	Strength      int    `json:"strength"`
-	Points        int    `json:"points"`
-	GoalsFor      int    `json:"goals_for"`
-	GoalsAgainst  int    `json:"goals_against"`
-	MatchesPlayed int    `json:"matches_played"`
-	Wins          int    `json:"wins"`  // Yeni eklendi
-	Draws         int    `json:"draws"` // Yeni eklendi
-	Loses         int    `json:"loses"` // Yeni eklendi
+	ID             int    `json:"id"`
+	LeagueID       int    `json:"league_id"` // 0 ise takım bir lige bağlı değildir (kupa, turnuva ya da sonraki kadrolar için)
+	Name           string `json:"name"`
+	Strength       int    `json:"strength"`
+	Points         int    `json:"points"`
+	GoalsFor       int    `json:"goals_for"`
+	GoalsAgainst   int    `json:"goals_against"`
+	MatchesPlayed  int    `json:"matches_played"`
+	Wins           int    `json:"wins"`              // Yeni eklendi
+	Draws          int    `json:"draws"`             // Yeni eklendi
+	Loses          int    `json:"loses"`             // Yeni eklendi
+	Attack         int    `json:"attack"`            // Hücum gücü, 0 ise Strength kullanılır
+	Defense        int    `json:"defense"`           // Savunma gücü, 0 ise Strength kullanılır
+	FairPlayPoints int    `json:"fair_play_points"`  // Disiplin puanı (kartlar); fair-play eşitlik ölçütünde düşük olan öndedir
+	Pot            int    `json:"pot,omitempty"`     // İsviçre usulü liglerde takımın torbası
+	Country        string `json:"country,omitempty"` // İsviçre usulü liglerde aynı ülkeden takımlar eşleşmez
 }
 
 func (t *Team) GoalDifference() int {
 	return t.GoalsFor - t.GoalsAgainst
 }
+
+// AttackRating takımın hücum gücünü döndürür; ayrı bir değer girilmemişse genel güç kullanılır.
+func (t *Team) AttackRating() int {
+	if t.Attack > 0 {
+		return t.Attack
+	}
+	return t.Strength
+}
+
+// DefenseRating takımın savunma gücünü döndürür; ayrı bir değer girilmemişse genel güç kullanılır.
+func (t *Team) DefenseRating() int {
+	if t.Defense > 0 {
+		return t.Defense
+	}
+	return t.Strength
+}
@@ -0,0 +1,56 @@
+package models
+
+// TournamentStage turnuvanın içinde bulunduğu aşamadır.
+type TournamentStage string
+
+const (
+	TournamentStageGroup    TournamentStage = "group"
+	TournamentStageKnockout TournamentStage = "knockout"
+)
+
+// Tournament önce gruplar halinde lig usulüyle, ardından grup birincileri ve ikincileriyle eleme
+// usulüyle oynanan bir turnuvadır (Dünya Kupası, Şampiyonlar Ligi vb.). Eleme aşaması bir kupa olarak oynanır.
+type Tournament struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	GroupLegs    int               `json:"group_legs"`    // Grup içinde iki takımın kaç kez karşılaştığı (1 ya da 2)
+	KnockoutLegs int               `json:"knockout_legs"` // Eleme eşleşmelerinin maç sayısı (1 ya da 2); final her zaman tek maçtır
+	Tiebreakers  []string          `json:"tiebreakers"`   // Grup sıralamasında puan eşitliğinde sırayla uygulanan ölçütler
+	Seed         *int64            `json:"seed,omitempty"`
+	Status       LeagueStatus      `json:"status"`
+	Stage        TournamentStage   `json:"stage"`
+	CurrentRound int               `json:"current_round"`    // Grup aşamasında sıradaki maç günü
+	TotalRounds  int               `json:"total_rounds"`     // Grup aşamasının maç günü sayısı
+	CupID        *int              `json:"cup_id,omitempty"` // Eleme aşamasının kupası; grup aşaması bitene kadar nil
+	WinnerTeamID *int              `json:"winner_team_id,omitempty"`
+	Version      int               `json:"version"` // İyimser eşzamanlılık denetimi için; her güncellemede bir artar
+	Groups       []TournamentGroup `json:"groups"`
+	Knockout     *CupBracket       `json:"knockout,omitempty"`
+}
+
+// TournamentGroup turnuvanın bir grubudur. Table ve Matches yalnızca turnuvanın ayrıntısı istendiğinde doldurulur.
+type TournamentGroup struct {
+	ID           int     `json:"id"`
+	TournamentID int     `json:"tournament_id"`
+	Name         string  `json:"name"` // "A", "B", ...
+	TeamIDs      []int   `json:"team_ids"`
+	Table        []Team  `json:"table,omitempty"` // Grubun sıralama kurallarına göre puan durumu
+	Matches      []Match `json:"matches,omitempty"`
+}
+
+// TournamentPrediction Monte Carlo simülasyonlarına göre her takımın turnuvanın her aşamasına ulaşma olasılıklarıdır.
+type TournamentPrediction struct {
+	Simulations int                   `json:"simulations"`
+	Seed        int64                 `json:"seed"`
+	Stages      []string              `json:"stages"` // Aşamalar sırasıyla: grup aşaması, eleme turları ve şampiyonluk
+	Teams       []TeamStagePrediction `json:"teams"`  // Şampiyonluk olasılığına göre sıralı
+}
+
+// TeamStagePrediction bir takımın turnuvanın aşamalarına ulaşma olasılıklarıdır.
+type TeamStagePrediction struct {
+	TeamID             int       `json:"team_id"`
+	TeamName           string    `json:"team_name"`
+	Group              string    `json:"group"`
+	StageProbabilities []float64 `json:"stage_probabilities"` // i. eleman Stages[i] aşamasına ulaşma olasılığı (%)
+	WinLikelihood      float64   `json:"win_likelihood"`      // Turnuvayı kazanma olasılığı (%)
+}
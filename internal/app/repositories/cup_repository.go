@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/database"
+)
+
+type cupRepository struct {
+	db querier
+}
+
+func NewCupRepository(db *database.DB) CupRepository {
+	return &cupRepository{db: db}
+}
+
+const cupColumns = `ID, Name, Legs, DrawMode, Seed, Owner, Status, CurrentRound, WinnerTeamID, Version`
+
+const cupTieColumns = `ID, CupID, Round, Position, HomeTeamID, AwayTeamID, FirstLegMatchID, SecondLegMatchID,
+	ExtraTimeHomeGoals, ExtraTimeAwayGoals, PenaltiesHome, PenaltiesAway, WinnerTeamID`
+
+func (r *cupRepository) CreateCup(cup *models.Cup) error {
+	query := `
+		INSERT INTO Cups (Name, Legs, DrawMode, Seed, Status, CurrentRound, WinnerTeamID, Owner)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8);
+		SELECT SCOPE_IDENTITY();`
+	var id int
+	err := r.db.QueryRow(query,
+		sql.Named("p1", cup.Name),
+		sql.Named("p2", cup.Legs),
+		sql.Named("p3", string(cup.Draw)),
+		sql.Named("p4", nullableSeed(cup.Seed)),
+		sql.Named("p5", string(cup.Status)),
+		sql.Named("p6", cup.CurrentRound),
+		sql.Named("p7", nullableID(cup.WinnerTeamID)),
+		sql.Named("p8", nullableOwner(cup.Owner)),
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+	cup.ID = id
+
+	for i, teamID := range cup.TeamIDs {
+		query := `
+			INSERT INTO CupTeams (CupID, TeamID, SeedRank)
+			VALUES (@p1, @p2, @p3)`
+		if _, err := r.db.Exec(query, sql.Named("p1", cup.ID), sql.Named("p2", teamID), sql.Named("p3", i+1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *cupRepository) GetCupByID(id int) (*models.Cup, error) {
+	query := `
+		SELECT ` + cupColumns + `
+		FROM Cups
+		WHERE ID = @p1`
+	cups, err := r.queryCups(query, sql.Named("p1", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(cups) == 0 {
+		return nil, nil
+	}
+	return &cups[0], nil
+}
+
+func (r *cupRepository) GetAllCups() ([]models.Cup, error) {
+	query := `
+		SELECT ` + cupColumns + `
+		FROM Cups
+		ORDER BY ID`
+	return r.queryCups(query)
+}
+
+func (r *cupRepository) UpdateCup(cup *models.Cup) error {
+	query := `
+		UPDATE Cups
+		SET Name = @p1, Status = @p2, CurrentRound = @p3, WinnerTeamID = @p4, Version = Version + 1
+		WHERE ID = @p5 AND Version = @p6`
+	result, err := r.db.Exec(query,
+		sql.Named("p1", cup.Name),
+		sql.Named("p2", string(cup.Status)),
+		sql.Named("p3", cup.CurrentRound),
+		sql.Named("p4", nullableID(cup.WinnerTeamID)),
+		sql.Named("p5", cup.ID),
+		sql.Named("p6", cup.Version),
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVersionConflict
+	}
+	cup.Version++
+	return nil
+}
+
+func (r *cupRepository) CreateTie(tie *models.CupTie) error {
+	query := `
+		INSERT INTO CupTies (CupID, Round, Position, HomeTeamID, AwayTeamID, FirstLegMatchID, SecondLegMatchID,
+			ExtraTimeHomeGoals, ExtraTimeAwayGoals, PenaltiesHome, PenaltiesAway, WinnerTeamID)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12);
+		SELECT SCOPE_IDENTITY();`
+	var id int
+	err := r.db.QueryRow(query,
+		sql.Named("p1", tie.CupID),
+		sql.Named("p2", tie.Round),
+		sql.Named("p3", tie.Position),
+		sql.Named("p4", tie.HomeTeamID),
+		sql.Named("p5", nullableID(tie.AwayTeamID)),
+		sql.Named("p6", nullableID(tie.FirstLegMatchID)),
+		sql.Named("p7", nullableID(tie.SecondLegMatchID)),
+		sql.Named("p8", nullableID(tie.ExtraTimeHomeGoals)),
+		sql.Named("p9", nullableID(tie.ExtraTimeAwayGoals)),
+		sql.Named("p10", nullableID(tie.PenaltiesHome)),
+		sql.Named("p11", nullableID(tie.PenaltiesAway)),
+		sql.Named("p12", nullableID(tie.WinnerTeamID)),
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+	tie.ID = id
+	return nil
+}
+
+func (r *cupRepository) UpdateTie(tie *models.CupTie) error {
+	query := `
+		UPDATE CupTies
+		SET FirstLegMatchID = @p1, SecondLegMatchID = @p2, ExtraTimeHomeGoals = @p3, ExtraTimeAwayGoals = @p4,
+			PenaltiesHome = @p5, PenaltiesAway = @p6, WinnerTeamID = @p7
+		WHERE ID = @p8`
+	_, err := r.db.Exec(query,
+		sql.Named("p1", nullableID(tie.FirstLegMatchID)),
+		sql.Named("p2", nullableID(tie.SecondLegMatchID)),
+		sql.Named("p3", nullableID(tie.ExtraTimeHomeGoals)),
+		sql.Named("p4", nullableID(tie.ExtraTimeAwayGoals)),
+		sql.Named("p5", nullableID(tie.PenaltiesHome)),
+		sql.Named("p6", nullableID(tie.PenaltiesAway)),
+		sql.Named("p7", nullableID(tie.WinnerTeamID)),
+		sql.Named("p8", tie.ID),
+	)
+	return err
+}
+
+func (r *cupRepository) GetTiesByCup(cupID int) ([]models.CupTie, error) {
+	query := `
+		SELECT ` + cupTieColumns + `
+		FROM CupTies
+		WHERE CupID = @p1
+		ORDER BY Round, Position`
+	rows, err := r.db.Query(query, sql.Named("p1", cupID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ties := []models.CupTie{}
+	for rows.Next() {
+		var (
+			tie      models.CupTie
+			nullable [8]sql.NullInt64
+		)
+		if err := rows.Scan(
+			&tie.ID,
+			&tie.CupID,
+			&tie.Round,
+			&tie.Position,
+			&tie.HomeTeamID,
+			&nullable[0],
+			&nullable[1],
+			&nullable[2],
+			&nullable[3],
+			&nullable[4],
+			&nullable[5],
+			&nullable[6],
+			&nullable[7],
+		); err != nil {
+			return nil, err
+		}
+		targets := []**int{
+			&tie.AwayTeamID,
+			&tie.FirstLegMatchID,
+			&tie.SecondLegMatchID,
+			&tie.ExtraTimeHomeGoals,
+			&tie.ExtraTimeAwayGoals,
+			&tie.PenaltiesHome,
+			&tie.PenaltiesAway,
+			&tie.WinnerTeamID,
+		}
+		for i, target := range targets {
+			if nullable[i].Valid {
+				value := int(nullable[i].Int64)
+				*target = &value
+			}
+		}
+		ties = append(ties, tie)
+	}
+	return ties, rows.Err()
+}
+
+// queryCups kupaları ve seri başı sırasıyla katılımcılarını okur.
+func (r *cupRepository) queryCups(query string, args ...any) ([]models.Cup, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cups := []models.Cup{}
+	for rows.Next() {
+		var (
+			cup      models.Cup
+			draw     string
+			status   string
+			seed     sql.NullInt64
+			owner    sql.NullString
+			winnerID sql.NullInt64
+		)
+		if err := rows.Scan(
+			&cup.ID,
+			&cup.Name,
+			&cup.Legs,
+			&draw,
+			&seed,
+			&owner,
+			&status,
+			&cup.CurrentRound,
+			&winnerID,
+			&cup.Version,
+		); err != nil {
+			return nil, err
+		}
+		cup.Draw = models.CupDraw(draw)
+		cup.Owner = models.CupOwner(owner.String)
+		cup.Status = models.LeagueStatus(status)
+		if seed.Valid {
+			cup.Seed = &seed.Int64
+		}
+		if winnerID.Valid {
+			id := int(winnerID.Int64)
+			cup.WinnerTeamID = &id
+		}
+		cups = append(cups, cup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range cups {
+		teamIDs, err := r.cupTeamIDs(cups[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		cups[i].TeamIDs = teamIDs
+	}
+	return cups, nil
+}
+
+func (r *cupRepository) cupTeamIDs(cupID int) ([]int, error) {
+	query := `
+		SELECT TeamID
+		FROM CupTeams
+		WHERE CupID = @p1
+		ORDER BY SeedRank`
+	rows, err := r.db.Query(query, sql.Named("p1", cupID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teamIDs := []int{}
+	for rows.Next() {
+		var teamID int
+		if err := rows.Scan(&teamID); err != nil {
+			return nil, err
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	return teamIDs, rows.Err()
+}
+
+// nullableOwner kupanın sahibini saklar; bağımsız kupalarda NULL döner.
+func nullableOwner(owner models.CupOwner) sql.NullString {
+	return sql.NullString{String: string(owner), Valid: owner != ""}
+}
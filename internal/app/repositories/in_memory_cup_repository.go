@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// InMemoryCupRepository CupRepository arayüzünü bellek içi (in-memory) olarak uygular.
+type InMemoryCupRepository struct {
+	mu        sync.RWMutex
+	cups      map[int]models.Cup
+	ties      map[int]models.CupTie
+	nextCupID int
+	nextTieID int
+}
+
+// NewInMemoryCupRepository bellek içi kupa deposunun yeni bir örneğini oluşturur.
+func NewInMemoryCupRepository() *InMemoryCupRepository {
+	return &InMemoryCupRepository{
+		cups:      make(map[int]models.Cup),
+		ties:      make(map[int]models.CupTie),
+		nextCupID: 1,
+		nextTieID: 1,
+	}
+}
+
+func (r *InMemoryCupRepository) CreateCup(cup *models.Cup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cup.ID = r.nextCupID
+	r.nextCupID++
+	r.cups[cup.ID] = *cloneCup(*cup)
+	return nil
+}
+
+func (r *InMemoryCupRepository) GetCupByID(id int) (*models.Cup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cup, ok := r.cups[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneCup(cup), nil
+}
+
+func (r *InMemoryCupRepository) GetAllCups() ([]models.Cup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cups := make([]models.Cup, 0, len(r.cups))
+	for _, cup := range r.cups {
+		cups = append(cups, *cloneCup(cup))
+	}
+	slices.SortFunc(cups, func(a, b models.Cup) int { return cmp.Compare(a.ID, b.ID) })
+	return cups, nil
+}
+
+func (r *InMemoryCupRepository) UpdateCup(cup *models.Cup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.cups[cup.ID]
+	if !ok {
+		return fmt.Errorf("cup with ID %d not found for update", cup.ID)
+	}
+	if stored.Version != cup.Version {
+		return ErrVersionConflict
+	}
+	cup.Version++
+	updated := *cloneCup(*cup)
+	updated.TeamIDs = stored.TeamIDs
+	r.cups[cup.ID] = updated
+	return nil
+}
+
+func (r *InMemoryCupRepository) CreateTie(tie *models.CupTie) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tie.ID = r.nextTieID
+	r.nextTieID++
+	r.ties[tie.ID] = *cloneCupTie(*tie)
+	return nil
+}
+
+func (r *InMemoryCupRepository) UpdateTie(tie *models.CupTie) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.ties[tie.ID]; !ok {
+		return fmt.Errorf("cup tie with ID %d not found for update", tie.ID)
+	}
+	r.ties[tie.ID] = *cloneCupTie(*tie)
+	return nil
+}
+
+func (r *InMemoryCupRepository) GetTiesByCup(cupID int) ([]models.CupTie, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ties := []models.CupTie{}
+	for _, tie := range r.ties {
+		if tie.CupID == cupID {
+			ties = append(ties, *cloneCupTie(tie))
+		}
+	}
+	slices.SortFunc(ties, func(a, b models.CupTie) int {
+		return cmp.Or(cmp.Compare(a.Round, b.Round), cmp.Compare(a.Position, b.Position))
+	})
+	return ties, nil
+}
+
+type cupSnapshot struct {
+	cups      map[int]models.Cup
+	ties      map[int]models.CupTie
+	nextCupID int
+	nextTieID int
+}
+
+func (r *InMemoryCupRepository) snapshot() cupSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := cupSnapshot{
+		cups:      make(map[int]models.Cup, len(r.cups)),
+		ties:      make(map[int]models.CupTie, len(r.ties)),
+		nextCupID: r.nextCupID,
+		nextTieID: r.nextTieID,
+	}
+	for id, cup := range r.cups {
+		s.cups[id] = *cloneCup(cup)
+	}
+	for id, tie := range r.ties {
+		s.ties[id] = *cloneCupTie(tie)
+	}
+	return s
+}
+
+func (r *InMemoryCupRepository) restore(s cupSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cups = s.cups
+	r.ties = s.ties
+	r.nextCupID = s.nextCupID
+	r.nextTieID = s.nextTieID
+}
+
+// cloneCup çağıranın depodaki kaydı değiştirememesi için kupanın derin bir kopyasını alır.
+func cloneCup(cup models.Cup) *models.Cup {
+	clone := cup
+	clone.TeamIDs = slices.Clone(cup.TeamIDs)
+	clone.Seed = cloneInt64(cup.Seed)
+	clone.WinnerTeamID = cloneInt(cup.WinnerTeamID)
+	return &clone
+}
+
+// cloneCupTie eşleşmenin derin bir kopyasını alır. Depoda saklanmayan alanlar (takım adları, maçlar) boşaltılır.
+func cloneCupTie(tie models.CupTie) *models.CupTie {
+	clone := tie
+	clone.AwayTeamID = cloneInt(tie.AwayTeamID)
+	clone.FirstLegMatchID = cloneInt(tie.FirstLegMatchID)
+	clone.SecondLegMatchID = cloneInt(tie.SecondLegMatchID)
+	clone.ExtraTimeHomeGoals = cloneInt(tie.ExtraTimeHomeGoals)
+	clone.ExtraTimeAwayGoals = cloneInt(tie.ExtraTimeAwayGoals)
+	clone.PenaltiesHome = cloneInt(tie.PenaltiesHome)
+	clone.PenaltiesAway = cloneInt(tie.PenaltiesAway)
+	clone.WinnerTeamID = cloneInt(tie.WinnerTeamID)
+	clone.HomeTeamName, clone.AwayTeamName, clone.Matches = "", "", nil
+	return &clone
+}
+
+func cloneInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func cloneInt64(v *int64) *int64 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
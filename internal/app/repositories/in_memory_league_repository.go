@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// InMemoryLeagueRepository LeagueRepository arayüzünü bellek içi (in-memory) olarak uygular.
+type InMemoryLeagueRepository struct {
+	mu      sync.RWMutex
+	leagues map[int]models.LeagueState
+	nextID  int
+}
+
+// NewInMemoryLeagueRepository bellek içi lig deposunun yeni bir örneğini oluşturur.
+func NewInMemoryLeagueRepository() *InMemoryLeagueRepository {
+	return &InMemoryLeagueRepository{
+		leagues: make(map[int]models.LeagueState),
+		nextID:  1,
+	}
+}
+
+func (r *InMemoryLeagueRepository) GetLeagueByID(id int) (*models.LeagueState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	league, ok := r.leagues[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneLeagueState(league), nil
+}
+
+func (r *InMemoryLeagueRepository) GetDefaultLeague() (*models.LeagueState, error) {
+	leagues, err := r.GetAllLeagues()
+	if err != nil || len(leagues) == 0 {
+		return nil, err
+	}
+	return &leagues[0], nil
+}
+
+func (r *InMemoryLeagueRepository) GetAllLeagues() ([]models.LeagueState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	leagues := make([]models.LeagueState, 0, len(r.leagues))
+	for _, league := range r.leagues {
+		leagues = append(leagues, *cloneLeagueState(league))
+	}
+	slices.SortFunc(leagues, func(a, b models.LeagueState) int { return cmp.Compare(a.ID, b.ID) })
+	return leagues, nil
+}
+
+func (r *InMemoryLeagueRepository) CreateLeague(league *models.LeagueState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	league.ID = r.nextID
+	r.nextID++
+	r.leagues[league.ID] = *cloneLeagueState(*league)
+	return nil
+}
+
+func (r *InMemoryLeagueRepository) UpdateLeague(league *models.LeagueState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.leagues[league.ID]
+	if !ok {
+		return fmt.Errorf("league with ID %d not found for update", league.ID)
+	}
+	if stored.Version != league.Version {
+		return ErrVersionConflict
+	}
+	league.Version++
+	r.leagues[league.ID] = *cloneLeagueState(*league)
+	return nil
+}
+
+type leagueSnapshot struct {
+	leagues map[int]models.LeagueState
+	nextID  int
+}
+
+func (r *InMemoryLeagueRepository) snapshot() leagueSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	leagues := make(map[int]models.LeagueState, len(r.leagues))
+	for id, league := range r.leagues {
+		leagues[id] = *cloneLeagueState(league)
+	}
+	return leagueSnapshot{leagues: leagues, nextID: r.nextID}
+}
+
+func (r *InMemoryLeagueRepository) restore(s leagueSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.leagues = s.leagues
+	r.nextID = s.nextID
+}
+
+// cloneLeagueState çağıranın depodaki kaydı değiştirememesi için ligin derin bir kopyasını alır.
+func cloneLeagueState(league models.LeagueState) *models.LeagueState {
+	clone := league
+	clone.Tiebreakers = slices.Clone(league.Tiebreakers)
+	if league.Seed != nil {
+		seed := *league.Seed
+		clone.Seed = &seed
+	}
+	if league.ParentLeagueID != nil {
+		parentID := *league.ParentLeagueID
+		clone.ParentLeagueID = &parentID
+	}
+	if league.Playoffs != nil {
+		playoffs := *league.Playoffs
+		clone.Playoffs = &playoffs
+	}
+	if league.Swiss != nil {
+		swiss := *league.Swiss
+		clone.Swiss = &swiss
+	}
+	clone.NextRoster = slices.Clone(league.NextRoster)
+	return &clone
+}
@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
@@ -50,28 +52,56 @@ func (r *InMemoryMatchRepository) GetMatchByID(id int) (*models.Match, error) {
 	return &match, nil
 }
 
-func (r *InMemoryMatchRepository) GetMatchesByWeek(week int) ([]models.Match, error) {
+func (r *InMemoryMatchRepository) GetMatchesByWeek(seasonID, week int) ([]models.Match, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var weekMatches []models.Match
 	for _, match := range r.matches {
-		if match.Week == week {
+		if match.SeasonID == seasonID && match.Week == week {
 			weekMatches = append(weekMatches, match)
 		}
 	}
 	return weekMatches, nil
 }
 
-func (r *InMemoryMatchRepository) GetAllMatches() ([]models.Match, error) {
+func (r *InMemoryMatchRepository) GetMatchesByLeague(leagueID int) ([]models.Match, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var allMatches []models.Match
+	var leagueMatches []models.Match
 	for _, match := range r.matches {
-		allMatches = append(allMatches, match)
+		if match.LeagueID == leagueID {
+			leagueMatches = append(leagueMatches, match)
+		}
+	}
+	return leagueMatches, nil
+}
+
+func (r *InMemoryMatchRepository) GetMatchesByCup(cupID int) ([]models.Match, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cupMatches []models.Match
+	for _, match := range r.matches {
+		if match.CupID == cupID {
+			cupMatches = append(cupMatches, match)
+		}
+	}
+	return cupMatches, nil
+}
+
+func (r *InMemoryMatchRepository) GetMatchesByGroup(groupID int) ([]models.Match, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var groupMatches []models.Match
+	for _, match := range r.matches {
+		if match.GroupID == groupID {
+			groupMatches = append(groupMatches, match)
+		}
 	}
-	return allMatches, nil
+	return groupMatches, nil
 }
 
 func (r *InMemoryMatchRepository) UpdateMatch(match *models.Match) error {
@@ -85,12 +115,28 @@ func (r *InMemoryMatchRepository) UpdateMatch(match *models.Match) error {
 	return nil
 }
 
-func (r *InMemoryMatchRepository) DeleteAllMatches() error {
+func (r *InMemoryMatchRepository) GetMatchesBySeason(seasonID int) ([]models.Match, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var seasonMatches []models.Match
+	for _, match := range r.matches {
+		if match.SeasonID == seasonID {
+			seasonMatches = append(seasonMatches, match)
+		}
+	}
+	return seasonMatches, nil
+}
+
+func (r *InMemoryMatchRepository) DeleteMatchesBySeason(seasonID int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.matches = make(map[int]models.Match)
-	r.nextID = 1
+	for id, match := range r.matches {
+		if match.SeasonID == seasonID {
+			delete(r.matches, id)
+		}
+	}
 	return nil
 }
 
@@ -107,15 +153,81 @@ func (r *InMemoryMatchRepository) GetPlayedMatches() ([]models.Match, error) {
 	return playedMatches, nil
 }
 
-func (r *InMemoryMatchRepository) GetMaxWeekPlayed() (int, error) {
+func (r *InMemoryMatchRepository) GetTotalWeeks(seasonID int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totalWeeks := 0
+	for _, match := range r.matches {
+		if match.SeasonID == seasonID && match.Week > totalWeeks {
+			totalWeeks = match.Week
+		}
+	}
+	return totalWeeks, nil
+}
+
+func (r *InMemoryMatchRepository) FindMatches(filter MatchFilter) ([]models.Match, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found []models.Match
+	for _, match := range r.matches {
+		if filter.matches(match) {
+			found = append(found, match)
+		}
+	}
+
+	slices.SortFunc(found, func(a, b models.Match) int {
+		c := cmp.Compare(a.ID, b.ID)
+		if filter.Sort != models.MatchSortID {
+			c = cmp.Or(cmp.Compare(a.Week, b.Week), c)
+		}
+		if filter.Descending {
+			return -c
+		}
+		return c
+	})
+
+	total := len(found)
+	page := found[min(filter.Offset, total):]
+	if filter.Limit > 0 && filter.Limit < len(page) {
+		page = page[:filter.Limit]
+	}
+	return slices.Clone(page), total, nil
+}
+
+// matches maçın süzgecin ölçütlerine uyup uymadığını denetler.
+func (f MatchFilter) matches(match models.Match) bool {
+	if f.SeasonID != 0 && match.SeasonID != f.SeasonID {
+		return false
+	}
+	if f.Week != 0 && match.Week != f.Week {
+		return false
+	}
+	if f.TeamID != 0 {
+		home, away := match.HomeTeamID == f.TeamID, match.AwayTeamID == f.TeamID
+		switch f.Venue {
+		case models.MatchVenueHome:
+			away = false
+		case models.MatchVenueAway:
+			home = false
+		}
+		if !home && !away {
+			return false
+		}
+	}
+	return f.Played == nil || match.Played == *f.Played
+}
+
+func (r *InMemoryMatchRepository) CountMatchesByTeam(teamID int) (int, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	maxWeek := 0
+	count := 0
 	for _, match := range r.matches {
-		if match.Played && match.Week > maxWeek {
-			maxWeek = match.Week
+		if match.HomeTeamID == teamID || match.AwayTeamID == teamID {
+			count++
 		}
 	}
-	return maxWeek, nil
+	return count, nil
 }
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// InMemorySeasonRepository SeasonRepository arayüzünü bellek içi (in-memory) olarak uygular.
+type InMemorySeasonRepository struct {
+	mu      sync.RWMutex
+	seasons map[int]models.Season
+	nextID  int
+}
+
+// NewInMemorySeasonRepository bellek içi sezon deposunun yeni bir örneğini oluşturur.
+func NewInMemorySeasonRepository() *InMemorySeasonRepository {
+	return &InMemorySeasonRepository{
+		seasons: make(map[int]models.Season),
+		nextID:  1,
+	}
+}
+
+func (r *InMemorySeasonRepository) CreateSeason(season *models.Season) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.seasons {
+		if s.LeagueID == season.LeagueID && s.Number == season.Number {
+			return fmt.Errorf("season %d of league %d already exists", season.Number, season.LeagueID)
+		}
+	}
+	season.ID = r.nextID
+	r.nextID++
+	r.seasons[season.ID] = *cloneSeason(*season)
+	return nil
+}
+
+func (r *InMemorySeasonRepository) GetCurrentSeason(leagueID int) (*models.Season, error) {
+	seasons, err := r.GetSeasonsByLeague(leagueID)
+	if err != nil || len(seasons) == 0 {
+		return nil, err
+	}
+	return &seasons[len(seasons)-1], nil
+}
+
+func (r *InMemorySeasonRepository) GetSeason(leagueID, number int) (*models.Season, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, season := range r.seasons {
+		if season.LeagueID == leagueID && season.Number == number {
+			return cloneSeason(season), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *InMemorySeasonRepository) GetSeasonsByLeague(leagueID int) ([]models.Season, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seasons := []models.Season{}
+	for _, season := range r.seasons {
+		if season.LeagueID == leagueID {
+			seasons = append(seasons, *cloneSeason(season))
+		}
+	}
+	slices.SortFunc(seasons, func(a, b models.Season) int { return cmp.Compare(a.Number, b.Number) })
+	return seasons, nil
+}
+
+func (r *InMemorySeasonRepository) UpdateSeason(season *models.Season) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.seasons[season.ID]; !ok {
+		return fmt.Errorf("season with ID %d not found for update", season.ID)
+	}
+	r.seasons[season.ID] = *cloneSeason(*season)
+	return nil
+}
+
+type seasonSnapshot struct {
+	seasons map[int]models.Season
+	nextID  int
+}
+
+func (r *InMemorySeasonRepository) snapshot() seasonSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seasons := make(map[int]models.Season, len(r.seasons))
+	for id, season := range r.seasons {
+		seasons[id] = *cloneSeason(season)
+	}
+	return seasonSnapshot{seasons: seasons, nextID: r.nextID}
+}
+
+func (r *InMemorySeasonRepository) restore(s seasonSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seasons = s.seasons
+	r.nextID = s.nextID
+}
+
+// cloneSeason çağıranın depodaki kaydı değiştirememesi için sezonun derin bir kopyasını alır.
+func cloneSeason(season models.Season) *models.Season {
+	clone := season
+	clone.Tiebreakers = slices.Clone(season.Tiebreakers)
+	if season.ChampionTeamID != nil {
+		id := *season.ChampionTeamID
+		clone.ChampionTeamID = &id
+	}
+	if season.EndedAt != nil {
+		endedAt := *season.EndedAt
+		clone.EndedAt = &endedAt
+	}
+	clone.PlayoffCupID = cloneInt(season.PlayoffCupID)
+	if season.Split != nil {
+		clone.Split = &models.SeasonSplit{Week: season.Split.Week, UpperTeamIDs: slices.Clone(season.Split.UpperTeamIDs)}
+	}
+	return &clone
+}
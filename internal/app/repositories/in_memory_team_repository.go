@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
@@ -24,6 +25,9 @@ func (r *InMemoryTeamRepository) CreateTeam(team *models.Team) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if err := r.checkName(team); err != nil {
+		return err
+	}
 	if team.ID == 0 {
 		team.ID = r.nextID
 		r.nextID++
@@ -58,6 +62,19 @@ func (r *InMemoryTeamRepository) GetAllTeams() ([]models.Team, error) {
 	return allTeams, nil
 }
 
+func (r *InMemoryTeamRepository) GetTeamsByLeague(leagueID int) ([]models.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var leagueTeams []models.Team
+	for _, team := range r.teams {
+		if team.LeagueID == leagueID {
+			leagueTeams = append(leagueTeams, team)
+		}
+	}
+	return leagueTeams, nil
+}
+
 func (r *InMemoryTeamRepository) UpdateTeam(team *models.Team) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -65,10 +82,21 @@ func (r *InMemoryTeamRepository) UpdateTeam(team *models.Team) error {
 	if _, ok := r.teams[team.ID]; !ok {
 		return fmt.Errorf("team with ID %d not found for update", team.ID)
 	}
+	if err := r.checkName(team); err != nil {
+		return err
+	}
 	r.teams[team.ID] = *team
 	return nil
 }
 
+func (r *InMemoryTeamRepository) DeleteTeam(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.teams, id)
+	return nil
+}
+
 func (r *InMemoryTeamRepository) DeleteAllTeams() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -77,3 +105,13 @@ func (r *InMemoryTeamRepository) DeleteAllTeams() error {
 	r.nextID = 1
 	return nil
 }
+
+// checkName veritabanındaki benzersiz dizin gibi takımın adının başka bir takımda kullanılmadığını denetler.
+func (r *InMemoryTeamRepository) checkName(team *models.Team) error {
+	for _, other := range r.teams {
+		if other.ID != team.ID && strings.EqualFold(other.Name, team.Name) {
+			return fmt.Errorf("%w: team %d is already named %q", ErrDuplicateName, other.ID, other.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// InMemoryTournamentRepository TournamentRepository arayüzünü bellek içi (in-memory) olarak uygular.
+type InMemoryTournamentRepository struct {
+	mu          sync.RWMutex
+	tournaments map[int]models.Tournament
+	nextID      int
+	nextGroupID int
+}
+
+// NewInMemoryTournamentRepository bellek içi turnuva deposunun yeni bir örneğini oluşturur.
+func NewInMemoryTournamentRepository() *InMemoryTournamentRepository {
+	return &InMemoryTournamentRepository{
+		tournaments: make(map[int]models.Tournament),
+		nextID:      1,
+		nextGroupID: 1,
+	}
+}
+
+func (r *InMemoryTournamentRepository) CreateTournament(tournament *models.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournament.ID = r.nextID
+	r.nextID++
+	for i := range tournament.Groups {
+		tournament.Groups[i].ID = r.nextGroupID
+		tournament.Groups[i].TournamentID = tournament.ID
+		r.nextGroupID++
+	}
+	r.tournaments[tournament.ID] = *cloneTournament(*tournament)
+	return nil
+}
+
+func (r *InMemoryTournamentRepository) GetTournamentByID(id int) (*models.Tournament, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tournament, ok := r.tournaments[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneTournament(tournament), nil
+}
+
+func (r *InMemoryTournamentRepository) GetAllTournaments() ([]models.Tournament, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tournaments := make([]models.Tournament, 0, len(r.tournaments))
+	for _, tournament := range r.tournaments {
+		tournaments = append(tournaments, *cloneTournament(tournament))
+	}
+	slices.SortFunc(tournaments, func(a, b models.Tournament) int { return cmp.Compare(a.ID, b.ID) })
+	return tournaments, nil
+}
+
+func (r *InMemoryTournamentRepository) UpdateTournament(tournament *models.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tournaments[tournament.ID]
+	if !ok {
+		return fmt.Errorf("tournament with ID %d not found for update", tournament.ID)
+	}
+	if stored.Version != tournament.Version {
+		return ErrVersionConflict
+	}
+	tournament.Version++
+	stored.Status = tournament.Status
+	stored.CupID = cloneInt(tournament.CupID)
+	stored.Version = tournament.Version
+	r.tournaments[tournament.ID] = stored
+	return nil
+}
+
+type tournamentSnapshot struct {
+	tournaments map[int]models.Tournament
+	nextID      int
+	nextGroupID int
+}
+
+func (r *InMemoryTournamentRepository) snapshot() tournamentSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s := tournamentSnapshot{
+		tournaments: make(map[int]models.Tournament, len(r.tournaments)),
+		nextID:      r.nextID,
+		nextGroupID: r.nextGroupID,
+	}
+	for id, tournament := range r.tournaments {
+		s.tournaments[id] = *cloneTournament(tournament)
+	}
+	return s
+}
+
+func (r *InMemoryTournamentRepository) restore(s tournamentSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tournaments = s.tournaments
+	r.nextID = s.nextID
+	r.nextGroupID = s.nextGroupID
+}
+
+// cloneTournament turnuvanın yalnızca depoda saklanan alanlarının derin bir kopyasını alır.
+func cloneTournament(tournament models.Tournament) *models.Tournament {
+	clone := models.Tournament{
+		ID:           tournament.ID,
+		Name:         tournament.Name,
+		GroupLegs:    tournament.GroupLegs,
+		KnockoutLegs: tournament.KnockoutLegs,
+		Tiebreakers:  slices.Clone(tournament.Tiebreakers),
+		Seed:         cloneInt64(tournament.Seed),
+		Status:       tournament.Status,
+		CupID:        cloneInt(tournament.CupID),
+		Version:      tournament.Version,
+	}
+	for _, group := range tournament.Groups {
+		clone.Groups = append(clone.Groups, models.TournamentGroup{
+			ID:           group.ID,
+			TournamentID: group.TournamentID,
+			Name:         group.Name,
+			TeamIDs:      slices.Sorted(slices.Values(group.TeamIDs)),
+		})
+	}
+	return &clone
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// InMemoryUnitOfWork bellek içi depolar için UnitOfWork uygular. İşlem başlarken depoların bir
+// kopyası alınır ve fn hata dönerse depolar bu kopyaya geri döndürülür. İşlemler birbirini bekler;
+// ancak işlem dışındaki okumalar yarım kalmış değişiklikleri görebilir.
+type InMemoryUnitOfWork struct {
+	mu             sync.Mutex
+	teamRepo       *InMemoryTeamRepository
+	matchRepo      *InMemoryMatchRepository
+	leagueRepo     *InMemoryLeagueRepository
+	seasonRepo     *InMemorySeasonRepository
+	cupRepo        *InMemoryCupRepository
+	tournamentRepo *InMemoryTournamentRepository
+}
+
+// NewInMemoryUnitOfWork verilen bellek içi depolar üzerinde çalışan bir iş birimi oluşturur.
+func NewInMemoryUnitOfWork(teamRepo *InMemoryTeamRepository, matchRepo *InMemoryMatchRepository, leagueRepo *InMemoryLeagueRepository, seasonRepo *InMemorySeasonRepository, cupRepo *InMemoryCupRepository, tournamentRepo *InMemoryTournamentRepository) *InMemoryUnitOfWork {
+	return &InMemoryUnitOfWork{teamRepo: teamRepo, matchRepo: matchRepo, leagueRepo: leagueRepo, seasonRepo: seasonRepo, cupRepo: cupRepo, tournamentRepo: tournamentRepo}
+}
+
+func (u *InMemoryUnitOfWork) Do(fn func(repos Repositories) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	teams := u.teamRepo.snapshot()
+	matches := u.matchRepo.snapshot()
+	league := u.leagueRepo.snapshot()
+	seasons := u.seasonRepo.snapshot()
+	cups := u.cupRepo.snapshot()
+	tournaments := u.tournamentRepo.snapshot()
+	rollback := func() {
+		u.teamRepo.restore(teams)
+		u.matchRepo.restore(matches)
+		u.leagueRepo.restore(league)
+		u.seasonRepo.restore(seasons)
+		u.cupRepo.restore(cups)
+		u.tournamentRepo.restore(tournaments)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(Repositories{Teams: u.teamRepo, Matches: u.matchRepo, Leagues: u.leagueRepo, Seasons: u.seasonRepo, Cups: u.cupRepo, Tournaments: u.tournamentRepo}); err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
+
+type teamSnapshot struct {
+	teams  map[int]models.Team
+	nextID int
+}
+
+func (r *InMemoryTeamRepository) snapshot() teamSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return teamSnapshot{teams: maps.Clone(r.teams), nextID: r.nextID}
+}
+
+func (r *InMemoryTeamRepository) restore(s teamSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.teams = s.teams
+	r.nextID = s.nextID
+}
+
+type matchSnapshot struct {
+	matches map[int]models.Match
+	nextID  int
+}
+
+func (r *InMemoryMatchRepository) snapshot() matchSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return matchSnapshot{matches: maps.Clone(r.matches), nextID: r.nextID}
+}
+
+func (r *InMemoryMatchRepository) restore(s matchSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.matches = s.matches
+	r.nextID = s.nextID
+}
@@ -1,37 +1,295 @@
 package repositories
 
-import "github.com/muzaffertuna/football-league-sim/internal/app/models"
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/database"
+)
 
 type leagueRepository struct {
-	teamRepo  TeamRepository
-	matchRepo MatchRepository
-	league    *models.League
+	db querier
+}
+
+func NewLeagueRepository(db *database.DB) LeagueRepository {
+	return &leagueRepository{db: db}
+}
+
+const leagueColumns = `ID, Name, CurrentWeek, Status, Tiebreakers, Seed, Version, ParentLeagueID, PromotionPlaces, RelegationPlaces,
+	PlayoffFirstPlace, PlayoffLastPlace, PlayoffLegs, PlayoffPrize, SwissOpponentsPerPot, NextRoster, MatchEngine,
+	PlayoffKind, PlayoffSplitPlace`
+
+func (r *leagueRepository) GetLeagueByID(id int) (*models.LeagueState, error) {
+	query := `
+		SELECT ` + leagueColumns + `
+		FROM Leagues
+		WHERE ID = @p1`
+	return r.queryLeague(query, sql.Named("p1", id))
+}
+
+func (r *leagueRepository) GetDefaultLeague() (*models.LeagueState, error) {
+	query := `
+		SELECT TOP 1 ` + leagueColumns + `
+		FROM Leagues
+		ORDER BY ID`
+	return r.queryLeague(query)
+}
+
+func (r *leagueRepository) GetAllLeagues() ([]models.LeagueState, error) {
+	query := `
+		SELECT ` + leagueColumns + `
+		FROM Leagues
+		ORDER BY ID`
+	return r.queryLeagues(query)
+}
+
+func (r *leagueRepository) CreateLeague(league *models.LeagueState) error {
+	query := `
+		INSERT INTO Leagues (Name, CurrentWeek, Status, Tiebreakers, Seed, ParentLeagueID, PromotionPlaces, RelegationPlaces,
+			PlayoffFirstPlace, PlayoffLastPlace, PlayoffLegs, PlayoffPrize, SwissOpponentsPerPot, NextRoster, MatchEngine,
+			PlayoffKind, PlayoffSplitPlace)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13, @p14, @p15, @p16, @p17);
+		SELECT SCOPE_IDENTITY();`
+	playoffs := nullablePlayoffs(league.Playoffs)
+	var id int
+	err := r.db.QueryRow(query,
+		sql.Named("p1", league.Name),
+		sql.Named("p2", league.CurrentWeek),
+		sql.Named("p3", string(league.Status)),
+		sql.Named("p4", strings.Join(league.Tiebreakers, ",")),
+		sql.Named("p5", nullableSeed(league.Seed)),
+		sql.Named("p6", nullableID(league.ParentLeagueID)),
+		sql.Named("p7", league.PromotionPlaces),
+		sql.Named("p8", league.RelegationPlaces),
+		sql.Named("p9", playoffs.first),
+		sql.Named("p10", playoffs.last),
+		sql.Named("p11", playoffs.legs),
+		sql.Named("p12", playoffs.prize),
+		sql.Named("p13", nullableSwiss(league.Swiss)),
+		sql.Named("p14", nullableTeamIDs(league.NextRoster)),
+		sql.Named("p15", nullableEngine(league.MatchEngine)),
+		sql.Named("p16", playoffs.kind),
+		sql.Named("p17", playoffs.split),
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+	league.ID = id
+	return nil
 }
 
-func NewLeagueRepository(teamRepo TeamRepository, matchRepo MatchRepository) LeagueRepository {
-	return &leagueRepository{
-		teamRepo:  teamRepo,
-		matchRepo: matchRepo,
-		league:    &models.League{CurrentWeek: 1},
+func (r *leagueRepository) UpdateLeague(league *models.LeagueState) error {
+	query := `
+		UPDATE Leagues
+		SET Name = @p1, CurrentWeek = @p2, Status = @p3, Tiebreakers = @p4, Seed = @p5,
+			ParentLeagueID = @p6, PromotionPlaces = @p7, RelegationPlaces = @p8,
+			PlayoffFirstPlace = @p9, PlayoffLastPlace = @p10, PlayoffLegs = @p11, PlayoffPrize = @p12,
+			SwissOpponentsPerPot = @p13, NextRoster = @p14, MatchEngine = @p15,
+			PlayoffKind = @p16, PlayoffSplitPlace = @p17, Version = Version + 1
+		WHERE ID = @p18 AND Version = @p19`
+	playoffs := nullablePlayoffs(league.Playoffs)
+	result, err := r.db.Exec(query,
+		sql.Named("p1", league.Name),
+		sql.Named("p2", league.CurrentWeek),
+		sql.Named("p3", string(league.Status)),
+		sql.Named("p4", strings.Join(league.Tiebreakers, ",")),
+		sql.Named("p5", nullableSeed(league.Seed)),
+		sql.Named("p6", nullableID(league.ParentLeagueID)),
+		sql.Named("p7", league.PromotionPlaces),
+		sql.Named("p8", league.RelegationPlaces),
+		sql.Named("p9", playoffs.first),
+		sql.Named("p10", playoffs.last),
+		sql.Named("p11", playoffs.legs),
+		sql.Named("p12", playoffs.prize),
+		sql.Named("p13", nullableSwiss(league.Swiss)),
+		sql.Named("p14", nullableTeamIDs(league.NextRoster)),
+		sql.Named("p15", nullableEngine(league.MatchEngine)),
+		sql.Named("p16", playoffs.kind),
+		sql.Named("p17", playoffs.split),
+		sql.Named("p18", league.ID),
+		sql.Named("p19", league.Version),
+	)
+	if err != nil {
+		return err
 	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVersionConflict
+	}
+	league.Version++
+	return nil
 }
 
-func (r *leagueRepository) GetLeague() (*models.League, error) {
-	teams, err := r.teamRepo.GetAllTeams()
+func (r *leagueRepository) queryLeague(query string, args ...any) (*models.LeagueState, error) {
+	leagues, err := r.queryLeagues(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	matches, err := r.matchRepo.GetAllMatches()
+	if len(leagues) == 0 {
+		return nil, nil
+	}
+	return &leagues[0], nil
+}
+
+func (r *leagueRepository) queryLeagues(query string, args ...any) ([]models.LeagueState, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	r.league.Teams = teams
-	r.league.Matches = matches
-	return r.league, nil
+	leagues := []models.LeagueState{}
+	for rows.Next() {
+		var (
+			league      models.LeagueState
+			status      string
+			tiebreakers string
+			seed        sql.NullInt64
+			parentID    sql.NullInt64
+			playoffs    playoffColumns
+			swiss       sql.NullInt64
+			roster      sql.NullString
+			engine      sql.NullString
+		)
+		if err := rows.Scan(
+			&league.ID,
+			&league.Name,
+			&league.CurrentWeek,
+			&status,
+			&tiebreakers,
+			&seed,
+			&league.Version,
+			&parentID,
+			&league.PromotionPlaces,
+			&league.RelegationPlaces,
+			&playoffs.first,
+			&playoffs.last,
+			&playoffs.legs,
+			&playoffs.prize,
+			&swiss,
+			&roster,
+			&engine,
+			&playoffs.kind,
+			&playoffs.split,
+		); err != nil {
+			return nil, err
+		}
+		league.Status = models.LeagueStatus(status)
+		league.Tiebreakers = splitTiebreakers(tiebreakers)
+		if seed.Valid {
+			league.Seed = &seed.Int64
+		}
+		if parentID.Valid {
+			id := int(parentID.Int64)
+			league.ParentLeagueID = &id
+		}
+		league.Playoffs = playoffs.format()
+		if swiss.Valid {
+			league.Swiss = &models.SwissFormat{OpponentsPerPot: int(swiss.Int64)}
+		}
+		if roster.Valid {
+			if league.NextRoster, err = splitTeamIDs(roster.String); err != nil {
+				return nil, err
+			}
+		}
+		league.MatchEngine = engine.String
+		leagues = append(leagues, league)
+	}
+	return leagues, rows.Err()
 }
 
-func (r *leagueRepository) SaveLeague(league *models.League) error {
-	r.league = league
-	return nil
+// splitTiebreakers veritabanındaki virgülle ayrılmış ölçüt listesini ayırır.
+func splitTiebreakers(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
+func nullableSeed(seed *int64) sql.NullInt64 {
+	if seed == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *seed, Valid: true}
+}
+
+// nullableTeamIDs takım kimliklerini (örneğin ligin bir sonraki kadrosunu) virgülle ayrılmış olarak saklar;
+// liste nil ise NULL döner.
+func nullableTeamIDs(teamIDs []int) sql.NullString {
+	if teamIDs == nil {
+		return sql.NullString{}
+	}
+	ids := make([]string, len(teamIDs))
+	for i, id := range teamIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return sql.NullString{String: strings.Join(ids, ","), Valid: true}
+}
+
+func splitTeamIDs(raw string) ([]int, error) {
+	teamIDs := []int{}
+	if raw == "" {
+		return teamIDs, nil
+	}
+	for _, part := range strings.Split(raw, ",") {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid team ID %q in team list: %w", part, err)
+		}
+		teamIDs = append(teamIDs, id)
+	}
+	return teamIDs, nil
+}
+
+// nullableEngine ligin maç motorunu saklar; lig sunucunun varsayılan motorunu kullanıyorsa NULL döner.
+func nullableEngine(name string) sql.NullString {
+	return sql.NullString{String: name, Valid: name != ""}
+}
+
+func nullableSwiss(format *models.SwissFormat) sql.NullInt64 {
+	if format == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(format.OpponentsPerPot), Valid: true}
+}
+
+// playoffColumns play-off biçiminin Leagues tablosundaki boş bırakılabilir sütunlarıdır; lig play-off
+// oynamıyorsa tümü NULL'dur.
+type playoffColumns struct {
+	first, last, legs, split sql.NullInt64
+	prize, kind              sql.NullString
+}
+
+func nullablePlayoffs(format *models.PlayoffFormat) playoffColumns {
+	if format == nil {
+		return playoffColumns{}
+	}
+	return playoffColumns{
+		first: sql.NullInt64{Int64: int64(format.FirstPlace), Valid: true},
+		last:  sql.NullInt64{Int64: int64(format.LastPlace), Valid: true},
+		legs:  sql.NullInt64{Int64: int64(format.Legs), Valid: true},
+		prize: sql.NullString{String: string(format.Prize), Valid: true},
+		kind:  sql.NullString{String: string(format.Kind), Valid: format.Kind != ""},
+		split: sql.NullInt64{Int64: int64(format.SplitPlace), Valid: format.SplitPlace != 0},
+	}
+}
+
+func (c playoffColumns) format() *models.PlayoffFormat {
+	if !c.first.Valid {
+		return nil
+	}
+	return &models.PlayoffFormat{
+		FirstPlace: int(c.first.Int64),
+		LastPlace:  int(c.last.Int64),
+		Legs:       int(c.legs.Int64),
+		Prize:      models.PlayoffPrize(c.prize.String),
+		Kind:       models.PlayoffKind(c.kind.String),
+		SplitPlace: int(c.split.Int64),
+	}
 }
@@ -2,32 +2,41 @@ package repositories
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
 	"github.com/muzaffertuna/football-league-sim/internal/database"
 )
 
 type matchRepository struct {
-	db *database.DB
+	db querier // *database.DB ya da bir iş birimi içindeyse *sql.Tx
 }
 
 func NewMatchRepository(db *database.DB) MatchRepository {
 	return &matchRepository{db: db}
 }
 
+const matchColumns = `ID, ISNULL(LeagueID, 0), ISNULL(SeasonID, 0), ISNULL(CupID, 0), ISNULL(GroupID, 0), HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played, ISNULL(Seed, 0)`
+
 func (r *matchRepository) CreateMatch(match *models.Match) error {
 	query := `
-		INSERT INTO Matches (HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played)
-		VALUES (@p1, @p2, @p3, @p4, @p5, @p6);
+		INSERT INTO Matches (LeagueID, SeasonID, CupID, GroupID, HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played, Seed)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11);
 		SELECT SCOPE_IDENTITY();`
 	var id int
 	err := r.db.QueryRow(query,
-		sql.Named("p1", match.HomeTeamID),
-		sql.Named("p2", match.AwayTeamID),
-		sql.Named("p3", match.HomeGoals),
-		sql.Named("p4", match.AwayGoals),
-		sql.Named("p5", match.Week),
-		sql.Named("p6", match.Played),
+		sql.Named("p1", nullableKey(match.LeagueID)),
+		sql.Named("p2", nullableKey(match.SeasonID)),
+		sql.Named("p3", nullableKey(match.CupID)),
+		sql.Named("p4", nullableKey(match.GroupID)),
+		sql.Named("p5", match.HomeTeamID),
+		sql.Named("p6", match.AwayTeamID),
+		sql.Named("p7", match.HomeGoals),
+		sql.Named("p8", match.AwayGoals),
+		sql.Named("p9", match.Week),
+		sql.Named("p10", match.Played),
+		sql.Named("p11", match.Seed),
 	).Scan(&id)
 	if err != nil {
 		return err
@@ -38,18 +47,23 @@ func (r *matchRepository) CreateMatch(match *models.Match) error {
 
 func (r *matchRepository) GetMatchByID(id int) (*models.Match, error) {
 	query := `
-		SELECT ID, HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played
+		SELECT ` + matchColumns + `
 		FROM Matches
 		WHERE ID = @p1`
 	match := &models.Match{}
 	err := r.db.QueryRow(query, sql.Named("p1", id)).Scan(
 		&match.ID,
+		&match.LeagueID,
+		&match.SeasonID,
+		&match.CupID,
+		&match.GroupID,
 		&match.HomeTeamID,
 		&match.AwayTeamID,
 		&match.HomeGoals,
 		&match.AwayGoals,
 		&match.Week,
 		&match.Played,
+		&match.Seed,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -60,91 +74,171 @@ func (r *matchRepository) GetMatchByID(id int) (*models.Match, error) {
 	return match, nil
 }
 
-func (r *matchRepository) GetMatchesByWeek(week int) ([]models.Match, error) {
+func (r *matchRepository) GetMatchesByWeek(seasonID, week int) ([]models.Match, error) {
 	query := `
-		SELECT ID, HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played
+		SELECT ` + matchColumns + `
 		FROM Matches
-		WHERE Week = @p1`
-	rows, err := r.db.Query(query, sql.Named("p1", week))
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	matches := []models.Match{}
-	for rows.Next() {
-		match := models.Match{}
-		if err := rows.Scan(
-			&match.ID,
-			&match.HomeTeamID,
-			&match.AwayTeamID,
-			&match.HomeGoals,
-			&match.AwayGoals,
-			&match.Week,
-			&match.Played,
-		); err != nil {
-			return nil, err
-		}
-		matches = append(matches, match)
-	}
-	return matches, nil
+		WHERE SeasonID = @p1 AND Week = @p2`
+	return r.queryMatches(query, sql.Named("p1", seasonID), sql.Named("p2", week))
 }
 
 func (r *matchRepository) UpdateMatch(match *models.Match) error {
 	query := `
 		UPDATE Matches
-		SET HomeGoals = @p1, AwayGoals = @p2, Played = @p3
-		WHERE ID = @p4`
+		SET HomeGoals = @p1, AwayGoals = @p2, Played = @p3, Seed = @p4
+		WHERE ID = @p5`
 	_, err := r.db.Exec(query,
 		sql.Named("p1", match.HomeGoals),
 		sql.Named("p2", match.AwayGoals),
 		sql.Named("p3", match.Played),
-		sql.Named("p4", match.ID),
+		sql.Named("p4", match.Seed),
+		sql.Named("p5", match.ID),
 	)
 	return err
 }
 
-func (r *matchRepository) DeleteAllMatches() error {
-	query := "DELETE FROM Matches"
-	_, err := r.db.Exec(query)
+func (r *matchRepository) DeleteMatchesBySeason(seasonID int) error {
+	query := "DELETE FROM Matches WHERE SeasonID = @p1"
+	_, err := r.db.Exec(query, sql.Named("p1", seasonID))
 	return err
 }
 
-func (r *matchRepository) GetAllMatches() ([]models.Match, error) {
+func (r *matchRepository) GetMatchesBySeason(seasonID int) ([]models.Match, error) {
 	query := `
-		SELECT ID, HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played
-		FROM Matches`
-	rows, err := r.db.Query(query)
+		SELECT ` + matchColumns + `
+		FROM Matches
+		WHERE SeasonID = @p1`
+	return r.queryMatches(query, sql.Named("p1", seasonID))
+}
+
+func (r *matchRepository) GetMatchesByLeague(leagueID int) ([]models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM Matches
+		WHERE LeagueID = @p1`
+	return r.queryMatches(query, sql.Named("p1", leagueID))
+}
+
+func (r *matchRepository) GetMatchesByCup(cupID int) ([]models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM Matches
+		WHERE CupID = @p1`
+	return r.queryMatches(query, sql.Named("p1", cupID))
+}
+
+func (r *matchRepository) GetMatchesByGroup(groupID int) ([]models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM Matches
+		WHERE GroupID = @p1`
+	return r.queryMatches(query, sql.Named("p1", groupID))
+}
+
+// GetPlayedMatches tüm liglerin, kupaların ve turnuvaların oynanmış maçlarını döndürür (örneğin maç motorunun parametrelerini kestirmek için).
+func (r *matchRepository) GetPlayedMatches() ([]models.Match, error) {
+	query := `
+		SELECT ` + matchColumns + `
+		FROM Matches
+		WHERE Played = 1`
+	return r.queryMatches(query)
+}
+
+// GetTotalWeeks sezonun fikstüründeki en son haftayı, yani sezonun kaç hafta sürdüğünü döndürür.
+func (r *matchRepository) GetTotalWeeks(seasonID int) (int, error) {
+	query := `
+		SELECT ISNULL(MAX(Week), 0)
+		FROM Matches
+		WHERE SeasonID = @p1;`
+
+	var totalWeeks int
+	err := r.db.QueryRow(query, sql.Named("p1", seasonID)).Scan(&totalWeeks)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
+	return totalWeeks, nil
+}
 
-	matches := []models.Match{}
-	for rows.Next() {
-		match := models.Match{}
-		if err := rows.Scan(
-			&match.ID,
-			&match.HomeTeamID,
-			&match.AwayTeamID,
-			&match.HomeGoals,
-			&match.AwayGoals,
-			&match.Week,
-			&match.Played,
-		); err != nil {
-			return nil, err
+func (r *matchRepository) FindMatches(filter MatchFilter) ([]models.Match, int, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, value any) {
+		name := fmt.Sprintf("p%d", len(args)+1)
+		conditions = append(conditions, strings.ReplaceAll(condition, "@p", "@"+name))
+		args = append(args, sql.Named(name, value))
+	}
+	if filter.SeasonID != 0 {
+		where("SeasonID = @p", filter.SeasonID)
+	}
+	if filter.Week != 0 {
+		where("Week = @p", filter.Week)
+	}
+	if filter.TeamID != 0 {
+		switch filter.Venue {
+		case models.MatchVenueHome:
+			where("HomeTeamID = @p", filter.TeamID)
+		case models.MatchVenueAway:
+			where("AwayTeamID = @p", filter.TeamID)
+		default:
+			where("(HomeTeamID = @p OR AwayTeamID = @p)", filter.TeamID)
 		}
-		matches = append(matches, match)
 	}
-	return matches, nil
+	if filter.Played != nil {
+		where("Played = @p", *filter.Played)
+	}
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM Matches "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	orderBy := fmt.Sprintf("Week %[1]s, ID %[1]s", direction)
+	if filter.Sort == models.MatchSortID {
+		orderBy = "ID " + direction
+	}
+	query := `
+		SELECT ` + matchColumns + `
+		FROM Matches
+		` + whereClause + `
+		ORDER BY ` + orderBy + `
+		OFFSET @offset ROWS`
+	args = append(args, sql.Named("offset", filter.Offset))
+	if filter.Limit > 0 {
+		query += " FETCH NEXT @limit ROWS ONLY"
+		args = append(args, sql.Named("limit", filter.Limit))
+	}
+	matches, err := r.queryMatches(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return matches, total, nil
 }
 
-func (r *matchRepository) GetPlayedMatches() ([]models.Match, error) {
+func (r *matchRepository) CountMatchesByTeam(teamID int) (int, error) {
 	query := `
-		SELECT ID, HomeTeamID, AwayTeamID, HomeGoals, AwayGoals, Week, Played
+		SELECT COUNT(*)
 		FROM Matches
-		WHERE Played = 1`
-	rows, err := r.db.Query(query)
+		WHERE HomeTeamID = @p1 OR AwayTeamID = @p1;`
+
+	var count int
+	if err := r.db.QueryRow(query, sql.Named("p1", teamID)).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *matchRepository) queryMatches(query string, args ...any) ([]models.Match, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -155,30 +249,29 @@ func (r *matchRepository) GetPlayedMatches() ([]models.Match, error) {
 		match := models.Match{}
 		if err := rows.Scan(
 			&match.ID,
+			&match.LeagueID,
+			&match.SeasonID,
+			&match.CupID,
+			&match.GroupID,
 			&match.HomeTeamID,
 			&match.AwayTeamID,
 			&match.HomeGoals,
 			&match.AwayGoals,
 			&match.Week,
 			&match.Played,
+			&match.Seed,
 		); err != nil {
 			return nil, err
 		}
 		matches = append(matches, match)
 	}
-	return matches, nil
+	return matches, rows.Err()
 }
 
-func (r *matchRepository) GetMaxWeekPlayed() (int, error) {
-	query := `
-		SELECT ISNULL(MAX(Week), 0)
-		FROM Matches
-		WHERE Played = 1;`
-
-	var maxWeek int
-	err := r.db.QueryRow(query).Scan(&maxWeek)
-	if err != nil {
-		return 0, err
+// nullableKey 0 olan (yani maçın ait olmadığı) lig, sezon, kupa ya da grup kimliğini NULL olarak yazar.
+func nullableKey(id int) sql.NullInt64 {
+	if id == 0 {
+		return sql.NullInt64{}
 	}
-	return maxWeek, nil
+	return sql.NullInt64{Int64: int64(id), Valid: true}
 }
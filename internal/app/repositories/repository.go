@@ -1,26 +1,126 @@
 package repositories
 
-import "github.com/muzaffertuna/football-league-sim/internal/app/models"
+import (
+	"errors"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// ErrVersionConflict, güncellenen kaydın sürümü okunduğundan beri değiştiyse döner.
+var ErrVersionConflict = errors.New("record was modified concurrently")
+
+// ErrDuplicateName, kaydedilen takımın adı (büyük/küçük harf farkı gözetmeden) başka bir takımda kullanılıyorsa döner.
+var ErrDuplicateName = errors.New("name is already used")
 
 type TeamRepository interface {
 	CreateTeam(team *models.Team) error
 	GetTeamByID(id int) (*models.Team, error)
 	GetAllTeams() ([]models.Team, error)
+	GetTeamsByLeague(leagueID int) ([]models.Team, error)
 	UpdateTeam(team *models.Team) error
+	DeleteTeam(id int) error
 }
 
 type MatchRepository interface {
 	CreateMatch(match *models.Match) error
 	GetMatchByID(id int) (*models.Match, error)
-	GetMatchesByWeek(week int) ([]models.Match, error)
+	GetMatchesByWeek(seasonID, week int) ([]models.Match, error)
 	UpdateMatch(match *models.Match) error
-	DeleteAllMatches() error
-	GetAllMatches() ([]models.Match, error)
+	DeleteMatchesBySeason(seasonID int) error
+	GetMatchesBySeason(seasonID int) ([]models.Match, error)
+	// GetMatchesByLeague ligin tüm sezonlarındaki maçları döndürür.
+	GetMatchesByLeague(leagueID int) ([]models.Match, error)
+	GetMatchesByCup(cupID int) ([]models.Match, error)
+	GetMatchesByGroup(groupID int) ([]models.Match, error)
 	GetPlayedMatches() ([]models.Match, error)
-	GetMaxWeekPlayed() (int, error)
+	GetTotalWeeks(seasonID int) (int, error)
+	// FindMatches ölçütlere uyan maçların filter.Limit ve filter.Offset ile seçilen sayfasını ve ölçütlere
+	// uyan tüm maçların sayısını döndürür.
+	FindMatches(filter MatchFilter) ([]models.Match, int, error)
+	// CountMatchesByTeam takımın oynadığı ya da fikstürde bekleyen tüm maçların (lig, kupa ve turnuva) sayısını döndürür.
+	CountMatchesByTeam(teamID int) (int, error)
+}
+
+// MatchFilter FindMatches sorgusunun ölçütleridir. Sıfır değerli alanlar filtre uygulamaz.
+type MatchFilter struct {
+	SeasonID   int
+	Week       int
+	TeamID     int
+	Venue      models.MatchVenue // TeamID ile birlikte verilir; boşsa takımın tüm maçları seçilir
+	Played     *bool
+	Sort       models.MatchSort // Boşsa MatchSortWeek
+	Descending bool
+	Limit      int // 0 ise tüm maçlar döner
+	Offset     int
+}
+
+// Repositories bir iş birimi içinde kullanılacak depolardır.
+type Repositories struct {
+	Teams       TeamRepository
+	Matches     MatchRepository
+	Leagues     LeagueRepository
+	Seasons     SeasonRepository
+	Cups        CupRepository
+	Tournaments TournamentRepository
 }
 
+// UnitOfWork birden çok depo işlemini tek bir işlem (transaction) olarak çalıştırır.
+type UnitOfWork interface {
+	// Do fn'i bir işlem içinde çalıştırır; fn'e verilen depolarla yapılan değişiklikler fn nil dönerse
+	// birlikte kaydedilir, hata dönerse (ya da panic olursa) birlikte geri alınır.
+	Do(fn func(repos Repositories) error) error
+}
+
+// LeagueRepository liglerin kalıcı durumunu (güncel hafta, sezon durumu, kurallar, tohum) saklar.
 type LeagueRepository interface {
-	GetLeague() (*models.League, error)
-	SaveLeague(league *models.League) error
+	// GetLeagueByID ligi döndürür; lig yoksa nil döner.
+	GetLeagueByID(id int) (*models.LeagueState, error)
+	// GetDefaultLeague kimliği en küçük olan ligi, yani lig kimliği verilmeyen isteklerin kullandığı ligi
+	// döndürür; hiç lig yoksa nil döner.
+	GetDefaultLeague() (*models.LeagueState, error)
+	GetAllLeagues() ([]models.LeagueState, error)
+	CreateLeague(league *models.LeagueState) error
+	// UpdateLeague ligi yalnızca kayıtlı sürüm league.Version ile aynıysa günceller ve sürümü bir artırır;
+	// aksi halde ErrVersionConflict döner.
+	UpdateLeague(league *models.LeagueState) error
+}
+
+// SeasonRepository liglerin sezonlarını saklar.
+type SeasonRepository interface {
+	CreateSeason(season *models.Season) error
+	// GetCurrentSeason ligin numarası en büyük, yani oynanmakta olan sezonunu döndürür; sezon yoksa nil döner.
+	GetCurrentSeason(leagueID int) (*models.Season, error)
+	// GetSeason ligin verilen numaralı sezonunu döndürür; sezon yoksa nil döner.
+	GetSeason(leagueID, number int) (*models.Season, error)
+	GetSeasonsByLeague(leagueID int) ([]models.Season, error)
+	UpdateSeason(season *models.Season) error
+}
+
+// CupRepository kupaları, katılımcılarını ve eşleşmelerini saklar. Kupa maçları MatchRepository'de tutulur.
+type CupRepository interface {
+	// CreateCup kupayı cup.TeamIDs sırasıyla seri başı sıraları verilmiş katılımcılarıyla birlikte oluşturur.
+	CreateCup(cup *models.Cup) error
+	// GetCupByID kupayı katılımcılarıyla birlikte döndürür; kupa yoksa nil döner.
+	GetCupByID(id int) (*models.Cup, error)
+	GetAllCups() ([]models.Cup, error)
+	// UpdateCup kupayı yalnızca kayıtlı sürüm cup.Version ile aynıysa günceller ve sürümü bir artırır;
+	// aksi halde ErrVersionConflict döner. Katılımcılar değiştirilmez.
+	UpdateCup(cup *models.Cup) error
+	CreateTie(tie *models.CupTie) error
+	UpdateTie(tie *models.CupTie) error
+	// GetTiesByCup kupanın tüm eşleşmelerini tur ve sıra numarasına göre sıralı döndürür.
+	GetTiesByCup(cupID int) ([]models.CupTie, error)
+}
+
+// TournamentRepository turnuvaları ve gruplarını saklar. Grup maçları MatchRepository'de, eleme aşaması
+// CupRepository'de tutulur.
+type TournamentRepository interface {
+	// CreateTournament turnuvayı grupları ve grupların takımlarıyla birlikte oluşturur; grupların kimlikleri de doldurulur.
+	CreateTournament(tournament *models.Tournament) error
+	// GetTournamentByID turnuvayı gruplarıyla birlikte döndürür; turnuva yoksa nil döner.
+	GetTournamentByID(id int) (*models.Tournament, error)
+	GetAllTournaments() ([]models.Tournament, error)
+	// UpdateTournament turnuvanın durumunu ve eleme kupasını yalnızca kayıtlı sürüm tournament.Version ile
+	// aynıysa günceller ve sürümü bir artırır; aksi halde ErrVersionConflict döner. Gruplar değiştirilmez.
+	UpdateTournament(tournament *models.Tournament) error
 }
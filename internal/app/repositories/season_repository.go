@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/database"
+)
+
+type seasonRepository struct {
+	db querier
+}
+
+func NewSeasonRepository(db *database.DB) SeasonRepository {
+	return &seasonRepository{db: db}
+}
+
+const seasonColumns = `ID, LeagueID, Number, ChampionTeamID, ISNULL(Tiebreakers, ''), StartedAt, EndedAt, PlayoffCupID, ISNULL(PlayoffPrize, ''),
+	SplitWeek, SplitTeamIDs`
+
+func (r *seasonRepository) CreateSeason(season *models.Season) error {
+	query := `
+		INSERT INTO Seasons (LeagueID, Number, ChampionTeamID, Tiebreakers, StartedAt, EndedAt, PlayoffCupID, PlayoffPrize,
+			SplitWeek, SplitTeamIDs)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10);
+		SELECT SCOPE_IDENTITY();`
+	splitWeek, splitTeams := nullableSplit(season.Split)
+	var id int
+	err := r.db.QueryRow(query,
+		sql.Named("p1", season.LeagueID),
+		sql.Named("p2", season.Number),
+		sql.Named("p3", nullableID(season.ChampionTeamID)),
+		sql.Named("p4", nullableTiebreakers(season.Tiebreakers)),
+		sql.Named("p5", season.StartedAt),
+		sql.Named("p6", nullableTime(season.EndedAt)),
+		sql.Named("p7", nullableID(season.PlayoffCupID)),
+		sql.Named("p8", nullablePrize(season.PlayoffPrize)),
+		sql.Named("p9", splitWeek),
+		sql.Named("p10", splitTeams),
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+	season.ID = id
+	return nil
+}
+
+func (r *seasonRepository) GetCurrentSeason(leagueID int) (*models.Season, error) {
+	query := `
+		SELECT TOP 1 ` + seasonColumns + `
+		FROM Seasons
+		WHERE LeagueID = @p1
+		ORDER BY Number DESC`
+	return r.querySeason(query, sql.Named("p1", leagueID))
+}
+
+func (r *seasonRepository) GetSeason(leagueID, number int) (*models.Season, error) {
+	query := `
+		SELECT ` + seasonColumns + `
+		FROM Seasons
+		WHERE LeagueID = @p1 AND Number = @p2`
+	return r.querySeason(query, sql.Named("p1", leagueID), sql.Named("p2", number))
+}
+
+func (r *seasonRepository) GetSeasonsByLeague(leagueID int) ([]models.Season, error) {
+	query := `
+		SELECT ` + seasonColumns + `
+		FROM Seasons
+		WHERE LeagueID = @p1
+		ORDER BY Number`
+	return r.querySeasons(query, sql.Named("p1", leagueID))
+}
+
+func (r *seasonRepository) UpdateSeason(season *models.Season) error {
+	query := `
+		UPDATE Seasons
+		SET ChampionTeamID = @p1, Tiebreakers = @p2, EndedAt = @p3, PlayoffCupID = @p4, PlayoffPrize = @p5,
+			SplitWeek = @p6, SplitTeamIDs = @p7
+		WHERE ID = @p8`
+	splitWeek, splitTeams := nullableSplit(season.Split)
+	_, err := r.db.Exec(query,
+		sql.Named("p1", nullableID(season.ChampionTeamID)),
+		sql.Named("p2", nullableTiebreakers(season.Tiebreakers)),
+		sql.Named("p3", nullableTime(season.EndedAt)),
+		sql.Named("p4", nullableID(season.PlayoffCupID)),
+		sql.Named("p5", nullablePrize(season.PlayoffPrize)),
+		sql.Named("p6", splitWeek),
+		sql.Named("p7", splitTeams),
+		sql.Named("p8", season.ID),
+	)
+	return err
+}
+
+func (r *seasonRepository) querySeason(query string, args ...any) (*models.Season, error) {
+	seasons, err := r.querySeasons(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(seasons) == 0 {
+		return nil, nil
+	}
+	return &seasons[0], nil
+}
+
+func (r *seasonRepository) querySeasons(query string, args ...any) ([]models.Season, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seasons := []models.Season{}
+	for rows.Next() {
+		var (
+			season      models.Season
+			championID  sql.NullInt64
+			tiebreakers string
+			endedAt     sql.NullTime
+			playoffCup  sql.NullInt64
+			prize       string
+			splitWeek   sql.NullInt64
+			splitTeams  sql.NullString
+		)
+		if err := rows.Scan(
+			&season.ID,
+			&season.LeagueID,
+			&season.Number,
+			&championID,
+			&tiebreakers,
+			&season.StartedAt,
+			&endedAt,
+			&playoffCup,
+			&prize,
+			&splitWeek,
+			&splitTeams,
+		); err != nil {
+			return nil, err
+		}
+		if championID.Valid {
+			id := int(championID.Int64)
+			season.ChampionTeamID = &id
+		}
+		season.Tiebreakers = splitTiebreakers(tiebreakers)
+		if endedAt.Valid {
+			season.EndedAt = &endedAt.Time
+		}
+		if playoffCup.Valid {
+			id := int(playoffCup.Int64)
+			season.PlayoffCupID = &id
+		}
+		season.PlayoffPrize = models.PlayoffPrize(prize)
+		if splitWeek.Valid {
+			upper, err := splitTeamIDs(splitTeams.String)
+			if err != nil {
+				return nil, err
+			}
+			season.Split = &models.SeasonSplit{Week: int(splitWeek.Int64), UpperTeamIDs: upper}
+		}
+		seasons = append(seasons, season)
+	}
+	return seasons, rows.Err()
+}
+
+func nullableID(id *int) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*id), Valid: true}
+}
+
+func nullableTiebreakers(tiebreakers []string) sql.NullString {
+	if len(tiebreakers) == 0 {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strings.Join(tiebreakers, ","), Valid: true}
+}
+
+func nullablePrize(prize models.PlayoffPrize) sql.NullString {
+	if prize == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(prize), Valid: true}
+}
+
+// nullableSplit sezonun bölünmesini ilk haftası ve üst gruptaki takımlar olarak saklar; sezon bölünmediyse
+// ikisi de NULL'dur.
+func nullableSplit(split *models.SeasonSplit) (sql.NullInt64, sql.NullString) {
+	if split == nil {
+		return sql.NullInt64{}, sql.NullString{}
+	}
+	return sql.NullInt64{Int64: int64(split.Week), Valid: true}, nullableTeamIDs(split.UpperTeamIDs)
+}
+
+func nullableTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -2,38 +2,50 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 
+	mssql "github.com/microsoft/go-mssqldb"
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
 	"github.com/muzaffertuna/football-league-sim/internal/database"
 )
 
 type teamRepository struct {
-	db *database.DB
+	db querier // *database.DB ya da bir iş birimi içindeyse *sql.Tx
 }
 
 func NewTeamRepository(db *database.DB) TeamRepository {
 	return &teamRepository{db: db}
 }
 
+const teamColumns = `ID, ISNULL(LeagueID, 0), Name, Strength, Points, GoalsFor, GoalsAgainst, MatchesPlayed, Wins, Draws, Loses, Attack, Defense, FairPlayPoints,
+	ISNULL(Pot, 0), ISNULL(Country, '')`
+
 func (r *teamRepository) CreateTeam(team *models.Team) error {
 	query := `
-		INSERT INTO Teams (Name, Strength, Points, GoalsFor, GoalsAgainst, MatchesPlayed, Wins, Draws, Loses)
-		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9);
+		INSERT INTO Teams (LeagueID, Name, Strength, Points, GoalsFor, GoalsAgainst, MatchesPlayed, Wins, Draws, Loses, Attack, Defense, FairPlayPoints, Pot, Country)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7, @p8, @p9, @p10, @p11, @p12, @p13, @p14, @p15);
 		SELECT SCOPE_IDENTITY();`
 	var id int
 	err := r.db.QueryRow(query,
-		sql.Named("p1", team.Name),
-		sql.Named("p2", team.Strength),
-		sql.Named("p3", team.Points),
-		sql.Named("p4", team.GoalsFor),
-		sql.Named("p5", team.GoalsAgainst),
-		sql.Named("p6", team.MatchesPlayed),
-		sql.Named("p7", team.Wins),  // Yeni eklendi
-		sql.Named("p8", team.Draws), // Yeni eklendi
-		sql.Named("p9", team.Loses), // Yeni eklendi
+		sql.Named("p1", nullableLeagueID(team.LeagueID)),
+		sql.Named("p2", team.Name),
+		sql.Named("p3", team.Strength),
+		sql.Named("p4", team.Points),
+		sql.Named("p5", team.GoalsFor),
+		sql.Named("p6", team.GoalsAgainst),
+		sql.Named("p7", team.MatchesPlayed),
+		sql.Named("p8", team.Wins),
+		sql.Named("p9", team.Draws),
+		sql.Named("p10", team.Loses),
+		sql.Named("p11", team.Attack),
+		sql.Named("p12", team.Defense),
+		sql.Named("p13", team.FairPlayPoints),
+		sql.Named("p14", nullablePot(team.Pot)),
+		sql.Named("p15", nullableCountry(team.Country)),
 	).Scan(&id)
 	if err != nil {
-		return err
+		return duplicateName(err)
 	}
 	team.ID = id
 	return nil
@@ -41,36 +53,68 @@ func (r *teamRepository) CreateTeam(team *models.Team) error {
 
 func (r *teamRepository) GetTeamByID(id int) (*models.Team, error) {
 	query := `
-		SELECT ID, Name, Strength, Points, GoalsFor, GoalsAgainst, MatchesPlayed, Wins, Draws, Loses
+		SELECT ` + teamColumns + `
 		FROM Teams
 		WHERE ID = @p1`
-	team := &models.Team{}
-	err := r.db.QueryRow(query, sql.Named("p1", id)).Scan(
-		&team.ID,
-		&team.Name,
-		&team.Strength,
-		&team.Points,
-		&team.GoalsFor,
-		&team.GoalsAgainst,
-		&team.MatchesPlayed,
-		&team.Wins,  // Yeni eklendi
-		&team.Draws, // Yeni eklendi
-		&team.Loses, // Yeni eklendi
-	)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
+	teams, err := r.queryTeams(query, sql.Named("p1", id))
 	if err != nil {
 		return nil, err
 	}
-	return team, nil
+	if len(teams) == 0 {
+		return nil, nil
+	}
+	return &teams[0], nil
 }
 
 func (r *teamRepository) GetAllTeams() ([]models.Team, error) {
 	query := `
-		SELECT ID, Name, Strength, Points, GoalsFor, GoalsAgainst, MatchesPlayed, Wins, Draws, Loses
+		SELECT ` + teamColumns + `
 		FROM Teams`
-	rows, err := r.db.Query(query)
+	return r.queryTeams(query)
+}
+
+func (r *teamRepository) GetTeamsByLeague(leagueID int) ([]models.Team, error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM Teams
+		WHERE LeagueID = @p1`
+	return r.queryTeams(query, sql.Named("p1", leagueID))
+}
+
+func (r *teamRepository) UpdateTeam(team *models.Team) error {
+	query := `
+		UPDATE Teams
+		SET Name = @p1, Strength = @p2, Points = @p3, GoalsFor = @p4, GoalsAgainst = @p5, MatchesPlayed = @p6, Wins = @p7, Draws = @p8, Loses = @p9, Attack = @p10, Defense = @p11, FairPlayPoints = @p12,
+			Pot = @p13, Country = @p14, LeagueID = @p15
+		WHERE ID = @p16`
+	_, err := r.db.Exec(query,
+		sql.Named("p1", team.Name),
+		sql.Named("p2", team.Strength),
+		sql.Named("p3", team.Points),
+		sql.Named("p4", team.GoalsFor),
+		sql.Named("p5", team.GoalsAgainst),
+		sql.Named("p6", team.MatchesPlayed),
+		sql.Named("p7", team.Wins),
+		sql.Named("p8", team.Draws),
+		sql.Named("p9", team.Loses),
+		sql.Named("p10", team.Attack),
+		sql.Named("p11", team.Defense),
+		sql.Named("p12", team.FairPlayPoints),
+		sql.Named("p13", nullablePot(team.Pot)),
+		sql.Named("p14", nullableCountry(team.Country)),
+		sql.Named("p15", nullableLeagueID(team.LeagueID)),
+		sql.Named("p16", team.ID),
+	)
+	return duplicateName(err)
+}
+
+func (r *teamRepository) DeleteTeam(id int) error {
+	_, err := r.db.Exec(`DELETE FROM Teams WHERE ID = @p1`, sql.Named("p1", id))
+	return err
+}
+
+func (r *teamRepository) queryTeams(query string, args ...any) ([]models.Team, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +125,7 @@ func (r *teamRepository) GetAllTeams() ([]models.Team, error) {
 		team := models.Team{}
 		if err := rows.Scan(
 			&team.ID,
+			&team.LeagueID,
 			&team.Name,
 			&team.Strength,
 			&team.Points,
@@ -90,30 +135,48 @@ func (r *teamRepository) GetAllTeams() ([]models.Team, error) {
 			&team.Wins,
 			&team.Draws,
 			&team.Loses,
+			&team.Attack,
+			&team.Defense,
+			&team.FairPlayPoints,
+			&team.Pot,
+			&team.Country,
 		); err != nil {
 			return nil, err
 		}
 		teams = append(teams, team)
 	}
-	return teams, nil
+	return teams, rows.Err()
 }
 
-func (r *teamRepository) UpdateTeam(team *models.Team) error {
-	query := `
-		UPDATE Teams
-		SET Name = @p1, Strength = @p2, Points = @p3, GoalsFor = @p4, GoalsAgainst = @p5, MatchesPlayed = @p6, Wins = @p7, Draws = @p8, Loses = @p9
-		WHERE ID = @p10`
-	_, err := r.db.Exec(query,
-		sql.Named("p1", team.Name),
-		sql.Named("p2", team.Strength),
-		sql.Named("p3", team.Points),
-		sql.Named("p4", team.GoalsFor),
-		sql.Named("p5", team.GoalsAgainst),
-		sql.Named("p6", team.MatchesPlayed),
-		sql.Named("p7", team.Wins),
-		sql.Named("p8", team.Draws),
-		sql.Named("p9", team.Loses),
-		sql.Named("p10", team.ID),
-	)
+// nullableLeagueID bir lige bağlı olmayan takımlar için NULL döndürür.
+func nullableLeagueID(leagueID int) sql.NullInt64 {
+	if leagueID == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(leagueID), Valid: true}
+}
+
+// nullablePot torbası olmayan (çift devreli lig) takımlar için NULL döndürür.
+func nullablePot(pot int) sql.NullInt64 {
+	if pot == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(pot), Valid: true}
+}
+
+func nullableCountry(country string) sql.NullString {
+	if country == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: country, Valid: true}
+}
+
+// duplicateName takım adlarının benzersiz dizinini (UX_Teams_Name) ihlal eden yazma hatalarını ErrDuplicateName'e
+// çevirir. 2601 benzersiz dizin, 2627 benzersiz kısıt ihlalidir.
+func duplicateName(err error) error {
+	var sqlErr mssql.Error
+	if errors.As(err, &sqlErr) && (sqlErr.Number == 2601 || sqlErr.Number == 2627) {
+		return fmt.Errorf("%w: %s", ErrDuplicateName, sqlErr.Message)
+	}
 	return err
 }
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/database"
+)
+
+type tournamentRepository struct {
+	db querier
+}
+
+func NewTournamentRepository(db *database.DB) TournamentRepository {
+	return &tournamentRepository{db: db}
+}
+
+const tournamentColumns = `ID, Name, GroupLegs, KnockoutLegs, ISNULL(Tiebreakers, ''), Seed, Status, CupID, Version`
+
+func (r *tournamentRepository) CreateTournament(tournament *models.Tournament) error {
+	query := `
+		INSERT INTO Tournaments (Name, GroupLegs, KnockoutLegs, Tiebreakers, Seed, Status, CupID)
+		VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7);
+		SELECT SCOPE_IDENTITY();`
+	var id int
+	err := r.db.QueryRow(query,
+		sql.Named("p1", tournament.Name),
+		sql.Named("p2", tournament.GroupLegs),
+		sql.Named("p3", tournament.KnockoutLegs),
+		sql.Named("p4", nullableTiebreakers(tournament.Tiebreakers)),
+		sql.Named("p5", nullableSeed(tournament.Seed)),
+		sql.Named("p6", string(tournament.Status)),
+		sql.Named("p7", nullableID(tournament.CupID)),
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+	tournament.ID = id
+
+	for i := range tournament.Groups {
+		group := &tournament.Groups[i]
+		group.TournamentID = tournament.ID
+		query := `
+			INSERT INTO TournamentGroups (TournamentID, Name)
+			VALUES (@p1, @p2);
+			SELECT SCOPE_IDENTITY();`
+		if err := r.db.QueryRow(query, sql.Named("p1", group.TournamentID), sql.Named("p2", group.Name)).Scan(&group.ID); err != nil {
+			return err
+		}
+		for _, teamID := range group.TeamIDs {
+			query := `
+				INSERT INTO TournamentGroupTeams (GroupID, TeamID)
+				VALUES (@p1, @p2)`
+			if _, err := r.db.Exec(query, sql.Named("p1", group.ID), sql.Named("p2", teamID)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *tournamentRepository) GetTournamentByID(id int) (*models.Tournament, error) {
+	query := `
+		SELECT ` + tournamentColumns + `
+		FROM Tournaments
+		WHERE ID = @p1`
+	tournaments, err := r.queryTournaments(query, sql.Named("p1", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(tournaments) == 0 {
+		return nil, nil
+	}
+	return &tournaments[0], nil
+}
+
+func (r *tournamentRepository) GetAllTournaments() ([]models.Tournament, error) {
+	query := `
+		SELECT ` + tournamentColumns + `
+		FROM Tournaments
+		ORDER BY ID`
+	return r.queryTournaments(query)
+}
+
+func (r *tournamentRepository) UpdateTournament(tournament *models.Tournament) error {
+	query := `
+		UPDATE Tournaments
+		SET Status = @p1, CupID = @p2, Version = Version + 1
+		WHERE ID = @p3 AND Version = @p4`
+	result, err := r.db.Exec(query,
+		sql.Named("p1", string(tournament.Status)),
+		sql.Named("p2", nullableID(tournament.CupID)),
+		sql.Named("p3", tournament.ID),
+		sql.Named("p4", tournament.Version),
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVersionConflict
+	}
+	tournament.Version++
+	return nil
+}
+
+// queryTournaments turnuvaları ve gruplarını okur.
+func (r *tournamentRepository) queryTournaments(query string, args ...any) ([]models.Tournament, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournaments := []models.Tournament{}
+	for rows.Next() {
+		var (
+			tournament  models.Tournament
+			tiebreakers string
+			seed        sql.NullInt64
+			status      string
+			cupID       sql.NullInt64
+		)
+		if err := rows.Scan(
+			&tournament.ID,
+			&tournament.Name,
+			&tournament.GroupLegs,
+			&tournament.KnockoutLegs,
+			&tiebreakers,
+			&seed,
+			&status,
+			&cupID,
+			&tournament.Version,
+		); err != nil {
+			return nil, err
+		}
+		tournament.Tiebreakers = splitTiebreakers(tiebreakers)
+		tournament.Status = models.LeagueStatus(status)
+		if seed.Valid {
+			tournament.Seed = &seed.Int64
+		}
+		if cupID.Valid {
+			id := int(cupID.Int64)
+			tournament.CupID = &id
+		}
+		tournaments = append(tournaments, tournament)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range tournaments {
+		groups, err := r.tournamentGroups(tournaments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		tournaments[i].Groups = groups
+	}
+	return tournaments, nil
+}
+
+// tournamentGroups turnuvanın gruplarını ada göre sıralı, takımlarıyla birlikte döndürür.
+func (r *tournamentRepository) tournamentGroups(tournamentID int) ([]models.TournamentGroup, error) {
+	query := `
+		SELECT g.ID, g.Name, gt.TeamID
+		FROM TournamentGroups g
+		JOIN TournamentGroupTeams gt ON gt.GroupID = g.ID
+		WHERE g.TournamentID = @p1
+		ORDER BY g.Name, gt.TeamID`
+	rows, err := r.db.Query(query, sql.Named("p1", tournamentID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []models.TournamentGroup{}
+	for rows.Next() {
+		var (
+			groupID int
+			name    string
+			teamID  int
+		)
+		if err := rows.Scan(&groupID, &name, &teamID); err != nil {
+			return nil, err
+		}
+		if len(groups) == 0 || groups[len(groups)-1].ID != groupID {
+			groups = append(groups, models.TournamentGroup{ID: groupID, TournamentID: tournamentID, Name: name})
+		}
+		group := &groups[len(groups)-1]
+		group.TeamIDs = append(group.TeamIDs, teamID)
+	}
+	return groups, rows.Err()
+}
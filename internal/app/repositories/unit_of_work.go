@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/muzaffertuna/football-league-sim/internal/database"
+)
+
+// querier *sql.DB ve *sql.Tx'in SQL depolarının kullandığı ortak yöntemleridir; böylece aynı depo
+// kodu hem doğrudan bağlantıyla hem de bir işlem içinde çalışır.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type unitOfWork struct {
+	db *database.DB
+}
+
+// NewUnitOfWork SQL Server işlemleriyle çalışan bir iş birimi oluşturur.
+func NewUnitOfWork(db *database.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(fn func(repos Repositories) error) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	repos := Repositories{
+		Teams:       &teamRepository{db: tx},
+		Matches:     &matchRepository{db: tx},
+		Leagues:     &leagueRepository{db: tx},
+		Seasons:     &seasonRepository{db: tx},
+		Cups:        &cupRepository{db: tx},
+		Tournaments: &tournamentRepository{db: tx},
+	}
+	if err := fn(repos); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
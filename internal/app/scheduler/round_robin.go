@@ -0,0 +1,114 @@
+package scheduler
+
+import "fmt"
+
+// bye, tek sayıda takım olduğunda eşleşmeyi tamamlamak için kullanılan boş takım kimliğidir.
+// Bu takımla eşleşen takım o hafta maç yapmaz.
+const bye = 0
+
+// Fixture fikstürdeki tek bir maçı (ev sahibi, deplasman ve hafta) temsil eder.
+type Fixture struct {
+	HomeTeamID int
+	AwayTeamID int
+	Week       int
+}
+
+// DoubleRoundRobin verilen takımlar için çift devreli (iç saha / deplasman) bir fikstür üretir.
+// Çember (circle) yöntemi kullanılır: tek sayıda takım varsa her takım her devrede bir hafta bay geçer.
+// İkinci devre ilk devrenin ev/deplasman olarak ters çevrilmiş halidir; devre arasında bir takımın
+// üst üste ikiden fazla iç saha ya da deplasman maçı yapmaması için ikinci devre bir hafta kaydırılır.
+func DoubleRoundRobin(teamIDs []int) ([]Fixture, error) {
+	rounds, err := singleRoundRobin(teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	numRounds := len(rounds)
+	fixtures := make([]Fixture, 0, 2*numRounds*len(teamIDs)/2)
+	for r, pairs := range rounds {
+		for _, p := range pairs {
+			fixtures = append(fixtures, Fixture{HomeTeamID: p[0], AwayTeamID: p[1], Week: r + 1})
+		}
+	}
+	for k := 0; k < numRounds; k++ {
+		pairs := rounds[(k+1)%numRounds]
+		for _, p := range pairs {
+			fixtures = append(fixtures, Fixture{HomeTeamID: p[1], AwayTeamID: p[0], Week: numRounds + k + 1})
+		}
+	}
+	return fixtures, nil
+}
+
+// SingleRoundRobin verilen takımlar için tek devreli bir fikstür üretir: her takım diğer her takımla bir kez,
+// ev sahibi ya da deplasman olarak karşılaşır (örneğin turnuva grupları için).
+func SingleRoundRobin(teamIDs []int) ([]Fixture, error) {
+	rounds, err := singleRoundRobin(teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := make([]Fixture, 0, len(rounds)*len(teamIDs)/2)
+	for r, pairs := range rounds {
+		for _, p := range pairs {
+			fixtures = append(fixtures, Fixture{HomeTeamID: p[0], AwayTeamID: p[1], Week: r + 1})
+		}
+	}
+	return fixtures, nil
+}
+
+// singleRoundRobin çember yöntemine göre her hafta için [ev sahibi, deplasman] çiftlerini döndürür.
+// Son takım sabit tutulur, diğerleri her hafta bir adım döner. Sabit takımın ev/deplasman sırası
+// her hafta değişir; diğer çiftlerde yön, çiftin sırasına göre belirlenir. Bu düzen tek devrede
+// en az sayıda (n-2) ardışık iç saha/deplasman kırılması verir.
+func singleRoundRobin(teamIDs []int) ([][][2]int, error) {
+	if len(teamIDs) < 2 {
+		return nil, fmt.Errorf("at least 2 teams are required to build a fixture, got %d", len(teamIDs))
+	}
+
+	seen := make(map[int]bool, len(teamIDs))
+	for _, id := range teamIDs {
+		if id == bye {
+			return nil, fmt.Errorf("invalid team ID %d", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate team ID %d in fixture", id)
+		}
+		seen[id] = true
+	}
+
+	slots := append([]int(nil), teamIDs...)
+	if len(slots)%2 == 1 {
+		slots = append(slots, bye)
+	}
+
+	n := len(slots)
+	m := n - 1 // dönen takım sayısı
+	rounds := make([][][2]int, 0, m)
+	for r := 0; r < m; r++ {
+		pairs := make([][2]int, 0, n/2)
+
+		home, away := slots[r], slots[m]
+		if r%2 == 1 {
+			home, away = away, home
+		}
+		pairs = appendPair(pairs, home, away)
+
+		for i := 1; i < n/2; i++ {
+			home, away := slots[(r+i)%m], slots[(r-i+m)%m]
+			if i%2 == 0 {
+				home, away = away, home
+			}
+			pairs = appendPair(pairs, home, away)
+		}
+		rounds = append(rounds, pairs)
+	}
+	return rounds, nil
+}
+
+// appendPair bay geçen takımı içermeyen çiftleri listeye ekler.
+func appendPair(pairs [][2]int, home, away int) [][2]int {
+	if home == bye || away == bye {
+		return pairs
+	}
+	return append(pairs, [2]int{home, away})
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"sort"
+	"testing"
+)
+
+func teamIDs(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	return ids
+}
+
+func TestDoubleRoundRobin(t *testing.T) {
+	tests := []struct {
+		teams int
+		weeks int
+	}{
+		{teams: 2, weeks: 2},
+		{teams: 3, weeks: 6},
+		{teams: 4, weeks: 6},
+		{teams: 5, weeks: 10},
+		{teams: 18, weeks: 34},
+		{teams: 20, weeks: 38},
+	}
+	for _, tt := range tests {
+		ids := teamIDs(tt.teams)
+		fixtures, err := DoubleRoundRobin(ids)
+		if err != nil {
+			t.Fatalf("%d teams: %v", tt.teams, err)
+		}
+
+		if want := tt.teams * (tt.teams - 1); len(fixtures) != want {
+			t.Errorf("%d teams: got %d matches, want %d", tt.teams, len(fixtures), want)
+		}
+
+		// Her sıralı (ev sahibi, deplasman) çifti tam bir kez oynanmalıdır.
+		pairs := make(map[[2]int]int)
+		maxWeek := 0
+		for _, f := range fixtures {
+			if f.HomeTeamID == f.AwayTeamID {
+				t.Errorf("%d teams: team %d plays itself in week %d", tt.teams, f.HomeTeamID, f.Week)
+			}
+			pairs[[2]int{f.HomeTeamID, f.AwayTeamID}]++
+			maxWeek = max(maxWeek, f.Week)
+		}
+		for _, home := range ids {
+			for _, away := range ids {
+				if home != away && pairs[[2]int{home, away}] != 1 {
+					t.Errorf("%d teams: %d vs %d played %d times, want 1", tt.teams, home, away, pairs[[2]int{home, away}])
+				}
+			}
+		}
+		if maxWeek != tt.weeks {
+			t.Errorf("%d teams: got %d weeks, want %d", tt.teams, maxWeek, tt.weeks)
+		}
+
+		// Bir takım bir haftada en fazla bir maç yapar.
+		played := make(map[[2]int]bool)
+		for _, f := range fixtures {
+			for _, id := range []int{f.HomeTeamID, f.AwayTeamID} {
+				key := [2]int{id, f.Week}
+				if played[key] {
+					t.Errorf("%d teams: team %d plays twice in week %d", tt.teams, id, f.Week)
+				}
+				played[key] = true
+			}
+		}
+
+		// Bay geçilen haftalar sayılmadan bir takım üst üste en fazla iki iç saha ya da deplasman maçı yapar.
+		for _, id := range ids {
+			if run := longestVenueRun(fixtures, id); run > 2 {
+				t.Errorf("%d teams: team %d has %d consecutive matches at the same venue", tt.teams, id, run)
+			}
+		}
+	}
+}
+
+func TestSingleRoundRobin(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 18, 20} {
+		fixtures, err := SingleRoundRobin(teamIDs(n))
+		if err != nil {
+			t.Fatalf("%d teams: %v", n, err)
+		}
+		if want := n * (n - 1) / 2; len(fixtures) != want {
+			t.Errorf("%d teams: got %d matches, want %d", n, len(fixtures), want)
+		}
+		met := make(map[[2]int]bool)
+		for _, f := range fixtures {
+			key := [2]int{min(f.HomeTeamID, f.AwayTeamID), max(f.HomeTeamID, f.AwayTeamID)}
+			if met[key] {
+				t.Errorf("%d teams: %d and %d meet twice", n, key[0], key[1])
+			}
+			met[key] = true
+		}
+	}
+}
+
+func TestRoundRobinInvalidTeams(t *testing.T) {
+	tests := [][]int{
+		nil,
+		{1},
+		{1, 2, 1},
+		{1, bye},
+	}
+	for _, ids := range tests {
+		if _, err := DoubleRoundRobin(ids); err == nil {
+			t.Errorf("DoubleRoundRobin(%v): expected error", ids)
+		}
+	}
+}
+
+// longestVenueRun takımın maçlarını hafta sırasıyla dolaşır ve aynı sahada üst üste oynadığı en uzun seriyi döndürür.
+func longestVenueRun(fixtures []Fixture, teamID int) int {
+	var own []Fixture
+	for _, f := range fixtures {
+		if f.HomeTeamID == teamID || f.AwayTeamID == teamID {
+			own = append(own, f)
+		}
+	}
+	sort.Slice(own, func(i, j int) bool { return own[i].Week < own[j].Week })
+
+	longest, run := 0, 0
+	for i, f := range own {
+		if i > 0 && (f.HomeTeamID == teamID) == (own[i-1].HomeTeamID == teamID) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
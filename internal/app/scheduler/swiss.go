@@ -0,0 +1,305 @@
+package scheduler
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// SwissTeam İsviçre sistemi fikstüründeki bir takımdır.
+type SwissTeam struct {
+	ID      int
+	Pot     int    // 1'den başlayan torba numarası
+	Country string // Aynı ülkeden takımlar eşleşmez; boşsa kısıt uygulanmaz
+}
+
+const (
+	swissAttempts     = 50    // Kısıtları sağlayan bir fikstür için yapılan en fazla deneme
+	swissWeekAttempts = 20    // Aynı eşleşmelerin haftalara dağıtılması için yapılan en fazla deneme
+	swissSearchBudget = 20000 // Tek bir geri izlemeli aramanın en fazla adım sayısı
+)
+
+// Swiss Şampiyonlar Ligi'nin lig aşaması gibi İsviçre usulü bir fikstür üretir: her takım her torbadan
+// opponentsPerPot rakiple (kendi torbası dahil) bir kez karşılaşır, aynı ülkeden iki takım eşleşmez.
+// opponentsPerPot çiftse her takım her torbanın rakiplerine karşı eşit sayıda iç saha ve deplasman maçı,
+// tekse toplamda en fazla bir fark olacak şekilde oynar. Her takım her hafta tam olarak bir maç yapar.
+//
+// Torbalar 1'den başlayarak ardışık numaralanmalı ve eşit büyüklükte olmalıdır; toplam takım sayısı çift
+// olmalıdır. Eşleşmeler ve haftalar rng ile rastgele seçilir; kısıtları sağlayan bir fikstür bulunamazsa
+// hata döner.
+func Swiss(teams []SwissTeam, opponentsPerPot int, rng *rand.Rand) ([]Fixture, error) {
+	pots, err := swissPots(teams, opponentsPerPot)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < swissAttempts; attempt++ {
+		edges, ok := swissPairings(teams, pots, opponentsPerPot, rng)
+		if !ok {
+			continue
+		}
+		for week := 0; week < swissWeekAttempts; week++ {
+			weeks, ok := swissWeeks(len(teams), edges, len(pots)*opponentsPerPot, rng)
+			if !ok {
+				continue
+			}
+			fixtures := make([]Fixture, len(edges))
+			for i, e := range edges {
+				fixtures[i] = Fixture{HomeTeamID: teams[e[0]].ID, AwayTeamID: teams[e[1]].ID, Week: weeks[i]}
+			}
+			slices.SortFunc(fixtures, func(a, b Fixture) int { return cmp.Compare(a.Week, b.Week) })
+			return fixtures, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find a swiss fixture satisfying the pot and country constraints after %d attempts", swissAttempts)
+}
+
+// swissPots takımları doğrular ve torbalarına göre (teams indeksleriyle) gruplar.
+func swissPots(teams []SwissTeam, opponentsPerPot int) ([][]int, error) {
+	if len(teams) < 2 {
+		return nil, fmt.Errorf("at least 2 teams are required to build a fixture, got %d", len(teams))
+	}
+	if len(teams)%2 == 1 {
+		return nil, fmt.Errorf("a swiss fixture requires an even number of teams, got %d", len(teams))
+	}
+	if opponentsPerPot < 1 {
+		return nil, fmt.Errorf("opponents per pot must be positive, got %d", opponentsPerPot)
+	}
+
+	seen := make(map[int]bool, len(teams))
+	var pots [][]int
+	for i, team := range teams {
+		if team.ID == bye {
+			return nil, fmt.Errorf("invalid team ID %d", team.ID)
+		}
+		if seen[team.ID] {
+			return nil, fmt.Errorf("duplicate team ID %d in fixture", team.ID)
+		}
+		seen[team.ID] = true
+		if team.Pot < 1 || team.Pot > len(teams) {
+			return nil, fmt.Errorf("team %d has invalid pot %d", team.ID, team.Pot)
+		}
+		for len(pots) < team.Pot {
+			pots = append(pots, nil)
+		}
+		pots[team.Pot-1] = append(pots[team.Pot-1], i)
+	}
+
+	size := len(pots[0])
+	for p, pot := range pots {
+		if len(pot) != size {
+			return nil, fmt.Errorf("all pots must have the same number of teams: pot 1 has %d, pot %d has %d", size, p+1, len(pot))
+		}
+	}
+	if opponentsPerPot > size-1 {
+		return nil, fmt.Errorf("%d opponents per pot need at least %d teams in every pot, got %d", opponentsPerPot, opponentsPerPot+1, size)
+	}
+	if size*opponentsPerPot%2 == 1 {
+		return nil, fmt.Errorf("%d teams per pot cannot each play %d opponents from their own pot", size, opponentsPerPot)
+	}
+	return pots, nil
+}
+
+// swissPairings her torba çifti için eşleşmeleri seçer ve ev sahiplerini belirler. Kenarlar [ev sahibi,
+// deplasman] teams indeksleridir.
+func swissPairings(teams []SwissTeam, pots [][]int, opponentsPerPot int, rng *rand.Rand) ([][2]int, bool) {
+	paired := make(map[[2]int]bool)
+	var all [][2]int
+	for p := range pots {
+		for q := p; q < len(pots); q++ {
+			edges, ok := pairPots(teams, pots[p], pots[q], opponentsPerPot, paired, rng)
+			if !ok {
+				return nil, false
+			}
+			// Çift sayıda rakipte ev sahipliği her torba çifti içinde dengelenir.
+			if opponentsPerPot%2 == 0 {
+				orient(len(teams), edges, rng)
+			}
+			all = append(all, edges...)
+		}
+	}
+	if opponentsPerPot%2 == 1 {
+		orient(len(teams), all, rng)
+	}
+	return all, true
+}
+
+// pairPots a torbasındaki her takıma b torbasından k rakip seçer; a ile b aynı torba olabilir. Rakipler
+// geri izlemeli arama ile seçilir; arama bütçeyi aşarsa false döner.
+func pairPots(teams []SwissTeam, a, b []int, k int, paired map[[2]int]bool, rng *rand.Rand) ([][2]int, bool) {
+	need := make(map[int]int, len(a)+len(b))
+	for _, v := range a {
+		need[v] = k
+	}
+	for _, v := range b {
+		need[v] = k
+	}
+	left := slices.Clone(a)
+	right := slices.Clone(b)
+	rng.Shuffle(len(left), func(i, j int) { left[i], left[j] = left[j], left[i] })
+	rng.Shuffle(len(right), func(i, j int) { right[i], right[j] = right[j], right[i] })
+
+	var (
+		edges [][2]int
+		steps int
+		fill  func() bool
+	)
+	fill = func() bool {
+		if steps++; steps > swissSearchBudget {
+			return false
+		}
+		v := -1
+		for _, u := range left {
+			if need[u] > 0 {
+				v = u
+				break
+			}
+		}
+		if v < 0 {
+			return true
+		}
+		for _, u := range right {
+			key := [2]int{min(u, v), max(u, v)}
+			if u == v || need[u] == 0 || paired[key] || sameCountry(teams[u], teams[v]) {
+				continue
+			}
+			paired[key] = true
+			need[u]--
+			need[v]--
+			edges = append(edges, [2]int{v, u})
+			if fill() {
+				return true
+			}
+			edges = edges[:len(edges)-1]
+			need[u]++
+			need[v]++
+			delete(paired, key)
+		}
+		return false
+	}
+	if !fill() {
+		return nil, false
+	}
+	return edges, true
+}
+
+func sameCountry(a, b SwissTeam) bool {
+	return a.Country != "" && a.Country == b.Country
+}
+
+// orient kenarların yönünü (ev sahibini) her takımın iç saha ve deplasman maçı sayıları arasındaki fark
+// en fazla bir olacak şekilde belirler. Tek dereceli düğümler sanal bir düğüme bağlanır; böylece tüm
+// dereceler çift olur ve kapalı yürüyüşler boyunca yönlendirilen her düğüme giren ve çıkan kenar sayısı eşitlenir.
+func orient(n int, edges [][2]int, rng *rand.Rand) {
+	degree := make([]int, n+1)
+	for _, e := range edges {
+		degree[e[0]]++
+		degree[e[1]]++
+	}
+	all := slices.Clone(edges)
+	for v := 0; v < n; v++ {
+		if degree[v]%2 == 1 {
+			all = append(all, [2]int{v, n})
+		}
+	}
+
+	incident := make([][]int, n+1)
+	for i, e := range all {
+		incident[e[0]] = append(incident[e[0]], i)
+		incident[e[1]] = append(incident[e[1]], i)
+	}
+	for v := range incident {
+		rng.Shuffle(len(incident[v]), func(i, j int) { incident[v][i], incident[v][j] = incident[v][j], incident[v][i] })
+	}
+
+	used := make([]bool, len(all))
+	next := make([]int, n+1)
+	for start := range incident {
+		for {
+			// Her kapalı yürüyüş başladığı düğümde biter, çünkü tüm dereceler çifttir.
+			v, moved := start, false
+			for {
+				for next[v] < len(incident[v]) && used[incident[v][next[v]]] {
+					next[v]++
+				}
+				if next[v] == len(incident[v]) {
+					break
+				}
+				i := incident[v][next[v]]
+				used[i] = true
+				u := all[i][0] + all[i][1] - v
+				all[i] = [2]int{v, u}
+				v, moved = u, true
+			}
+			if !moved {
+				break
+			}
+		}
+	}
+	copy(edges, all[:len(edges)])
+}
+
+// swissWeeks maçları her takımın her hafta tam olarak bir maç yapacağı şekilde weeks haftaya dağıtır.
+// Her hafta için kalan maçlardan geri izlemeyle bir tam eşleşme seçilir; bulunamazsa false döner.
+func swissWeeks(n int, edges [][2]int, weeks int, rng *rand.Rand) ([]int, bool) {
+	week := make([]int, len(edges))
+	incident := make([][]int, n)
+	for i, e := range edges {
+		incident[e[0]] = append(incident[e[0]], i)
+		incident[e[1]] = append(incident[e[1]], i)
+	}
+	for v := range incident {
+		rng.Shuffle(len(incident[v]), func(i, j int) { incident[v][i], incident[v][j] = incident[v][j], incident[v][i] })
+	}
+
+	busy := make([]bool, n)
+	for w := 1; w <= weeks; w++ {
+		clear(busy)
+		steps := 0
+		var match func(matched int) bool
+		match = func(matched int) bool {
+			if matched == n {
+				return true
+			}
+			if steps++; steps > swissSearchBudget {
+				return false
+			}
+			// En az seçeneği kalan takım önce eşleştirilir.
+			v, options := -1, 0
+			for u := 0; u < n; u++ {
+				if busy[u] {
+					continue
+				}
+				count := 0
+				for _, i := range incident[u] {
+					if week[i] == 0 && !busy[edges[i][0]+edges[i][1]-u] {
+						count++
+					}
+				}
+				if v < 0 || count < options {
+					v, options = u, count
+				}
+			}
+			if options == 0 {
+				return false
+			}
+			for _, i := range incident[v] {
+				u := edges[i][0] + edges[i][1] - v
+				if week[i] != 0 || busy[u] {
+					continue
+				}
+				week[i], busy[v], busy[u] = w, true, true
+				if match(matched + 2) {
+					return true
+				}
+				week[i], busy[v], busy[u] = 0, false, false
+			}
+			return false
+		}
+		if !match(0) {
+			return nil, false
+		}
+	}
+	return week, true
+}
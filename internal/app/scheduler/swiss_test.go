@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// swissTeams torbalara eşit dağılmış takımlar üretir. countries sıfırdan büyükse takımlar sırayla o kadar
+// ülkeye dağıtılır; aynı ülkedeki takımlar farklı torbalara düşer.
+func swissTeams(pots, perPot, countries int) []SwissTeam {
+	teams := make([]SwissTeam, pots*perPot)
+	for i := range teams {
+		teams[i] = SwissTeam{ID: i + 1, Pot: i/perPot + 1}
+		if countries > 0 {
+			teams[i].Country = string(rune('A' + i%countries))
+		}
+	}
+	return teams
+}
+
+func TestSwiss(t *testing.T) {
+	tests := []struct {
+		name      string
+		pots      int
+		perPot    int
+		countries int
+		k         int
+	}{
+		{name: "champions league", pots: 4, perPot: 9, countries: 12, k: 2},
+		{name: "odd opponents per pot", pots: 2, perPot: 4, countries: 4, k: 3},
+		{name: "single pot", pots: 1, perPot: 10, k: 3},
+		{name: "one opponent per pot", pots: 3, perPot: 6, countries: 6, k: 1},
+	}
+	for _, tt := range tests {
+		teams := swissTeams(tt.pots, tt.perPot, tt.countries)
+		byID := make(map[int]SwissTeam, len(teams))
+		for _, team := range teams {
+			byID[team.ID] = team
+		}
+		weeks := tt.pots * tt.k
+
+		for seed := int64(0); seed < 50; seed++ {
+			fixtures, err := Swiss(teams, tt.k, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatalf("%s, seed %d: %v", tt.name, seed, err)
+			}
+			if want := len(teams) * weeks / 2; len(fixtures) != want {
+				t.Errorf("%s, seed %d: got %d matches, want %d", tt.name, seed, len(fixtures), want)
+			}
+
+			opponents := make(map[[2]int]int) // [takım, torba] -> rakip sayısı
+			venues := make(map[[2]int]int)    // [takım, torba] -> iç saha maçları eksi deplasman maçları
+			met := make(map[[2]int]bool)
+			played := make(map[[2]int]bool)
+			for _, f := range fixtures {
+				home, away := byID[f.HomeTeamID], byID[f.AwayTeamID]
+				if home.ID == away.ID {
+					t.Fatalf("%s, seed %d: team %d plays itself", tt.name, seed, home.ID)
+				}
+				if sameCountry(home, away) {
+					t.Errorf("%s, seed %d: %d and %d are both from %s", tt.name, seed, home.ID, away.ID, home.Country)
+				}
+				pair := [2]int{min(home.ID, away.ID), max(home.ID, away.ID)}
+				if met[pair] {
+					t.Errorf("%s, seed %d: %d and %d meet twice", tt.name, seed, pair[0], pair[1])
+				}
+				met[pair] = true
+
+				if f.Week < 1 || f.Week > weeks {
+					t.Errorf("%s, seed %d: match in week %d, want 1..%d", tt.name, seed, f.Week, weeks)
+				}
+				for _, id := range []int{home.ID, away.ID} {
+					key := [2]int{id, f.Week}
+					if played[key] {
+						t.Errorf("%s, seed %d: team %d plays twice in week %d", tt.name, seed, id, f.Week)
+					}
+					played[key] = true
+				}
+
+				opponents[[2]int{home.ID, away.Pot}]++
+				opponents[[2]int{away.ID, home.Pot}]++
+				venues[[2]int{home.ID, away.Pot}]++
+				venues[[2]int{away.ID, home.Pot}]--
+			}
+
+			for _, team := range teams {
+				for w := 1; w <= weeks; w++ {
+					if !played[[2]int{team.ID, w}] {
+						t.Errorf("%s, seed %d: team %d has no match in week %d", tt.name, seed, team.ID, w)
+					}
+				}
+				total := 0
+				for pot := 1; pot <= tt.pots; pot++ {
+					key := [2]int{team.ID, pot}
+					if opponents[key] != tt.k {
+						t.Errorf("%s, seed %d: team %d has %d opponents from pot %d, want %d", tt.name, seed, team.ID, opponents[key], pot, tt.k)
+					}
+					// Çift sayıda rakipte her torbaya karşı iç saha ve deplasman maçları eşittir.
+					if tt.k%2 == 0 && venues[key] != 0 {
+						t.Errorf("%s, seed %d: team %d is unbalanced against pot %d by %d", tt.name, seed, team.ID, pot, venues[key])
+					}
+					total += venues[key]
+				}
+				if total < -1 || total > 1 {
+					t.Errorf("%s, seed %d: team %d has home/away difference %d", tt.name, seed, team.ID, total)
+				}
+			}
+		}
+	}
+}
+
+func TestSwissDeterministic(t *testing.T) {
+	teams := swissTeams(4, 9, 12)
+	a, errA := Swiss(teams, 2, rand.New(rand.NewSource(7)))
+	b, errB := Swiss(teams, 2, rand.New(rand.NewSource(7)))
+	if errA != nil || errB != nil {
+		t.Fatalf("unexpected errors: %v, %v", errA, errB)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same seed produced different fixtures at %d: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestSwissInvalidPots(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []SwissTeam
+		k     int
+	}{
+		{name: "no teams", teams: nil, k: 1},
+		{name: "odd team count", teams: []SwissTeam{{ID: 1, Pot: 1}, {ID: 2, Pot: 1}, {ID: 3, Pot: 1}}, k: 1},
+		{name: "zero opponents", teams: swissTeams(2, 4, 0), k: 0},
+		{name: "pot zero", teams: []SwissTeam{{ID: 1, Pot: 0}, {ID: 2, Pot: 1}}, k: 1},
+		{name: "pot numbers skip", teams: []SwissTeam{{ID: 1, Pot: 1}, {ID: 2, Pot: 1}, {ID: 3, Pot: 3}, {ID: 4, Pot: 3}}, k: 1},
+		{name: "unequal pots", teams: []SwissTeam{{ID: 1, Pot: 1}, {ID: 2, Pot: 1}, {ID: 3, Pot: 1}, {ID: 4, Pot: 2}}, k: 1},
+		{name: "too many opponents per pot", teams: swissTeams(2, 4, 0), k: 4},
+		{name: "odd pot cannot pair internally", teams: swissTeams(2, 3, 0), k: 1},
+		{name: "duplicate team", teams: []SwissTeam{{ID: 1, Pot: 1}, {ID: 1, Pot: 1}}, k: 1},
+		{name: "bye team ID", teams: []SwissTeam{{ID: 1, Pot: 1}, {ID: bye, Pot: 1}}, k: 1},
+		{name: "all from one country", teams: []SwissTeam{{ID: 1, Pot: 1, Country: "TR"}, {ID: 2, Pot: 1, Country: "TR"}}, k: 1},
+	}
+	for _, tt := range tests {
+		if _, err := Swiss(tt.teams, tt.k, rand.New(rand.NewSource(1))); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
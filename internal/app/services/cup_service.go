@@ -0,0 +1,582 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// Uzatma ve penaltıların tohumları maç tohumlarından ayrılsın diye maç sırası yerine kullanılan değerler.
+const (
+	cupDrawSeedPart      = 0
+	cupExtraTimeSeedPart = 3
+)
+
+// cupService kupaların durumunu (güncel tur, kazanan) CupRepository'de tutar. Kura ve tur oynatma
+// süreç içinde tek bir kilitle sıraya sokulur; aynı veritabanını kullanan birden çok sunucu için
+// ayrıca kupanın sürüm numarası denetlenir ve değişmişse işlem geri alınıp ErrConflict döner.
+type cupService struct {
+	mu sync.Mutex
+
+	cupRepo   repositories.CupRepository
+	matchRepo repositories.MatchRepository
+	teamRepo  repositories.TeamRepository
+	matchSvc  MatchService
+	uow       repositories.UnitOfWork
+}
+
+func NewCupService(cupRepo repositories.CupRepository, matchRepo repositories.MatchRepository, teamRepo repositories.TeamRepository, matchSvc MatchService, uow repositories.UnitOfWork) CupService {
+	return &cupService{
+		cupRepo:   cupRepo,
+		matchRepo: matchRepo,
+		teamRepo:  teamRepo,
+		matchSvc:  matchSvc,
+		uow:       uow,
+	}
+}
+
+func (c NewCup) validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCup)
+	}
+	if len(c.TeamIDs) < 2 {
+		return fmt.Errorf("%w: at least 2 teams are required, got %d", ErrInvalidCup, len(c.TeamIDs))
+	}
+	seen := make(map[int]bool, len(c.TeamIDs))
+	for _, id := range c.TeamIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate team %d", ErrInvalidCup, id)
+		}
+		seen[id] = true
+	}
+	if c.Legs != 1 && c.Legs != 2 {
+		return fmt.Errorf("%w: legs must be 1 or 2, got %d", ErrInvalidCup, c.Legs)
+	}
+	if c.Draw != models.CupDrawSeeded && c.Draw != models.CupDrawRandom && c.Draw != models.CupDrawFixed {
+		return fmt.Errorf("%w: unknown draw %q", ErrInvalidCup, c.Draw)
+	}
+	return nil
+}
+
+func (s *cupService) CreateCup(input NewCup) (*models.Cup, error) {
+	if input.Legs == 0 {
+		input.Legs = 1
+	}
+	if input.Draw == "" {
+		input.Draw = models.CupDrawSeeded
+	}
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	for _, id := range input.TeamIDs {
+		team, err := s.teamRepo.GetTeamByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team %d: %w", id, err)
+		}
+		if team == nil {
+			return nil, fmt.Errorf("%w: team %d not found", ErrInvalidCup, id)
+		}
+	}
+
+	cup := &models.Cup{
+		Name:    strings.TrimSpace(input.Name),
+		Legs:    input.Legs,
+		Draw:    input.Draw,
+		Seed:    input.Seed,
+		Status:  models.LeagueStatusNotStarted,
+		TeamIDs: slices.Clone(input.TeamIDs),
+	}
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		return repos.Cups.CreateCup(cup)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cup: %w", err)
+	}
+	cup.TotalRounds = cupRounds(len(cup.TeamIDs))
+	return cup, nil
+}
+
+func (s *cupService) GetCups() ([]models.Cup, error) {
+	cups, err := s.cupRepo.GetAllCups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cups: %w", err)
+	}
+	for i := range cups {
+		cups[i].TotalRounds = cupRounds(len(cups[i].TeamIDs))
+	}
+	return cups, nil
+}
+
+func (s *cupService) GetBracket(cupID int) (*models.CupBracket, error) {
+	cup, err := findCup(s.cupRepo, cupID)
+	if err != nil {
+		return nil, err
+	}
+	return cupBracket(repositories.Repositories{Teams: s.teamRepo, Matches: s.matchRepo, Cups: s.cupRepo}, cup)
+}
+
+// DrawNextRound güncel turun kazananları (ilk turda tüm takımlar) arasında sonraki turun kurasını çeker
+// ve eşleşmelerin maçlarını oluşturur. Takım sayısı ikinin kuvveti değilse ilk turda en iyi seri başları
+// bay geçer. Final her zaman tek maçtır.
+func (s *cupService) DrawNextRound(cupID int, seed *int64) (*models.CupRound, error) {
+	return s.updateRound(cupID, func(repos repositories.Repositories, cup *models.Cup) error {
+		return drawNextCupRound(repos, cup, seed)
+	})
+}
+
+// PlayRound kurası çekilmiş turun henüz sonuçlanmamış eşleşmelerini oynatır. Toplam skor eşitse
+// eşleşmenin son maçının sahasında uzatma, o da eşit biterse seri penaltı atışları oynanır; deplasman
+// golü kuralı uygulanmaz. Final oynandığında kupa tamamlanır.
+func (s *cupService) PlayRound(cupID int, seed *int64) (*models.CupRound, error) {
+	return s.updateRound(cupID, func(repos repositories.Repositories, cup *models.Cup) error {
+		matches, err := repos.Matches.GetMatchesByCup(cup.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load cup matches for the match engine: %w", err)
+		}
+		engine, err := fitEngine(s.matchSvc.Engine(), matches)
+		if err != nil {
+			return err
+		}
+		return playCupRound(repos, s.matchSvc.WithEngine(engine), cup, seed)
+	})
+}
+
+// updateRound kupanın turunu değiştiren bir işlemi tek bir işlem içinde çalıştırır ve güncel turun son halini döndürür.
+func (s *cupService) updateRound(cupID int, fn func(repos repositories.Repositories, cup *models.Cup) error) (*models.CupRound, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var round *models.CupRound
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		cup, err := findCup(repos.Cups, cupID)
+		if err != nil {
+			return err
+		}
+		if cup.Owner != "" {
+			return fmt.Errorf("%w: cup %d belongs to a %s", ErrCupOwned, cupID, cup.Owner)
+		}
+		if err := fn(repos, cup); err != nil {
+			return err
+		}
+		if err := repos.Cups.UpdateCup(cup); err != nil {
+			return fmt.Errorf("failed to save cup state: %w", err)
+		}
+
+		bracket, err := cupBracket(repos, cup)
+		if err != nil {
+			return err
+		}
+		round = &bracket.Rounds[cup.CurrentRound-1]
+		return nil
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: cup %d was changed by another request", ErrConflict, cupID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// drawNextCupRound sonraki turun kurasını çeker ve kupanın güncel turunu ilerletir. Kupa kaydedilmez.
+func drawNextCupRound(repos repositories.Repositories, cup *models.Cup, seed *int64) error {
+	if cup.Status == models.LeagueStatusCompleted {
+		return ErrCupCompleted
+	}
+
+	ties, err := repos.Cups.GetTiesByCup(cup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cup ties: %w", err)
+	}
+	entrants := cup.TeamIDs
+	if cup.CurrentRound > 0 {
+		entrants = nil
+		for _, tie := range ties {
+			if tie.Round != cup.CurrentRound {
+				continue
+			}
+			if tie.WinnerTeamID == nil {
+				return fmt.Errorf("%w: round %d", ErrCupRoundNotPlayed, cup.CurrentRound)
+			}
+			entrants = append(entrants, *tie.WinnerTeamID)
+		}
+	}
+
+	next := cup.CurrentRound + 1
+	rng := newRand(deriveSeed(resolveSeed(seed, cup.Seed), int64(next), cupDrawSeedPart))
+	entrants = sortBySeed(entrants, cup.TeamIDs)
+
+	position := 0
+	if next == 1 {
+		byes := 1<<cup.TotalRounds - len(entrants)
+		for _, teamID := range entrants[:byes] {
+			position++
+			winner := teamID
+			tie := &models.CupTie{CupID: cup.ID, Round: next, Position: position, HomeTeamID: teamID, WinnerTeamID: &winner}
+			if err := repos.Cups.CreateTie(tie); err != nil {
+				return fmt.Errorf("failed to save bye: %w", err)
+			}
+		}
+		entrants = entrants[byes:]
+	}
+
+	for _, pair := range drawCupPairs(rng, entrants, cup.Draw) {
+		position++
+		if err := createCupTie(repos, cup, next, position, pair); err != nil {
+			return err
+		}
+	}
+
+	cup.CurrentRound = next
+	cup.Status = models.LeagueStatusInProgress
+	return nil
+}
+
+// playCupRound güncel turun sonuçlanmamış eşleşmelerini oynatır; final oynandıysa kupayı tamamlar. Kupa kaydedilmez.
+func playCupRound(repos repositories.Repositories, matchSvc MatchService, cup *models.Cup, seed *int64) error {
+	if cup.Status == models.LeagueStatusCompleted {
+		return ErrCupCompleted
+	}
+	if cup.CurrentRound == 0 {
+		return fmt.Errorf("%w: round 1", ErrCupRoundNotDrawn)
+	}
+
+	ties, err := repos.Cups.GetTiesByCup(cup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cup ties: %w", err)
+	}
+	matches, err := repos.Matches.GetMatchesByCup(cup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cup matches: %w", err)
+	}
+	matchesByID := make(map[int]models.Match, len(matches))
+	for _, match := range matches {
+		matchesByID[match.ID] = match
+	}
+
+	roundSeed := deriveSeed(resolveSeed(seed, cup.Seed), int64(cup.CurrentRound))
+	played := 0
+	for i := range ties {
+		tie := &ties[i]
+		if tie.Round != cup.CurrentRound || tie.WinnerTeamID != nil {
+			continue
+		}
+		if err := playTie(repos, matchSvc, tie, matchesByID, roundSeed); err != nil {
+			return err
+		}
+		played++
+		if cup.CurrentRound == cup.TotalRounds {
+			cup.WinnerTeamID = tie.WinnerTeamID
+			cup.Status = models.LeagueStatusCompleted
+		}
+	}
+	if played == 0 {
+		return fmt.Errorf("%w: round %d", ErrCupRoundNotDrawn, cup.CurrentRound+1)
+	}
+	return nil
+}
+
+// advanceCup kupanın güncel turunu oynatır, kupa tamamlanmadıysa sonraki turun kurasını çeker ve kupayı
+// kaydeder. Güncel tur, kupanın sahibi kaydedilmeden önce kupa uç noktalarıyla oynatılmış ama sonraki
+// turun kurası çekilmemişse önce kura çekilir. Turnuvaların eleme aşaması ve liglerin play-off'ları kupayı
+// bu şekilde tek adımda ilerletir.
+func advanceCup(repos repositories.Repositories, matchSvc MatchService, cup *models.Cup, seed *int64) error {
+	ties, err := repos.Cups.GetTiesByCup(cup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get cup ties: %w", err)
+	}
+	if cupRoundPlayed(ties, cup.CurrentRound) {
+		if err := drawNextCupRound(repos, cup, seed); err != nil {
+			return err
+		}
+	}
+
+	if err := playCupRound(repos, matchSvc, cup, seed); err != nil {
+		return err
+	}
+	if cup.Status != models.LeagueStatusCompleted {
+		if err := drawNextCupRound(repos, cup, seed); err != nil {
+			return err
+		}
+	}
+	if err := repos.Cups.UpdateCup(cup); err != nil {
+		return fmt.Errorf("failed to save cup state: %w", err)
+	}
+	return nil
+}
+
+// cupRoundPlayed kupanın verilen turundaki tüm eşleşmelerin sonuçlanıp sonuçlanmadığını döndürür.
+func cupRoundPlayed(ties []models.CupTie, round int) bool {
+	for _, tie := range ties {
+		if tie.Round == round && tie.WinnerTeamID == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// playTie eşleşmenin maçlarını simüle eder ve kazananı belirler.
+func playTie(repos repositories.Repositories, matchSvc MatchService, tie *models.CupTie, matchesByID map[int]models.Match, roundSeed int64) error {
+	if tie.AwayTeamID == nil {
+		return fmt.Errorf("cup tie %d has no opponent", tie.ID)
+	}
+	homeTeam, err := repos.Teams.GetTeamByID(tie.HomeTeamID)
+	if err != nil {
+		return err
+	}
+	awayTeam, err := repos.Teams.GetTeamByID(*tie.AwayTeamID)
+	if err != nil {
+		return err
+	}
+	if homeTeam == nil || awayTeam == nil {
+		return fmt.Errorf("cup tie %d references unknown team", tie.ID)
+	}
+	// venue maçın ev sahibi ve deplasman takımlarını eşleşmedeki sıralarına göre döndürür.
+	venue := func(match *models.Match) (*models.Team, *models.Team) {
+		if match.HomeTeamID == homeTeam.ID {
+			return homeTeam, awayTeam
+		}
+		return awayTeam, homeTeam
+	}
+
+	var legs []*models.Match
+	for _, id := range []*int{tie.FirstLegMatchID, tie.SecondLegMatchID} {
+		if id == nil {
+			continue
+		}
+		match, ok := matchesByID[*id]
+		if !ok {
+			return fmt.Errorf("cup tie %d references unknown match %d", tie.ID, *id)
+		}
+		legs = append(legs, &match)
+	}
+	if len(legs) == 0 {
+		return fmt.Errorf("cup tie %d has no matches", tie.ID)
+	}
+
+	homeGoals, awayGoals := 0, 0
+	for i, match := range legs {
+		matchHome, matchAway := venue(match)
+		matchSeed := deriveSeed(roundSeed, int64(match.HomeTeamID), int64(match.AwayTeamID), int64(i+1))
+		if err := matchSvc.SimulateMatch(match, matchHome, matchAway, matchSeed); err != nil {
+			return err
+		}
+		if err := repos.Matches.UpdateMatch(match); err != nil {
+			return fmt.Errorf("failed to save match %d: %w", match.ID, err)
+		}
+		if match.HomeTeamID == tie.HomeTeamID {
+			homeGoals += match.HomeGoals
+			awayGoals += match.AwayGoals
+		} else {
+			homeGoals += match.AwayGoals
+			awayGoals += match.HomeGoals
+		}
+	}
+
+	if homeGoals == awayGoals {
+		// Uzatma ve penaltılar son maçın sahasında, o maçın ev sahibi ev sahibi sayılarak oynanır;
+		// sonuçlar eşleşmenin ev sahibi takımının bakış açısıyla saklanır.
+		last := legs[len(legs)-1]
+		lastHome, lastAway := venue(last)
+		swapped := lastHome.ID != tie.HomeTeamID
+		orient := func(a, b int) (int, int) {
+			if swapped {
+				return b, a
+			}
+			return a, b
+		}
+
+		engine := matchSvc.Engine()
+		rng := newRand(deriveSeed(roundSeed, int64(last.HomeTeamID), int64(last.AwayTeamID), cupExtraTimeSeedPart))
+		extraHome, extraAway := orient(engine.SimulateExtraTime(rng, lastHome, lastAway))
+		tie.ExtraTimeHomeGoals, tie.ExtraTimeAwayGoals = &extraHome, &extraAway
+		homeGoals += extraHome
+		awayGoals += extraAway
+
+		if homeGoals == awayGoals {
+			penaltiesHome, penaltiesAway := orient(engine.SimulatePenalties(rng, lastHome, lastAway))
+			tie.PenaltiesHome, tie.PenaltiesAway = &penaltiesHome, &penaltiesAway
+			homeGoals += penaltiesHome
+			awayGoals += penaltiesAway
+		}
+	}
+
+	winner := tie.HomeTeamID
+	if awayGoals > homeGoals {
+		winner = *tie.AwayTeamID
+	}
+	tie.WinnerTeamID = &winner
+	if err := repos.Cups.UpdateTie(tie); err != nil {
+		return fmt.Errorf("failed to save cup tie %d: %w", tie.ID, err)
+	}
+	return nil
+}
+
+// drawCupPairs takımları eşleştirir. Seri başlı kurada takımlar seri başı sırasıyla verilmelidir:
+// ilk yarıdaki her takım ikinci yarıdan rastgele bir rakiple eşleşir ve ilk maçı evinde oynar.
+// Sabit ağaçta kura çekilmez: i. seri başı sondan i. takımla eşleşir ve ilk maçı evinde oynar.
+// Serbest kurada tüm takımlar karıştırılır ve ilk çekilen takım ev sahibi olur.
+func drawCupPairs(rng *rand.Rand, teamIDs []int, draw models.CupDraw) [][2]int {
+	half := len(teamIDs) / 2
+	pairs := make([][2]int, 0, half)
+	if draw == models.CupDrawFixed {
+		for i, teamID := range teamIDs[:half] {
+			pairs = append(pairs, [2]int{teamID, teamIDs[len(teamIDs)-1-i]})
+		}
+		return pairs
+	}
+	if draw == models.CupDrawSeeded {
+		unseeded := slices.Clone(teamIDs[half:])
+		rng.Shuffle(len(unseeded), func(i, j int) { unseeded[i], unseeded[j] = unseeded[j], unseeded[i] })
+		for i, teamID := range teamIDs[:half] {
+			pairs = append(pairs, [2]int{teamID, unseeded[i]})
+		}
+		return pairs
+	}
+
+	shuffled := slices.Clone(teamIDs)
+	rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	for i := 0; i+1 < len(shuffled); i += 2 {
+		pairs = append(pairs, [2]int{shuffled[i], shuffled[i+1]})
+	}
+	return pairs
+}
+
+// createCupTie eşleşmeyi ve maçlarını oluşturur. İki maçlı eşleşmelerde rövanşta saha değişir; final tek maçtır.
+func createCupTie(repos repositories.Repositories, cup *models.Cup, round, position int, pair [2]int) error {
+	legs := cup.Legs
+	if round == cup.TotalRounds {
+		legs = 1
+	}
+	tie := &models.CupTie{CupID: cup.ID, Round: round, Position: position, HomeTeamID: pair[0], AwayTeamID: &pair[1]}
+	for leg := 1; leg <= legs; leg++ {
+		match := &models.Match{CupID: cup.ID, HomeTeamID: pair[0], AwayTeamID: pair[1], Week: round}
+		if leg == 2 {
+			match.HomeTeamID, match.AwayTeamID = pair[1], pair[0]
+		}
+		if err := repos.Matches.CreateMatch(match); err != nil {
+			return fmt.Errorf("failed to create cup match: %w", err)
+		}
+		if leg == 1 {
+			tie.FirstLegMatchID = &match.ID
+		} else {
+			tie.SecondLegMatchID = &match.ID
+		}
+	}
+	if err := repos.Cups.CreateTie(tie); err != nil {
+		return fmt.Errorf("failed to save cup tie: %w", err)
+	}
+	return nil
+}
+
+// cupBracket kupanın kurası çekilmiş turlarını takım adları ve maçlarıyla birlikte döndürür.
+func cupBracket(repos repositories.Repositories, cup *models.Cup) (*models.CupBracket, error) {
+	ties, err := repos.Cups.GetTiesByCup(cup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cup ties: %w", err)
+	}
+	matches, err := repos.Matches.GetMatchesByCup(cup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cup matches: %w", err)
+	}
+	matchesByID := make(map[int]models.Match, len(matches))
+	for _, match := range matches {
+		matchesByID[match.ID] = match
+	}
+
+	names := make(map[int]string)
+	teamName := func(id int) (string, error) {
+		if name, ok := names[id]; ok {
+			return name, nil
+		}
+		team, err := repos.Teams.GetTeamByID(id)
+		if err != nil {
+			return "", fmt.Errorf("failed to get team %d: %w", id, err)
+		}
+		if team != nil {
+			names[id] = team.Name
+		}
+		return names[id], nil
+	}
+
+	rounds := make([]models.CupRound, cup.CurrentRound)
+	for i := range rounds {
+		rounds[i] = models.CupRound{Round: i + 1, Name: cupRoundName(cup.TotalRounds, i+1), Ties: []models.CupTie{}}
+	}
+	for _, tie := range ties {
+		if tie.Round < 1 || tie.Round > len(rounds) {
+			continue
+		}
+		if tie.HomeTeamName, err = teamName(tie.HomeTeamID); err != nil {
+			return nil, err
+		}
+		if tie.AwayTeamID != nil {
+			if tie.AwayTeamName, err = teamName(*tie.AwayTeamID); err != nil {
+				return nil, err
+			}
+		}
+		for _, id := range []*int{tie.FirstLegMatchID, tie.SecondLegMatchID} {
+			if id == nil {
+				continue
+			}
+			if match, ok := matchesByID[*id]; ok {
+				tie.Matches = append(tie.Matches, match)
+			}
+		}
+		rounds[tie.Round-1].Ties = append(rounds[tie.Round-1].Ties, tie)
+	}
+	return &models.CupBracket{Cup: *cup, Rounds: rounds}, nil
+}
+
+// sortBySeed takımları kupanın seri başı sırasına (teamIDs'deki sıraya) göre sıralanmış bir kopya olarak döndürür.
+func sortBySeed(entrants, teamIDs []int) []int {
+	seedRank := make(map[int]int, len(teamIDs))
+	for i, id := range teamIDs {
+		seedRank[id] = i
+	}
+	sorted := slices.Clone(entrants)
+	slices.SortFunc(sorted, func(a, b int) int { return seedRank[a] - seedRank[b] })
+	return sorted
+}
+
+func findCup(cupRepo repositories.CupRepository, cupID int) (*models.Cup, error) {
+	cup, err := cupRepo.GetCupByID(cupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cup: %w", err)
+	}
+	if cup == nil {
+		return nil, fmt.Errorf("%w: %d", ErrCupNotFound, cupID)
+	}
+	cup.TotalRounds = cupRounds(len(cup.TeamIDs))
+	return cup, nil
+}
+
+// cupRounds verilen sayıda takımla oynanan kupanın tur sayısını döndürür (eleme ağacı bir sonraki
+// ikinin kuvvetine tamamlanır).
+func cupRounds(numTeams int) int {
+	rounds := 0
+	for size := 1; size < numTeams; size *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// cupRoundName turu, tura kalan takım sayısına göre adlandırır.
+func cupRoundName(totalRounds, round int) string {
+	switch teams := 1 << (totalRounds - round + 1); teams {
+	case 2:
+		return "Final"
+	case 4:
+		return "Semi-finals"
+	case 8:
+		return "Quarter-finals"
+	default:
+		return fmt.Sprintf("Round of %d", teams)
+	}
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// levelEngine her maçı 1-1 bitirir; uzatma ve penaltı skorları maçın ev sahibinin bakış açısıyla sabittir.
+// Böylece eşleşmelerin toplam skoru hep eşit kalır ve uzatma ile penaltıların yönü doğrulanabilir.
+type levelEngine struct {
+	extraTime [2]int
+	penalties [2]int
+}
+
+func (e levelEngine) Name() string { return "level" }
+
+func (e levelEngine) SimulateScore(*rand.Rand, *models.Team, *models.Team) (int, int) { return 1, 1 }
+
+func (e levelEngine) SimulateExtraTime(*rand.Rand, *models.Team, *models.Team) (int, int) {
+	return e.extraTime[0], e.extraTime[1]
+}
+
+func (e levelEngine) SimulatePenalties(*rand.Rand, *models.Team, *models.Team) (int, int) {
+	return e.penalties[0], e.penalties[1]
+}
+
+func TestCupTwoLeggedWithByes(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine MatchEngine
+		seeds  int
+	}{
+		{name: "extra time", engine: levelEngine{extraTime: [2]int{1, 0}}, seeds: 1},
+		{name: "penalties", engine: levelEngine{penalties: [2]int{4, 2}}, seeds: 1},
+		{name: "poisson", engine: NewPoissonEngine(), seeds: 30},
+	}
+	for _, tt := range tests {
+		for seed := int64(0); seed < int64(tt.seeds); seed++ {
+			repos := newTestRepos()
+			var teamIDs []int
+			for i, name := range []string{"A", "B", "C", "D", "E", "F"} {
+				team := &models.Team{Name: name, Strength: 90 - 5*i}
+				if err := repos.teams.CreateTeam(team); err != nil {
+					t.Fatal(err)
+				}
+				teamIDs = append(teamIDs, team.ID)
+			}
+			svc := NewCupService(repos.cups, repos.matches, repos.teams, NewMatchService(repos.matches, tt.engine), repos.uow)
+
+			cup, err := svc.CreateCup(NewCup{Name: "Cup", TeamIDs: teamIDs, Legs: 2, Seed: &seed})
+			if err != nil {
+				t.Fatalf("%s: CreateCup: %v", tt.name, err)
+			}
+			for round := 1; round <= cup.TotalRounds; round++ {
+				if _, err := svc.DrawNextRound(cup.ID, nil); err != nil {
+					t.Fatalf("%s, seed %d: drawing round %d: %v", tt.name, seed, round, err)
+				}
+				if _, err := svc.PlayRound(cup.ID, nil); err != nil {
+					t.Fatalf("%s, seed %d: playing round %d: %v", tt.name, seed, round, err)
+				}
+			}
+
+			bracket, err := svc.GetBracket(cup.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bracket.Cup.Status != models.LeagueStatusCompleted || bracket.Cup.WinnerTeamID == nil {
+				t.Errorf("%s, seed %d: cup not completed: %+v", tt.name, seed, bracket.Cup)
+			}
+			if len(bracket.Rounds) != 3 {
+				t.Fatalf("%s, seed %d: got %d rounds, want 3", tt.name, seed, len(bracket.Rounds))
+			}
+
+			// 6 takımlık ağaçta 8 yer vardır; ilk iki seri başı ilk turu bay geçer.
+			byes := map[int]bool{}
+			for _, tie := range bracket.Rounds[0].Ties {
+				if tie.AwayTeamID == nil {
+					byes[tie.HomeTeamID] = true
+					if tie.WinnerTeamID == nil || *tie.WinnerTeamID != tie.HomeTeamID {
+						t.Errorf("%s, seed %d: bye of team %d has no winner", tt.name, seed, tie.HomeTeamID)
+					}
+				}
+			}
+			if len(byes) != 2 || !byes[teamIDs[0]] || !byes[teamIDs[1]] {
+				t.Errorf("%s, seed %d: byes went to %v, want the top two seeds %v", tt.name, seed, byes, teamIDs[:2])
+			}
+
+			for _, round := range bracket.Rounds {
+				for _, tie := range round.Ties {
+					if tie.WinnerTeamID == nil {
+						t.Errorf("%s, seed %d: round %d tie %d has no winner", tt.name, seed, round.Round, tie.ID)
+						continue
+					}
+					if tie.AwayTeamID == nil {
+						continue
+					}
+					wantLegs := 2
+					if round.Round == cup.TotalRounds {
+						wantLegs = 1
+					}
+					if len(tie.Matches) != wantLegs {
+						t.Errorf("%s, seed %d: round %d tie %d has %d matches, want %d", tt.name, seed, round.Round, tie.ID, len(tie.Matches), wantLegs)
+						continue
+					}
+					checkTie(t, tt.name, seed, tie, tt.engine)
+				}
+			}
+		}
+	}
+}
+
+// checkTie eşleşmenin kazananını maçlardan, uzatmadan ve penaltılardan yeniden hesaplar. Motor levelEngine
+// ise uzatma ve penaltı skorlarının eşleşmenin ev sahibinin bakış açısıyla saklandığını da doğrular.
+func checkTie(t *testing.T, name string, seed int64, tie models.CupTie, engine MatchEngine) {
+	t.Helper()
+
+	home, away := 0, 0
+	for _, match := range tie.Matches {
+		if !match.Played {
+			t.Errorf("%s, seed %d: match %d of tie %d was not played", name, seed, match.ID, tie.ID)
+		}
+		if match.HomeTeamID == tie.HomeTeamID {
+			home, away = home+match.HomeGoals, away+match.AwayGoals
+		} else {
+			home, away = home+match.AwayGoals, away+match.HomeGoals
+		}
+	}
+
+	level := home == away
+	if level != (tie.ExtraTimeHomeGoals != nil && tie.ExtraTimeAwayGoals != nil) {
+		t.Errorf("%s, seed %d: tie %d level on aggregate %t but extra time stored %t", name, seed, tie.ID, level, tie.ExtraTimeHomeGoals != nil)
+		return
+	}
+	if level {
+		home, away = home+*tie.ExtraTimeHomeGoals, away+*tie.ExtraTimeAwayGoals
+	}
+	if (home == away) != (tie.PenaltiesHome != nil && tie.PenaltiesAway != nil) {
+		t.Errorf("%s, seed %d: tie %d level after extra time %t but penalties stored %t", name, seed, tie.ID, home == away, tie.PenaltiesHome != nil)
+		return
+	}
+	if tie.PenaltiesHome != nil {
+		home, away = home+*tie.PenaltiesHome, away+*tie.PenaltiesAway
+	}
+
+	want := tie.HomeTeamID
+	if away > home {
+		want = *tie.AwayTeamID
+	}
+	if *tie.WinnerTeamID != want {
+		t.Errorf("%s, seed %d: tie %d winner = %d, want %d", name, seed, tie.ID, *tie.WinnerTeamID, want)
+	}
+
+	stub, ok := engine.(levelEngine)
+	if !ok {
+		return
+	}
+	// Uzatma ve penaltılar son maçın sahasında oynanır; son maçın ev sahibi eşleşmenin deplasman takımıysa
+	// motorun ürettiği skorlar ters çevrilerek saklanmalıdır.
+	orient := func(score [2]int) (int, int) {
+		if tie.Matches[len(tie.Matches)-1].HomeTeamID != tie.HomeTeamID {
+			return score[1], score[0]
+		}
+		return score[0], score[1]
+	}
+	if h, a := orient(stub.extraTime); *tie.ExtraTimeHomeGoals != h || *tie.ExtraTimeAwayGoals != a {
+		t.Errorf("%s: tie %d extra time = %d-%d, want %d-%d", name, tie.ID, *tie.ExtraTimeHomeGoals, *tie.ExtraTimeAwayGoals, h, a)
+	}
+	if stub.penalties == [2]int{} {
+		return
+	}
+	if h, a := orient(stub.penalties); *tie.PenaltiesHome != h || *tie.PenaltiesAway != a {
+		t.Errorf("%s: tie %d penalties = %d-%d, want %d-%d", name, tie.ID, *tie.PenaltiesHome, *tie.PenaltiesAway, h, a)
+	}
+}
+
+func TestPlayoffCupIsPlayedByItsLeague(t *testing.T) {
+	repos := newTestRepos()
+	svc := repos.leagueService(t, LeagueSettings{})
+	cupSvc := NewCupService(repos.cups, repos.matches, repos.teams, NewMatchService(repos.matches, NewPoissonEngine()), repos.uow)
+
+	seed := int64(7)
+	league, err := svc.CreateLeague(NewLeague{
+		Name:     "League",
+		Seed:     &seed,
+		Teams:    []NewTeam{{Name: "A", Strength: 80}, {Name: "B", Strength: 70}, {Name: "C", Strength: 60}, {Name: "D", Strength: 50}},
+		Playoffs: &models.PlayoffFormat{FirstPlace: 1, LastPlace: 4, Legs: 1, Prize: models.PlayoffPrizeTitle},
+	})
+	if err != nil {
+		t.Fatalf("CreateLeague: %v", err)
+	}
+	regularWeeks, err := svc.GetTotalWeeks(league.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for week := 1; week <= regularWeeks; week++ {
+		if err := svc.PlayWeek(league.ID, week, nil); err != nil {
+			t.Fatalf("PlayWeek(%d): %v", week, err)
+		}
+	}
+	season, err := currentSeason(repos.seasons, league.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if season.PlayoffCupID == nil {
+		t.Fatal("play-offs did not start after the regular season")
+	}
+
+	// Play-off kupası kupa uç noktalarıyla ilerletilemez; turları ligin haftalarıyla oynanır.
+	if _, err := cupSvc.PlayRound(*season.PlayoffCupID, nil); !errors.Is(err, ErrCupOwned) {
+		t.Errorf("playing a play-off round as a cup: err = %v, want ErrCupOwned", err)
+	}
+	if _, err := cupSvc.DrawNextRound(*season.PlayoffCupID, nil); !errors.Is(err, ErrCupOwned) {
+		t.Errorf("drawing a play-off round as a cup: err = %v, want ErrCupOwned", err)
+	}
+	if _, err := svc.SimulateAllWeeks(league.ID, nil); err != nil {
+		t.Fatalf("SimulateAllWeeks: %v", err)
+	}
+	cup, err := repos.cups.GetCupByID(*season.PlayoffCupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cup.Status != models.LeagueStatusCompleted || cup.WinnerTeamID == nil {
+		t.Errorf("play-off cup status = %s, winner = %v, want a completed cup with a winner", cup.Status, cup.WinnerTeamID)
+	}
+}
+
+func TestKnockoutCupIsPlayedByItsTournament(t *testing.T) {
+	repos := newTestRepos()
+	matchSvc := NewMatchService(repos.matches, NewPoissonEngine())
+	svc := NewTournamentService(repos.tournaments, repos.cups, repos.matches, repos.teams, matchSvc, repos.uow)
+	cupSvc := NewCupService(repos.cups, repos.matches, repos.teams, matchSvc, repos.uow)
+
+	groups := [][]int{{}, {}}
+	for i, name := range []string{"A", "B", "C", "D", "E", "F", "G", "H"} {
+		team := &models.Team{Name: name, Strength: 90 - 5*i}
+		if err := repos.teams.CreateTeam(team); err != nil {
+			t.Fatal(err)
+		}
+		groups[i%2] = append(groups[i%2], team.ID)
+	}
+	seed := int64(11)
+	tournament, err := svc.CreateTournament(NewTournament{Name: "Tournament", Groups: groups, Seed: &seed})
+	if err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	for tournament.CupID == nil {
+		if tournament, err = svc.PlayNextRound(tournament.ID, nil); err != nil {
+			t.Fatalf("PlayNextRound: %v", err)
+		}
+	}
+
+	if _, err := cupSvc.PlayRound(*tournament.CupID, nil); !errors.Is(err, ErrCupOwned) {
+		t.Errorf("playing a knockout round as a cup: err = %v, want ErrCupOwned", err)
+	}
+
+	// Sahipliği kaydedilmeden önce kupa uç noktalarıyla bitirilmiş bir eleme aşaması turnuvayı tamamlar.
+	cup, err := repos.cups.GetCupByID(*tournament.CupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cup.Status = models.LeagueStatusCompleted
+	if err := repos.cups.UpdateCup(cup); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.PlayNextRound(tournament.ID, nil); !errors.Is(err, ErrTournamentCompleted) {
+		t.Errorf("playing a decided knockout stage: err = %v, want ErrTournamentCompleted", err)
+	}
+	stored, err := repos.tournaments.GetTournamentByID(tournament.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != models.LeagueStatusCompleted {
+		t.Errorf("stored tournament status = %s, want completed", stored.Status)
+	}
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+const EngineDixonColes = "dixon-coles"
+
+const (
+	defaultDixonColesRho = -0.1
+	dixonColesMaxGoals   = 10
+	dixonColesMaxRho     = 0.3
+	// Parametre tahmini için gereken en az oynanmış maç sayısı; daha azında varsayılan değerler kullanılır.
+	dixonColesMinFitMatches = 6
+
+	dixonColesFitIterations = 500
+	// Her log(lambda) aynı anda dört parametreyle (sabit, iç saha, hücum, savunma) güncellendiğinden
+	// Newton adımı dörde bölünür.
+	dixonColesDamping          = 0.25
+	dixonColesRhoLearningRate  = 0.5
+	dixonColesL2Regularization = 0.01
+)
+
+// DixonColesEngine Poisson modelini Dixon-Coles düzeltmesiyle genişletir: 0-0, 1-0, 0-1 ve 1-1
+// skorlarının olasılıkları rho parametresiyle ayarlanır. Oynanmış maçlardan takım bazında hücum,
+// savunma, iç saha avantajı ve rho tahmin edilebilir; tahmini olmayan takımlar için beklenen
+// goller Base motorundan hesaplanır.
+type DixonColesEngine struct {
+	Base *PoissonEngine
+	Rho  float64
+
+	// Fit ile tahmin edilen parametreler (log ölçeğinde). Boşsa Base kullanılır.
+	Intercept     float64
+	HomeAdvantage float64
+	Attack        map[int]float64
+	Defense       map[int]float64
+}
+
+func NewDixonColesEngine() *DixonColesEngine {
+	return &DixonColesEngine{
+		Base: NewPoissonEngine(),
+		Rho:  defaultDixonColesRho,
+	}
+}
+
+// fitEngine motor Dixon-Coles ise verilen maçlarla (örneğin yalnızca bir ligin ya da kupanın maçlarıyla)
+// eğitilmiş yeni bir kopyasını döndürür; geçmiş sonuçları kullanmayan motorlar olduğu gibi döner.
+// Yeterli oynanmış maç yoksa varsayılan parametrelerle devam edilir.
+func fitEngine(engine MatchEngine, matches []models.Match) (MatchEngine, error) {
+	if _, ok := engine.(*DixonColesEngine); !ok {
+		return engine, nil
+	}
+
+	fitted := NewDixonColesEngine()
+	played := 0
+	for _, m := range matches {
+		if m.Played {
+			played++
+		}
+	}
+	if played < dixonColesMinFitMatches {
+		return fitted, nil
+	}
+	if err := fitted.Fit(matches); err != nil {
+		return nil, err
+	}
+	return fitted, nil
+}
+
+func (e *DixonColesEngine) Name() string {
+	return EngineDixonColes
+}
+
+// ExpectedGoals ev sahibi ve deplasman takımlarının beklenen gol sayılarını döndürür.
+func (e *DixonColesEngine) ExpectedGoals(homeTeam, awayTeam *models.Team) (float64, float64) {
+	homeAttack, okHomeAttack := e.Attack[homeTeam.ID]
+	homeDefense, okHomeDefense := e.Defense[homeTeam.ID]
+	awayAttack, okAwayAttack := e.Attack[awayTeam.ID]
+	awayDefense, okAwayDefense := e.Defense[awayTeam.ID]
+	if !okHomeAttack || !okHomeDefense || !okAwayAttack || !okAwayDefense {
+		return e.Base.ExpectedGoals(homeTeam, awayTeam)
+	}
+
+	homeXG := math.Exp(e.Intercept + e.HomeAdvantage + homeAttack - awayDefense)
+	awayXG := math.Exp(e.Intercept + awayAttack - homeDefense)
+	return homeXG, awayXG
+}
+
+// ScoreProbabilities 0..dixonColesMaxGoals aralığındaki her skor için düzeltilmiş olasılık matrisini döndürür.
+func (e *DixonColesEngine) ScoreProbabilities(homeTeam, awayTeam *models.Team) [][]float64 {
+	homeXG, awayXG := e.ExpectedGoals(homeTeam, awayTeam)
+	rho := clampRho(e.Rho, homeXG, awayXG)
+
+	homePMF := poissonPMF(homeXG, dixonColesMaxGoals)
+	awayPMF := poissonPMF(awayXG, dixonColesMaxGoals)
+
+	probs := make([][]float64, dixonColesMaxGoals+1)
+	total := 0.0
+	for x := range probs {
+		probs[x] = make([]float64, dixonColesMaxGoals+1)
+		for y := range probs[x] {
+			p := homePMF[x] * awayPMF[y] * dixonColesTau(x, y, homeXG, awayXG, rho)
+			probs[x][y] = p
+			total += p
+		}
+	}
+	// Kesilen kuyruk ve düzeltme nedeniyle toplam 1 olmayabilir.
+	for x := range probs {
+		for y := range probs[x] {
+			probs[x][y] /= total
+		}
+	}
+	return probs
+}
+
+func (e *DixonColesEngine) SimulateScore(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	return e.Prepare(homeTeam, awayTeam)(rng)
+}
+
+// SimulateExtraTime uzatma gollerini beklenen gollerden bağımsız Poisson dağılımlarıyla çeker;
+// düşük skor düzeltmesi yalnızca 90 dakikalık skorlar için tahmin edildiğinden uygulanmaz.
+func (e *DixonColesEngine) SimulateExtraTime(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	homeXG, awayXG := e.ExpectedGoals(homeTeam, awayTeam)
+	return sampleExtraTime(rng, homeXG, awayXG)
+}
+
+func (e *DixonColesEngine) SimulatePenalties(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	return simulateShootout(rng, homeTeam, awayTeam)
+}
+
+// Prepare skor olasılık matrisinin kümülatif dağılımını bir kez hesaplayan bir örnekleyici döndürür.
+func (e *DixonColesEngine) Prepare(homeTeam, awayTeam *models.Team) ScoreSampler {
+	probs := e.ScoreProbabilities(homeTeam, awayTeam)
+
+	size := dixonColesMaxGoals + 1
+	cumulative := make([]float64, 0, size*size)
+	total := 0.0
+	for x := range probs {
+		for y := range probs[x] {
+			total += probs[x][y]
+			cumulative = append(cumulative, total)
+		}
+	}
+
+	return func(rng *rand.Rand) (int, int) {
+		i := sort.SearchFloat64s(cumulative, rng.Float64())
+		if i >= len(cumulative) {
+			i = len(cumulative) - 1
+		}
+		return i / size, i % size
+	}
+}
+
+// Fit oynanmış maçlardan hücum/savunma güçlerini, iç saha avantajını ve rho değerini
+// maksimum olabilirlik yöntemiyle tahmin eder. Gol parametreleri Fisher bilgisinin köşegeniyle
+// ölçeklenen (sönümlü Newton) adımlarla, rho ise gradyan yükselişiyle güncellenir. Hücum ve
+// savunma parametreleri her adımda sıfır ortalamaya çekilir; az veriyle aşırı uyumu önlemek
+// için küçük bir L2 cezası uygulanır.
+func (e *DixonColesEngine) Fit(matches []models.Match) error {
+	var played []models.Match
+	teamIndex := make(map[int]int)
+	for _, m := range matches {
+		if !m.Played {
+			continue
+		}
+		played = append(played, m)
+		for _, id := range []int{m.HomeTeamID, m.AwayTeamID} {
+			if _, ok := teamIndex[id]; !ok {
+				teamIndex[id] = len(teamIndex)
+			}
+		}
+	}
+	if len(played) < dixonColesMinFitMatches {
+		return fmt.Errorf("at least %d played matches are required to fit dixon-coles, got %d", dixonColesMinFitMatches, len(played))
+	}
+
+	numTeams := len(teamIndex)
+	attack := make([]float64, numTeams)
+	defense := make([]float64, numTeams)
+	totalGoals := 0
+	for _, m := range played {
+		totalGoals += m.HomeGoals + m.AwayGoals
+	}
+	intercept := math.Log(math.Max(float64(totalGoals)/float64(2*len(played)), 0.1))
+	home := 0.0
+	rho := 0.0
+
+	gradAttack := make([]float64, numTeams)
+	gradDefense := make([]float64, numTeams)
+	infoAttack := make([]float64, numTeams)
+	infoDefense := make([]float64, numTeams)
+	penalty := dixonColesL2Regularization * float64(len(played))
+	rhoStep := dixonColesRhoLearningRate / float64(len(played))
+
+	for iter := 0; iter < dixonColesFitIterations; iter++ {
+		for i := range gradAttack {
+			gradAttack[i] = -penalty * attack[i]
+			gradDefense[i] = -penalty * defense[i]
+			infoAttack[i] = penalty
+			infoDefense[i] = penalty
+		}
+		gradIntercept, gradHome, gradRho := 0.0, 0.0, 0.0
+		infoIntercept, infoHome := 0.0, 0.0
+
+		for _, m := range played {
+			h, a := teamIndex[m.HomeTeamID], teamIndex[m.AwayTeamID]
+			lambda := math.Exp(intercept + home + attack[h] - defense[a])
+			mu := math.Exp(intercept + attack[a] - defense[h])
+			r := clampRho(rho, lambda, mu)
+
+			// Log-olabilirliğin log(lambda), log(mu) ve rho'ya göre türevleri
+			gLambda := float64(m.HomeGoals) - lambda
+			gMu := float64(m.AwayGoals) - mu
+			switch {
+			case m.HomeGoals == 0 && m.AwayGoals == 0:
+				denom := 1 - lambda*mu*r
+				gLambda -= lambda * mu * r / denom
+				gMu -= lambda * mu * r / denom
+				gradRho -= lambda * mu / denom
+			case m.HomeGoals == 0 && m.AwayGoals == 1:
+				denom := 1 + lambda*r
+				gLambda += lambda * r / denom
+				gradRho += lambda / denom
+			case m.HomeGoals == 1 && m.AwayGoals == 0:
+				denom := 1 + mu*r
+				gMu += mu * r / denom
+				gradRho += mu / denom
+			case m.HomeGoals == 1 && m.AwayGoals == 1:
+				gradRho -= 1 / (1 - r)
+			}
+
+			gradIntercept += gLambda + gMu
+			gradHome += gLambda
+			gradAttack[h] += gLambda
+			gradDefense[a] -= gLambda
+			gradAttack[a] += gMu
+			gradDefense[h] -= gMu
+
+			// Poisson log-olabilirliğinde log(lambda) için Fisher bilgisi lambda'dır.
+			infoIntercept += lambda + mu
+			infoHome += lambda
+			infoAttack[h] += lambda
+			infoDefense[a] += lambda
+			infoAttack[a] += mu
+			infoDefense[h] += mu
+		}
+
+		intercept += dixonColesDamping * gradIntercept / infoIntercept
+		home += dixonColesDamping * gradHome / infoHome
+		rho = math.Max(-dixonColesMaxRho, math.Min(dixonColesMaxRho, rho+rhoStep*gradRho))
+		for i := range attack {
+			attack[i] += dixonColesDamping * gradAttack[i] / infoAttack[i]
+			defense[i] += dixonColesDamping * gradDefense[i] / infoDefense[i]
+		}
+		centre(attack)
+		centre(defense)
+	}
+
+	e.Intercept = intercept
+	e.HomeAdvantage = home
+	e.Rho = rho
+	e.Attack = make(map[int]float64, numTeams)
+	e.Defense = make(map[int]float64, numTeams)
+	for id, i := range teamIndex {
+		e.Attack[id] = attack[i]
+		e.Defense[id] = defense[i]
+	}
+	return nil
+}
+
+// dixonColesTau düşük skorlu sonuçlar için bağımlılık düzeltme katsayısıdır.
+func dixonColesTau(homeGoals, awayGoals int, lambda, mu, rho float64) float64 {
+	switch {
+	case homeGoals == 0 && awayGoals == 0:
+		return 1 - lambda*mu*rho
+	case homeGoals == 0 && awayGoals == 1:
+		return 1 + lambda*rho
+	case homeGoals == 1 && awayGoals == 0:
+		return 1 + mu*rho
+	case homeGoals == 1 && awayGoals == 1:
+		return 1 - rho
+	default:
+		return 1
+	}
+}
+
+// clampRho tau katsayılarının pozitif kalacağı aralığa rho değerini sınırlar.
+func clampRho(rho, lambda, mu float64) float64 {
+	const margin = 0.99
+	lower := math.Max(-1/lambda, -1/mu) * margin
+	upper := math.Min(1/(lambda*mu), 1) * margin
+	return math.Max(lower, math.Min(upper, rho))
+}
+
+// poissonPMF 0..maxGoals için Poisson olasılık kütle fonksiyonunu hesaplar.
+func poissonPMF(lambda float64, maxGoals int) []float64 {
+	pmf := make([]float64, maxGoals+1)
+	pmf[0] = math.Exp(-lambda)
+	for k := 1; k <= maxGoals; k++ {
+		pmf[k] = pmf[k-1] * lambda / float64(k)
+	}
+	return pmf
+}
+
+// centre değerleri ortalamaları sıfır olacak şekilde kaydırır.
+func centre(values []float64) {
+	if len(values) == 0 {
+		return
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for i := range values {
+		values[i] -= mean
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+// ErrorKind bir alan hatasının türüdür. Handler'lar yanıtın HTTP durum kodunu hatanın türüne göre seçer.
+type ErrorKind int
+
+const (
+	// KindNotFound istenen lig, sezon, kupa, turnuva, takım ya da maç yoktur.
+	KindNotFound ErrorKind = iota + 1
+	// KindInvalid isteğin içeriği alan kurallarına uymaz (doğrulama hatası).
+	KindInvalid
+	// KindConflict istek kaydın güncel durumuyla çelişir (tamamlanmış lig, güncel olmayan hafta, eşzamanlı
+	// değişiklik, kullanımdaki takım gibi).
+	KindConflict
+)
+
+// Error servislerin döndürdüğü alan hatasıdır. Hatalar aşağıdaki sabit değerler olarak tanımlanır ve ayrıntı
+// eklemek için fmt.Errorf("%w: ...") ile sarmalanır; hangi hata olduğu errors.Is ile, türü errors.As ile
+// öğrenilir.
+type Error struct {
+	Kind    ErrorKind
+	Code    string // Hatanın makinece okunabilir adı, örneğin "league_not_found"
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// ErrLeagueCompleted, fikstürdeki tüm haftalar oynandıktan sonra yeni bir hafta oynatılmak istendiğinde döner.
+var ErrLeagueCompleted = newError(KindConflict, "league_completed", "league has already completed")
+
+// ErrWeekMismatch, oynatılmak istenen hafta ligin güncel haftası olmadığında döner.
+var ErrWeekMismatch = newError(KindConflict, "week_mismatch", "week is not the current week of the league")
+
+// ErrConflict, lig, kupa ya da turnuva isteğin okuduğu halinden sonra başka bir istek tarafından
+// değiştirildiğinde döner (örneğin aynı hafta iki istekle aynı anda oynatılmak istendiğinde).
+var ErrConflict = newError(KindConflict, "concurrent_modification", "modified by another request")
+
+// ErrLeagueNotFound, istenen kimlikte bir lig olmadığında döner.
+var ErrLeagueNotFound = newError(KindNotFound, "league_not_found", "league not found")
+
+// ErrInvalidLeague, oluşturulmak istenen ligin bilgileri geçersiz olduğunda döner.
+var ErrInvalidLeague = newError(KindInvalid, "invalid_league", "invalid league")
+
+// ErrSeasonNotFound, ligin istenen numarada bir sezonu olmadığında döner.
+var ErrSeasonNotFound = newError(KindNotFound, "season_not_found", "season not found")
+
+// ErrInvalidFixtureQuery, fikstür isteğinin ölçütleri geçersiz olduğunda döner.
+var ErrInvalidFixtureQuery = newError(KindInvalid, "invalid_fixture_query", "invalid fixture query")
+
+// ErrSeasonNotCompleted, sezon geçişi istendiğinde piramitteki liglerden birinin sezonu henüz tamamlanmadıysa döner.
+var ErrSeasonNotCompleted = newError(KindConflict, "season_not_completed", "season is not completed")
+
+// ErrCupNotFound, istenen kimlikte bir kupa olmadığında döner.
+var ErrCupNotFound = newError(KindNotFound, "cup_not_found", "cup not found")
+
+// ErrInvalidCup, oluşturulmak istenen kupanın bilgileri geçersiz olduğunda döner.
+var ErrInvalidCup = newError(KindInvalid, "invalid_cup", "invalid cup")
+
+// ErrCupCompleted, final oynandıktan sonra yeni bir tur çekilmek ya da oynatılmak istendiğinde döner.
+var ErrCupCompleted = newError(KindConflict, "cup_completed", "cup has already completed")
+
+// ErrCupRoundNotPlayed, güncel turun eşleşmeleri tamamlanmadan sonraki turun kurası çekilmek istendiğinde döner.
+var ErrCupRoundNotPlayed = newError(KindConflict, "cup_round_not_played", "current cup round has not been played")
+
+// ErrCupRoundNotDrawn, oynatılacak turun kurası henüz çekilmediğinde döner.
+var ErrCupRoundNotDrawn = newError(KindConflict, "cup_round_not_drawn", "next cup round has not been drawn")
+
+// ErrCupOwned, bir ligin ya da turnuvanın oynattığı kupanın kurası kupa uç noktalarıyla çekilmek ya da turu
+// oynatılmak istendiğinde döner.
+var ErrCupOwned = newError(KindConflict, "cup_owned", "cup is played by its league or tournament")
+
+// ErrTournamentNotFound, istenen kimlikte bir turnuva olmadığında döner.
+var ErrTournamentNotFound = newError(KindNotFound, "tournament_not_found", "tournament not found")
+
+// ErrInvalidTournament, oluşturulmak istenen turnuvanın bilgileri geçersiz olduğunda döner.
+var ErrInvalidTournament = newError(KindInvalid, "invalid_tournament", "invalid tournament")
+
+// ErrTournamentCompleted, eleme aşamasının finali oynandıktan sonra yeni bir tur oynatılmak istendiğinde döner.
+var ErrTournamentCompleted = newError(KindConflict, "tournament_completed", "tournament has already completed")
+
+// ErrMatchNotFound, istenen kimlikte bir maç olmadığında döner.
+var ErrMatchNotFound = newError(KindNotFound, "match_not_found", "match not found")
+
+// ErrInvalidResult, elle girilmek istenen maç sonucu geçersiz olduğunda ya da maç bir lig maçı olmadığında döner.
+var ErrInvalidResult = newError(KindInvalid, "invalid_result", "invalid match result")
+
+// ErrResultLocked, arşivlenmiş bir sezonun ya da play-off'u başlamış bir sezonun maç sonucu değiştirilmek istendiğinde döner.
+var ErrResultLocked = newError(KindConflict, "result_locked", "match result can no longer be changed")
+
+// ErrTeamNotFound, istenen kimlikte bir takım olmadığında döner.
+var ErrTeamNotFound = newError(KindNotFound, "team_not_found", "team not found")
+
+// ErrInvalidTeam, oluşturulmak ya da güncellenmek istenen takımın bilgileri geçersiz olduğunda döner.
+var ErrInvalidTeam = newError(KindInvalid, "invalid_team", "invalid team")
+
+// ErrTeamInUse, fikstürü olan ya da bir lige, kupaya veya turnuvaya katılan bir takım silinmek istendiğinde döner.
+var ErrTeamInUse = newError(KindConflict, "team_in_use", "team is in use")
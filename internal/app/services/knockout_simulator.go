@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// knockoutSimulator Monte Carlo tahminleri için bir eleme ağacının kalan turlarını takım indeksleriyle
+// simüle eder. Turnuvaların eleme aşaması ve liglerin play-off'ları tarafından kullanılır. Yalnızca
+// okunur; her işçi kendi knockoutState'ini tutar.
+type knockoutSimulator struct {
+	teams       []models.Team
+	engine      MatchEngine
+	legs        int // Final dışındaki eşleşmelerin maç sayısı
+	draw        models.CupDraw
+	totalRounds int
+}
+
+// simTie bir eleme eşleşmesidir; away -1 ise ev sahibi bay geçer, winner -1 ise eşleşme sonuçlanmamıştır.
+type simTie struct {
+	home, away, winner int // teams dizisindeki indeksler
+}
+
+// knockoutState tek bir işçinin simülasyon başına yeniden doldurulan çalışma alanıdır.
+type knockoutState struct {
+	samplers map[[2]int]ScoreSampler
+	seedRank []int // Takımların seri başı sırası; seri başlı ve sabit kuralarda kullanılır
+	ties     []simTie
+	entrants []int
+}
+
+func (ks *knockoutSimulator) newState() *knockoutState {
+	return &knockoutState{
+		samplers: make(map[[2]int]ScoreSampler),
+		seedRank: make([]int, len(ks.teams)),
+	}
+}
+
+// newSimTie kupanın eşleşmesini teams indeksleriyle ifade eder.
+func newSimTie(tie models.CupTie, index map[int]int) (simTie, error) {
+	home, ok := index[tie.HomeTeamID]
+	if !ok {
+		return simTie{}, fmt.Errorf("cup tie %d references unknown team %d", tie.ID, tie.HomeTeamID)
+	}
+	st := simTie{home: home, away: -1, winner: -1}
+	if tie.AwayTeamID != nil {
+		if st.away, ok = index[*tie.AwayTeamID]; !ok {
+			return simTie{}, fmt.Errorf("cup tie %d references unknown team %d", tie.ID, *tie.AwayTeamID)
+		}
+	}
+	if tie.WinnerTeamID != nil {
+		st.winner = index[*tie.WinnerTeamID]
+	}
+	return st, nil
+}
+
+// drawRound turun kurasını drawNextCupRound gibi çeker ve st.ties'a yazar: takımlar seri başı sırasına
+// dizilir, ilk turda en iyi seri başları bay geçer.
+func (ks *knockoutSimulator) drawRound(rng *rand.Rand, st *knockoutState, round int, entrants []int) {
+	sorted := append(st.entrants[:0], entrants...)
+	slices.SortFunc(sorted, func(a, b int) int { return st.seedRank[a] - st.seedRank[b] })
+
+	st.ties = st.ties[:0]
+	if round == 1 {
+		byes := 1<<ks.totalRounds - len(sorted)
+		for _, team := range sorted[:byes] {
+			st.ties = append(st.ties, simTie{home: team, away: -1, winner: team})
+		}
+		sorted = sorted[byes:]
+	}
+	for _, pair := range drawCupPairs(rng, sorted, ks.draw) {
+		st.ties = append(st.ties, simTie{home: pair[0], away: pair[1], winner: -1})
+	}
+}
+
+// run st.ties'taki round turundan başlayarak ağacı finale kadar oynatır ve şampiyonu döndürür. stages nil
+// değilse takımların ulaştığı son aşamayı (tur numarası, şampiyon için totalRounds+1) yükseltir.
+func (ks *knockoutSimulator) run(rng *rand.Rand, st *knockoutState, round int, stages []int) int {
+	for ; ; round++ {
+		legs := ks.legs
+		if round == ks.totalRounds {
+			legs = 1
+		}
+		winners := st.entrants[:0]
+		for i := range st.ties {
+			tie := &st.ties[i]
+			if tie.winner < 0 {
+				tie.winner = ks.playTie(rng, st, tie.home, tie.away, legs)
+			}
+			if stages != nil {
+				ks.reach(stages, *tie, round)
+			}
+			winners = append(winners, tie.winner)
+		}
+		if round >= ks.totalRounds {
+			return winners[0]
+		}
+		ks.drawRound(rng, st, round+1, winners)
+	}
+}
+
+// reach eşleşmenin takımlarının verilen tura ulaştığını, finalse kazananın şampiyon olduğunu kaydeder.
+func (ks *knockoutSimulator) reach(stages []int, tie simTie, round int) {
+	stages[tie.home] = max(stages[tie.home], round)
+	if tie.away >= 0 {
+		stages[tie.away] = max(stages[tie.away], round)
+	}
+	if round == ks.totalRounds && tie.winner >= 0 {
+		stages[tie.winner] = ks.totalRounds + 1
+	}
+}
+
+// playTie eşleşmeyi simüle eder ve kazananı döndürür. Toplam skor eşitse son maçın sahasında uzatma,
+// o da eşitse penaltılar oynanır.
+func (ks *knockoutSimulator) playTie(rng *rand.Rand, st *knockoutState, home, away, legs int) int {
+	homeGoals, awayGoals := 0, 0
+	venueHome, venueAway := home, away
+	for leg := 1; leg <= legs; leg++ {
+		if leg == 2 {
+			venueHome, venueAway = away, home
+		}
+		goals, conceded := st.sampler(ks, venueHome, venueAway)(rng)
+		if venueHome == home {
+			homeGoals, awayGoals = homeGoals+goals, awayGoals+conceded
+		} else {
+			homeGoals, awayGoals = homeGoals+conceded, awayGoals+goals
+		}
+	}
+	if homeGoals > awayGoals {
+		return home
+	}
+	if awayGoals > homeGoals {
+		return away
+	}
+
+	homeTeam, awayTeam := &ks.teams[venueHome], &ks.teams[venueAway]
+	extraHome, extraAway := ks.engine.SimulateExtraTime(rng, homeTeam, awayTeam)
+	if extraHome == extraAway {
+		extraHome, extraAway = ks.engine.SimulatePenalties(rng, homeTeam, awayTeam)
+	}
+	if extraHome > extraAway {
+		return venueHome
+	}
+	return venueAway
+}
+
+// sampler eşleşmenin örnekleyicisini işçinin önbelleğinden döndürür; eleme eşleşmeleri önceden
+// bilinmediğinden örnekleyiciler ilk kullanıldıklarında hazırlanır.
+func (st *knockoutState) sampler(ks *knockoutSimulator, home, away int) ScoreSampler {
+	key := [2]int{home, away}
+	sample, ok := st.samplers[key]
+	if !ok {
+		sample = prepareScore(ks.engine, &ks.teams[home], &ks.teams[away])
+		st.samplers[key] = sample
+	}
+	return sample
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// validateEngineName ligin seçtiği maç motorunun adını doğrular; boş ad sunucunun varsayılan motorudur.
+func validateEngineName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := NewMatchEngine(name); err != nil {
+		return fmt.Errorf("%w: %v, expected %s, %s or %s", ErrInvalidLeague, err, EngineLegacy, EnginePoisson, EngineDixonColes)
+	}
+	return nil
+}
+
+// leagueEngineName ligin kullandığı maç motorunun adını döndürür. Lig bir motor seçmediyse sunucunun
+// varsayılan motoru kullanılır.
+func (s *leagueService) leagueEngineName(league *models.LeagueState) string {
+	if league.MatchEngine == "" {
+		return s.matchSvc.Engine().Name()
+	}
+	return league.MatchEngine
+}
+
+// leagueEngine ligin maçlarını ve tahminlerini simüle eden motoru döndürür. Geçmiş sonuçlardan beslenen
+// motorların (Dixon-Coles) parametreleri yalnızca bu ligin oynanmış maçlarından ve her çağrıda yeniden
+// tahmin edilir; oynanan haftalar ve düzeltilen sonuçlar bir sonraki simülasyona hemen yansır.
+func (s *leagueService) leagueEngine(league *models.LeagueState) (MatchEngine, error) {
+	engine := s.matchSvc.Engine()
+	if league.MatchEngine != "" {
+		var err error
+		if engine, err = NewMatchEngine(league.MatchEngine); err != nil {
+			return nil, err
+		}
+	}
+	matches, err := s.matchRepo.GetMatchesByLeague(league.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load matches of league %d for the match engine: %w", league.ID, err)
+	}
+	return fitEngine(engine, matches)
+}
+
+// SetLeagueMatchEngine ligin maç motorunu değiştirir; name boşsa lig sunucunun varsayılan motoruna döner.
+// Yeni motor ligin sonraki maçlarında ve tahminlerinde kullanılır; oynanmış maçlar değişmez.
+func (s *leagueService) SetLeagueMatchEngine(leagueID int, name string) (*models.LeagueState, error) {
+	if err := validateEngineName(name); err != nil {
+		return nil, err
+	}
+
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.lockLeague(league.ID)()
+	defer s.predictions.invalidate()
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		league, err = findLeague(repos.Leagues, league.ID)
+		if err != nil {
+			return err
+		}
+		league.MatchEngine = name
+		return repos.Leagues.UpdateLeague(league)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: league was changed while updating its match engine", ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// GetFixtures ligin güncel (ya da query.Season ile seçilen) sezonunun fikstürünü ölçütlere göre süzer ve
+// istenen sayfayı takım adlarıyla birlikte döndürür. Süzme ve sayfalama veritabanında yapılır. Play-off
+// maçları kupanın eleme ağacında yer aldığından fikstüre dahil değildir.
+func (s *leagueService) GetFixtures(leagueID int, query FixtureQuery) (*models.Fixtures, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultFixtureLimit
+	}
+
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	season, err := findSeason(s.seasonRepo, league.ID, query.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, total, err := s.matchRepo.FindMatches(repositories.MatchFilter{
+		SeasonID:   season.ID,
+		Week:       query.Week,
+		TeamID:     query.TeamID,
+		Venue:      query.Venue,
+		Played:     query.Played,
+		Sort:       query.Sort,
+		Descending: query.Descending,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matches: %w", err)
+	}
+
+	fixtureMatches, err := s.fixtureMatches(league.ID, matches)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Fixtures{
+		LeagueID: league.ID,
+		Season:   season.Number,
+		Total:    total,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+		Matches:  fixtureMatches,
+	}, nil
+}
+
+// GetWeekResults güncel sezonun verilen haftasının maçlarını takım adlarıyla ve ligin güncel puan
+// durumunu döndürür. Play-off haftalarında maçlar yerine play-off'un eleme ağacı döner.
+func (s *leagueService) GetWeekResults(ctx context.Context, leagueID, week int) (*models.WeekResults, error) {
+	table, err := s.GetLeagueTable(ctx, leagueID, LeagueTableOptions{})
+	if err != nil {
+		return nil, err
+	}
+	season, err := currentSeason(s.seasonRepo, table.ID)
+	if err != nil {
+		return nil, err
+	}
+	matches, _, err := s.matchRepo.FindMatches(repositories.MatchFilter{SeasonID: season.ID, Week: week})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find matches: %w", err)
+	}
+	fixtureMatches, err := s.fixtureMatches(table.ID, matches)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WeekResults{
+		LeagueID: table.ID,
+		Season:   table.Season,
+		Week:     week,
+		Status:   table.Status,
+		Matches:  fixtureMatches,
+		Table:    table.Teams,
+		Playoffs: table.Playoffs,
+	}, nil
+}
+
+// fixtureMatches maçları takım adlarıyla zenginleştirir.
+func (s *leagueService) fixtureMatches(leagueID int, matches []models.Match) ([]models.FixtureMatch, error) {
+	names, err := s.teamNames(leagueID, matches)
+	if err != nil {
+		return nil, err
+	}
+	fixtureMatches := make([]models.FixtureMatch, len(matches))
+	for i, match := range matches {
+		fixtureMatches[i] = models.FixtureMatch{
+			Match:        match,
+			HomeTeamName: names[match.HomeTeamID],
+			AwayTeamName: names[match.AwayTeamID],
+		}
+	}
+	return fixtureMatches, nil
+}
+
+// teamNames maçlardaki takımların adlarını döndürür. Adlar ligin takımlarından okunur; arşivlenmiş
+// sezonlarda ligden ayrılmış takımlar ayrıca okunur.
+func (s *leagueService) teamNames(leagueID int, matches []models.Match) (map[int]string, error) {
+	teams, err := s.teamRepo.GetTeamsByLeague(leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+	names := make(map[int]string, len(teams))
+	for _, team := range teams {
+		names[team.ID] = team.Name
+	}
+	for _, match := range matches {
+		for _, id := range []int{match.HomeTeamID, match.AwayTeamID} {
+			if _, ok := names[id]; ok {
+				continue
+			}
+			team, err := findTeam(s.teamRepo, id)
+			if err != nil {
+				return nil, err
+			}
+			names[id] = team.Name
+		}
+	}
+	return names, nil
+}
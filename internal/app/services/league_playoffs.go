@@ -0,0 +1,326 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// validatePlayoffs play-off biçimini ligin takım sayısına ve piramitteki yerine göre doğrular ve eksik
+// alanları varsayılanlarla doldurur (eleme play-off'u, tek maçlı eşleşmeler, şampiyonluk play-off'u).
+func validatePlayoffs(format *models.PlayoffFormat, league *models.LeagueState, numTeams int) error {
+	if format.Kind == "" {
+		format.Kind = models.PlayoffKindKnockout
+	}
+	if format.Legs == 0 {
+		format.Legs = 1
+	}
+	if format.Legs != 1 && format.Legs != 2 {
+		return fmt.Errorf("%w: playoff legs must be 1 or 2, got %d", ErrInvalidLeague, format.Legs)
+	}
+	switch format.Kind {
+	case models.PlayoffKindKnockout:
+		return validateKnockoutPlayoffs(format, league, numTeams)
+	case models.PlayoffKindSplit:
+		return validateSplit(format, numTeams)
+	default:
+		return fmt.Errorf("%w: unknown playoff kind %q", ErrInvalidLeague, format.Kind)
+	}
+}
+
+// validateKnockoutPlayoffs eleme play-off'unun sıralarını ve ödülünü doğrular.
+func validateKnockoutPlayoffs(format *models.PlayoffFormat, league *models.LeagueState, numTeams int) error {
+	if format.Prize == "" {
+		format.Prize = models.PlayoffPrizeTitle
+	}
+	if format.SplitPlace != 0 {
+		return fmt.Errorf("%w: split_place is only used by split playoffs", ErrInvalidLeague)
+	}
+	if format.FirstPlace < 1 || format.LastPlace <= format.FirstPlace {
+		return fmt.Errorf("%w: playoff places must span at least two positions, got %d-%d", ErrInvalidLeague, format.FirstPlace, format.LastPlace)
+	}
+	if format.LastPlace > numTeams {
+		return fmt.Errorf("%w: playoff places %d-%d exceed the league's %d teams", ErrInvalidLeague, format.FirstPlace, format.LastPlace, numTeams)
+	}
+	switch format.Prize {
+	case models.PlayoffPrizeTitle:
+	case models.PlayoffPrizePromotion:
+		if league.ParentLeagueID == nil || league.PromotionPlaces == 0 {
+			return fmt.Errorf("%w: promotion playoffs require a parent league and at least one promotion place", ErrInvalidLeague)
+		}
+		// İlk PromotionPlaces-1 takım doğrudan çıktığından play-off'a onlardan sonraki takımlar katılır.
+		if format.FirstPlace < league.PromotionPlaces {
+			return fmt.Errorf("%w: promotion playoffs must start at place %d or below, after the automatic promotion places", ErrInvalidLeague, league.PromotionPlaces)
+		}
+		if format.LastPlace > numTeams-league.RelegationPlaces {
+			return fmt.Errorf("%w: promotion playoffs must not include the relegation places", ErrInvalidLeague)
+		}
+	default:
+		return fmt.Errorf("%w: unknown playoff prize %q", ErrInvalidLeague, format.Prize)
+	}
+	return nil
+}
+
+// SetLeaguePlayoffs ligin sezon sonu play-off biçimini ayarlar; format nil ise lig play-off oynamaz.
+// Yeni biçim, play-off'u henüz başlamamış sezonun sonunda uygulanır.
+func (s *leagueService) SetLeaguePlayoffs(leagueID int, format *models.PlayoffFormat) (*models.LeagueState, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.lockLeague(league.ID)()
+	defer s.predictions.invalidate()
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		league, err = findLeague(repos.Leagues, league.ID)
+		if err != nil {
+			return err
+		}
+		if format != nil {
+			teams, err := repos.Teams.GetTeamsByLeague(league.ID)
+			if err != nil {
+				return err
+			}
+			if err := validatePlayoffs(format, league, len(teams)); err != nil {
+				return err
+			}
+		}
+		league.Playoffs = format
+		return repos.Leagues.UpdateLeague(league)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: league was changed while updating its playoffs", ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
+
+// startPlayoffs normal sezonu biten ligin play-off kupasını sezonun final sıralamasıyla oluşturur ve ilk
+// turun eşleşmelerini belirler; play-off bölünmeyse tabloyu böler (bkz. startSplit). Sıralamada üstte
+// bitiren takım seri başıdır. Lig, play-off bitene kadar devam ediyor sayılır. Lig ve kupa kaydedilmez.
+func startPlayoffs(repos repositories.Repositories, league *models.LeagueState, season *models.Season, rules standings.Rules, seed int64) error {
+	format := league.Playoffs
+	teams, err := repos.Teams.GetTeamsByLeague(league.ID)
+	if err != nil {
+		return err
+	}
+	matches, err := repos.Matches.GetMatchesBySeason(season.ID)
+	if err != nil {
+		return err
+	}
+	ranked := rankTeams(teams, matches, 0, rules)
+	if isSplit(format) {
+		return startSplit(repos, league, season, ranked)
+	}
+	if format.FirstPlace >= len(ranked) {
+		return nil
+	}
+
+	cup := &models.Cup{
+		Name:   fmt.Sprintf("%s play-offs (season %d)", league.Name, season.Number),
+		Legs:   format.Legs,
+		Draw:   models.CupDrawFixed,
+		Seed:   league.Seed,
+		Owner:  models.CupOwnerLeague,
+		Status: models.LeagueStatusNotStarted,
+	}
+	for _, team := range ranked[format.FirstPlace-1 : min(format.LastPlace, len(ranked))] {
+		cup.TeamIDs = append(cup.TeamIDs, team.ID)
+	}
+	if err := repos.Cups.CreateCup(cup); err != nil {
+		return fmt.Errorf("failed to create playoff cup: %w", err)
+	}
+	cup.TotalRounds = cupRounds(len(cup.TeamIDs))
+	if err := drawNextCupRound(repos, cup, &seed); err != nil {
+		return err
+	}
+	if err := repos.Cups.UpdateCup(cup); err != nil {
+		return fmt.Errorf("failed to save playoff cup: %w", err)
+	}
+
+	season.PlayoffCupID = &cup.ID
+	season.PlayoffPrize = format.Prize
+	if err := repos.Seasons.UpdateSeason(season); err != nil {
+		return fmt.Errorf("failed to save season %d: %w", season.Number, err)
+	}
+	league.Status = models.LeagueStatusInProgress
+	return nil
+}
+
+// playPlayoffWeek normal sezondan sonraki bir haftada play-off'un güncel turunu oynatır ve sonraki turun
+// eşleşmelerini belirler. Final oynandığında sezon tamamlanır. Haftanın tohumu normal sezondaki gibi türetilir.
+func (s *leagueService) playPlayoffWeek(league *models.LeagueState, season *models.Season, week int, seed *int64) error {
+	engine, err := s.leagueEngine(league)
+	if err != nil {
+		return err
+	}
+	weekSeed := deriveSeed(resolveSeed(seed, league.Seed), int64(week))
+	defer s.predictions.invalidate()
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		cup, err := findCup(repos.Cups, *season.PlayoffCupID)
+		if err != nil {
+			return err
+		}
+		if err := advanceCup(repos, s.matchSvc.WithEngine(engine), cup, &weekSeed); err != nil {
+			return err
+		}
+
+		league.CurrentWeek = week + 1
+		if cup.Status == models.LeagueStatusCompleted {
+			league.Status = models.LeagueStatusCompleted
+		}
+		if err := repos.Leagues.UpdateLeague(league); err != nil {
+			return fmt.Errorf("failed to save league state: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return fmt.Errorf("%w: week %d was played by another request", ErrConflict, week)
+	}
+	if err != nil {
+		return fmt.Errorf("playoff week %d rolled back: %w", week, err)
+	}
+	return nil
+}
+
+// seasonChampion tamamlanmış sezonun şampiyonunu döndürür: şampiyonluk play-off'la belirlendiyse
+// play-off'un kazananı, değilse puan durumunun lideri.
+func seasonChampion(cupRepo repositories.CupRepository, season *models.Season, teams []models.Team, matches []models.Match, rules standings.Rules) (*int, error) {
+	if season.PlayoffCupID != nil && season.PlayoffPrize == models.PlayoffPrizeTitle {
+		cup, err := findCup(cupRepo, *season.PlayoffCupID)
+		if err != nil {
+			return nil, err
+		}
+		return cup.WinnerTeamID, nil
+	}
+	if ranked := rankSeason(season, teams, matches, 0, rules); len(ranked) > 0 {
+		return &ranked[0].ID, nil
+	}
+	return nil, nil
+}
+
+// promotedTeams sezon sonunda üst lige çıkan up takımı döndürür. Play-off üst lige çıkmayı belirliyorsa
+// ilk up-1 takım doğrudan, play-off'un kazananı da son takım olarak çıkar; aksi halde ilk up takım çıkar.
+func promotedTeams(cupRepo repositories.CupRepository, season *models.Season, ranking []models.Team, up int) ([]models.Team, error) {
+	if up == 0 || season.PlayoffCupID == nil || season.PlayoffPrize != models.PlayoffPrizePromotion {
+		return ranking[:up], nil
+	}
+	cup, err := findCup(cupRepo, *season.PlayoffCupID)
+	if err != nil {
+		return nil, err
+	}
+	if cup.WinnerTeamID == nil {
+		return ranking[:up], nil
+	}
+
+	promoted := slices.Clone(ranking[:up-1])
+	for _, team := range ranking[up-1:] {
+		if team.ID == *cup.WinnerTeamID {
+			promoted = append(promoted, team)
+			return promoted, nil
+		}
+	}
+	// Play-off'u kazanan takım doğrudan çıkanlar arasındaysa sıradaki takım çıkar.
+	return ranking[:up], nil
+}
+
+// playoffSimulator Monte Carlo tahminlerinde ligin play-off'unu simüle eder. Play-off başlamadıysa
+// katılımcılar her simülasyonun sezon sonu sıralamasından alınır; başladıysa kupanın güncel turundan
+// devam edilir. Takım indeksleri seasonSimulator'ınkilerdir.
+type playoffSimulator struct {
+	knockout   *knockoutSimulator
+	prize      models.PlayoffPrize
+	first      int // Play-off'a kalan ilk sıra (0'dan başlayarak); yalnızca kupa yoksa kullanılır
+	last       int // Play-off'a kalan son sıradan sonraki sıra
+	fromCup    bool
+	startRound int
+	startTies  []simTie
+	seedRank   []int
+	qualified  []bool // Kupa varsa play-off'a katılan takımlar
+}
+
+// newPlayoffSimulator sezonun play-off'u için bir simülatör hazırlar. Sezon play-off oynamayacaksa ya da
+// lig bölünmeyle oynuyorsa nil döner; bölünmeyi seasonSimulator oynatır.
+func newPlayoffSimulator(sim *seasonSimulator, league *models.LeagueState, season *models.Season, cup *models.Cup, ties []models.CupTie, engine MatchEngine) (*playoffSimulator, error) {
+	if cup == nil {
+		format := league.Playoffs
+		if format == nil || isSplit(format) || league.Status == models.LeagueStatusCompleted || format.FirstPlace >= len(sim.teams) {
+			return nil, nil
+		}
+		last := min(format.LastPlace, len(sim.teams))
+		return &playoffSimulator{
+			knockout: &knockoutSimulator{
+				teams:       sim.teams,
+				engine:      engine,
+				legs:        format.Legs,
+				draw:        models.CupDrawFixed,
+				totalRounds: cupRounds(last - format.FirstPlace + 1),
+			},
+			prize: format.Prize,
+			first: format.FirstPlace - 1,
+			last:  last,
+		}, nil
+	}
+
+	ps := &playoffSimulator{
+		knockout: &knockoutSimulator{
+			teams:       sim.teams,
+			engine:      engine,
+			legs:        cup.Legs,
+			draw:        cup.Draw,
+			totalRounds: cup.TotalRounds,
+		},
+		prize:      season.PlayoffPrize,
+		fromCup:    true,
+		startRound: cup.CurrentRound,
+		seedRank:   make([]int, len(sim.teams)),
+		qualified:  make([]bool, len(sim.teams)),
+	}
+	for rank, teamID := range cup.TeamIDs {
+		i, ok := sim.index[teamID]
+		if !ok {
+			return nil, fmt.Errorf("playoff cup %d references team %d outside the league", cup.ID, teamID)
+		}
+		ps.seedRank[i] = rank
+		ps.qualified[i] = true
+	}
+	for _, tie := range ties {
+		if tie.Round != cup.CurrentRound {
+			continue
+		}
+		st, err := newSimTie(tie, sim.index)
+		if err != nil {
+			return nil, err
+		}
+		ps.startTies = append(ps.startTies, st)
+	}
+	return ps, nil
+}
+
+// run play-off'u order sezon sonu sıralamasıyla bir kez oynatır ve kazananı döndürür. qualified
+// play-off'a katılan takımları işaretlemek için kullanılır.
+func (ps *playoffSimulator) run(rng *rand.Rand, st *knockoutState, order []int, qualified []bool) int {
+	if ps.fromCup {
+		copy(st.seedRank, ps.seedRank)
+		copy(qualified, ps.qualified)
+		st.ties = append(st.ties[:0], ps.startTies...)
+		return ps.knockout.run(rng, st, ps.startRound, nil)
+	}
+
+	clear(qualified)
+	entrants := order[ps.first:ps.last]
+	for rank, team := range entrants {
+		st.seedRank[team] = rank
+		qualified[team] = true
+	}
+	ps.knockout.drawRound(rng, st, 1, entrants)
+	return ps.knockout.run(rng, st, 1, nil)
+}
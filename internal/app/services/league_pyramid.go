@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// validate ligin piramitteki yerini doğrular. leagueID yeni oluşturulan bir lig için 0'dır.
+// Üst lig var olmalı, ligin kendisi ya da alt liglerinden biri olmamalı ve başka bir alt lige sahip olmamalıdır.
+func (t LeagueTier) validate(leagues []models.LeagueState, leagueID int) error {
+	if t.PromotionPlaces < 0 || t.RelegationPlaces < 0 {
+		return fmt.Errorf("%w: promotion and relegation places must not be negative", ErrInvalidLeague)
+	}
+	if t.ParentLeagueID == nil {
+		if t.PromotionPlaces > 0 {
+			return fmt.Errorf("%w: promotion places require a parent league", ErrInvalidLeague)
+		}
+		return nil
+	}
+
+	parentID := *t.ParentLeagueID
+	byID := make(map[int]models.LeagueState, len(leagues))
+	for _, l := range leagues {
+		byID[l.ID] = l
+	}
+	if _, ok := byID[parentID]; !ok {
+		return fmt.Errorf("%w: parent league %d does not exist", ErrInvalidLeague, parentID)
+	}
+	for id := parentID; ; {
+		if id == leagueID {
+			return fmt.Errorf("%w: league %d cannot be placed below itself", ErrInvalidLeague, leagueID)
+		}
+		parent := byID[id].ParentLeagueID
+		if parent == nil {
+			break
+		}
+		id = *parent
+	}
+	if child := childLeague(leagues, parentID); child != nil && child.ID != leagueID {
+		return fmt.Errorf("%w: league %d already has a lower division (league %d)", ErrInvalidLeague, parentID, child.ID)
+	}
+	return nil
+}
+
+// childLeague ligin alt ligini döndürür; alt lig yoksa nil döner.
+func childLeague(leagues []models.LeagueState, leagueID int) *models.LeagueState {
+	for i := range leagues {
+		if parent := leagues[i].ParentLeagueID; parent != nil && *parent == leagueID {
+			return &leagues[i]
+		}
+	}
+	return nil
+}
+
+// pyramid ligin bulunduğu piramidin liglerini en üst ligden en alt lige doğru döndürür.
+func pyramid(leagues []models.LeagueState, leagueID int) []models.LeagueState {
+	byID := make(map[int]models.LeagueState, len(leagues))
+	for _, l := range leagues {
+		byID[l.ID] = l
+	}
+	top, ok := byID[leagueID]
+	if !ok {
+		return nil
+	}
+	for top.ParentLeagueID != nil {
+		top = byID[*top.ParentLeagueID]
+	}
+
+	tiers := []models.LeagueState{top}
+	for child := childLeague(leagues, top.ID); child != nil; child = childLeague(leagues, child.ID) {
+		tiers = append(tiers, *child)
+	}
+	return tiers
+}
+
+// tierMoves piramidin i. liginden üst lige çıkacak ve alt lige düşecek takım sayılarını döndürür.
+// En üst ligden kimse çıkmaz, en alt ligden kimse düşmez.
+func tierMoves(tiers []models.LeagueState, i int) (up, down int) {
+	if i > 0 {
+		up = tiers[i].PromotionPlaces
+	}
+	if i+1 < len(tiers) {
+		down = tiers[i].RelegationPlaces
+	}
+	return up, down
+}
+
+// tierArrivals piramidin i. ligine üst ligden düşecek ve alt ligden çıkacak takımların sayısını döndürür.
+func tierArrivals(tiers []models.LeagueState, i int) int {
+	arrivals := 0
+	if i > 0 {
+		_, down := tierMoves(tiers, i-1)
+		arrivals += down
+	}
+	if i+1 < len(tiers) {
+		up, _ := tierMoves(tiers, i+1)
+		arrivals += up
+	}
+	return arrivals
+}
+
+// SetLeagueTier ligi bir üst lige bağlar (ya da bağlantısını kaldırır) ve lig değiştiren takım sayılarını ayarlar.
+// Yeni ayarlar bir sonraki sezon geçişinde uygulanır.
+func (s *leagueService) SetLeagueTier(leagueID int, tier LeagueTier) (*models.LeagueState, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.lockLeague(league.ID)()
+	defer s.predictions.invalidate()
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		leagues, err := repos.Leagues.GetAllLeagues()
+		if err != nil {
+			return err
+		}
+		if err := tier.validate(leagues, league.ID); err != nil {
+			return err
+		}
+
+		league, err = findLeague(repos.Leagues, league.ID)
+		if err != nil {
+			return err
+		}
+		league.ParentLeagueID = tier.ParentLeagueID
+		league.PromotionPlaces = tier.PromotionPlaces
+		league.RelegationPlaces = tier.RelegationPlaces
+		return repos.Leagues.UpdateLeague(league)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: league was changed while updating its tier", ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
+
+// RolloverSeason ligin bulunduğu piramidin tüm liglerinde sezonu kapatır: her alt ligin ilk PromotionPlaces
+// takımı (play-off üst lige çıkmayı belirliyorsa son takım yerine play-off'un kazananı) üst lige çıkar, her
+// üst ligin son RelegationPlaces takımı alt lige düşer, ardından her lig için yeni sezon ve fikstür
+// oluşturulur. Ligin sonraki kadrosu ayarlandıysa ResetLeague'deki gibi uygulanır; lige çıkan ya da düşen
+// takımlar kadroya eklenir, ligden ayrılanlar çıkarılır. Piramitteki tüm liglerin sezonu tamamlanmış
+// olmalıdır; aksi halde ErrSeasonNotCompleted döner. Tüm değişiklikler tek bir işlemde kaydedilir.
+func (s *leagueService) RolloverSeason(leagueID int) (*models.Rollover, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	leagues, err := s.leagueRepo.GetAllLeagues()
+	if err != nil {
+		return nil, err
+	}
+
+	// Piramidin liglerinin kilitleri, birbirini bekleyen isteklerin kilitlenmemesi için kimlik sırasıyla alınır.
+	ids := make([]int, 0, len(leagues))
+	for _, l := range pyramid(leagues, league.ID) {
+		ids = append(ids, l.ID)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		defer s.lockLeague(id)()
+	}
+	defer s.predictions.invalidate()
+
+	result := &models.Rollover{Moves: []models.TeamMove{}}
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		leagues, err := repos.Leagues.GetAllLeagues()
+		if err != nil {
+			return err
+		}
+		tiers := pyramid(leagues, league.ID)
+		tierIDs := make([]int, 0, len(tiers))
+		for _, tier := range tiers {
+			tierIDs = append(tierIDs, tier.ID)
+		}
+		slices.Sort(tierIDs)
+		if !slices.Equal(tierIDs, ids) {
+			return fmt.Errorf("%w: league pyramid was changed by another request", ErrConflict)
+		}
+
+		// Önce tüm liglerin final sıralaması alınır ve sezonlar arşivlenir; takımlar ancak ondan sonra taşınır.
+		rankings := make([][]models.Team, len(tiers))
+		seasons := make([]*models.Season, len(tiers))
+		for i := range tiers {
+			tier := &tiers[i]
+			if tier.Status != models.LeagueStatusCompleted {
+				return fmt.Errorf("%w: league %d (%s) is at week %d", ErrSeasonNotCompleted, tier.ID, tier.Name, tier.CurrentWeek)
+			}
+			rules, err := leagueRules(tier)
+			if err != nil {
+				return err
+			}
+			season, err := currentSeason(repos.Seasons, tier.ID)
+			if err != nil {
+				return err
+			}
+			teams, err := repos.Teams.GetTeamsByLeague(tier.ID)
+			if err != nil {
+				return err
+			}
+			matches, err := repos.Matches.GetMatchesBySeason(season.ID)
+			if err != nil {
+				return err
+			}
+			rankings[i] = rankSeason(season, teams, matches, 0, rules)
+			if err := archiveSeason(repos, season, tier, teams, matches, rules); err != nil {
+				return err
+			}
+			seasons[i] = season
+		}
+
+		for i := range tiers {
+			up, down := tierMoves(tiers, i)
+			size := len(rankings[i])
+			if up+down > size {
+				return fmt.Errorf("%w: league %d cannot promote %d and relegate %d of its %d teams", ErrInvalidLeague, tiers[i].ID, up, down, size)
+			}
+			if after := size - up - down + tierArrivals(tiers, i); after < 2 {
+				return fmt.Errorf("%w: league %d would have %d teams after the rollover", ErrInvalidLeague, tiers[i].ID, after)
+			}
+			promoted, err := promotedTeams(repos.Cups, seasons[i], rankings[i], up)
+			if err != nil {
+				return err
+			}
+			for _, team := range promoted {
+				result.Moves = append(result.Moves, models.TeamMove{TeamID: team.ID, TeamName: team.Name, FromLeagueID: tiers[i].ID, ToLeagueID: tiers[i-1].ID, Promoted: true})
+			}
+			for _, team := range rankings[i][size-down:] {
+				result.Moves = append(result.Moves, models.TeamMove{TeamID: team.ID, TeamName: team.Name, FromLeagueID: tiers[i].ID, ToLeagueID: tiers[i+1].ID})
+			}
+		}
+		for _, move := range result.Moves {
+			team, err := repos.Teams.GetTeamByID(move.TeamID)
+			if err != nil {
+				return err
+			}
+			team.LeagueID = move.ToLeagueID
+			if err := repos.Teams.UpdateTeam(team); err != nil {
+				return fmt.Errorf("failed to move team %d: %w", team.ID, err)
+			}
+		}
+
+		for i := range tiers {
+			tier := &tiers[i]
+			rules, err := leagueRules(tier)
+			if err != nil {
+				return err
+			}
+			teams, err := repos.Teams.GetTeamsByLeague(tier.ID)
+			if err != nil {
+				return err
+			}
+			tier.NextRoster = rolloverRoster(tier.NextRoster, tier.ID, result.Moves)
+			if teams, err = applyRoster(repos, tier, teams); err != nil {
+				return err
+			}
+			if len(teams) < 2 {
+				return fmt.Errorf("%w: league %d would have %d teams after the rollover", ErrInvalidLeague, tier.ID, len(teams))
+			}
+			season := &models.Season{LeagueID: tier.ID, Number: seasons[i].Number + 1, StartedAt: time.Now().UTC()}
+			if err := repos.Seasons.CreateSeason(season); err != nil {
+				return fmt.Errorf("failed to create season: %w", err)
+			}
+			if err := generateMatches(repos.Matches, tier, season, teams); err != nil {
+				return err
+			}
+
+			tier.CurrentWeek = 1
+			tier.Status = models.LeagueStatusNotStarted
+			if err := repos.Leagues.UpdateLeague(tier); err != nil {
+				return err
+			}
+			if err := s.refreshStandingsCache(repos, season, rules); err != nil {
+				return err
+			}
+			season.Status = tier.Status
+			result.Seasons = append(result.Seasons, *season)
+		}
+		return nil
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: a league was changed during the season rollover", ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
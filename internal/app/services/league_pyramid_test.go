@@ -0,0 +1,90 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRolloverAppliesNextRoster(t *testing.T) {
+	repos := newTestRepos()
+	svc := repos.leagueService(t, LeagueSettings{})
+	teamSvc := NewTeamService(repos.teams, repos.uow)
+
+	seed := int64(3)
+	top, err := svc.CreateLeague(NewLeague{Name: "Top", Seed: &seed, Tier: LeagueTier{RelegationPlaces: 1}, Teams: []NewTeam{
+		{Name: "T1", Strength: 90}, {Name: "T2", Strength: 85}, {Name: "T3", Strength: 80}, {Name: "T4", Strength: 75},
+	}})
+	if err != nil {
+		t.Fatalf("CreateLeague(top): %v", err)
+	}
+	bottom, err := svc.CreateLeague(NewLeague{Name: "Bottom", Seed: &seed, Tier: LeagueTier{ParentLeagueID: &top.ID, PromotionPlaces: 1}, Teams: []NewTeam{
+		{Name: "B1", Strength: 60}, {Name: "B2", Strength: 55}, {Name: "B3", Strength: 50}, {Name: "B4", Strength: 45},
+	}})
+	if err != nil {
+		t.Fatalf("CreateLeague(bottom): %v", err)
+	}
+	newcomer, err := teamSvc.CreateTeam(NewTeam{Name: "N1", Strength: 50})
+	if err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	bottomTeams, err := repos.teams.GetTeamsByLeague(bottom.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roster []int
+	for _, team := range bottomTeams {
+		roster = append(roster, team.ID)
+	}
+	// Alt ligin kadrosundan bir takım çıkarılır ve bağımsız bir takım eklenir.
+	slices.Sort(roster)
+	leaving := roster[len(roster)-1]
+	roster = append(roster[:len(roster)-1], newcomer.ID)
+	if _, err := svc.SetLeagueRoster(bottom.ID, roster); err != nil {
+		t.Fatalf("SetLeagueRoster: %v", err)
+	}
+
+	for _, id := range []int{top.ID, bottom.ID} {
+		if _, err := svc.SimulateAllWeeks(id, nil); err != nil {
+			t.Fatalf("SimulateAllWeeks(%d): %v", id, err)
+		}
+	}
+	rollover, err := svc.RolloverSeason(bottom.ID)
+	if err != nil {
+		t.Fatalf("RolloverSeason: %v", err)
+	}
+
+	want := map[int]int{leaving: 0, newcomer.ID: bottom.ID}
+	for _, move := range rollover.Moves {
+		want[move.TeamID] = move.ToLeagueID
+	}
+	if len(rollover.Moves) != 2 {
+		t.Fatalf("got %d moves, want one promotion and one relegation: %+v", len(rollover.Moves), rollover.Moves)
+	}
+	for teamID, leagueID := range want {
+		team, err := repos.teams.GetTeamByID(teamID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if team.LeagueID != leagueID {
+			t.Errorf("team %d plays in league %d after the rollover, want %d", teamID, team.LeagueID, leagueID)
+		}
+	}
+
+	for _, id := range []int{top.ID, bottom.ID} {
+		league, err := repos.leagues.GetLeagueByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if league.NextRoster != nil {
+			t.Errorf("league %d still has a next roster %v", id, league.NextRoster)
+		}
+		teams, err := repos.teams.GetTeamsByLeague(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(teams) != 4 {
+			t.Errorf("league %d has %d teams after the rollover, want 4", id, len(teams))
+		}
+	}
+}
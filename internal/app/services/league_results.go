@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// maxGoals elle girilebilecek en yüksek gol sayısıdır.
+const maxGoals = 99
+
+// SetMatchResult bir lig maçının skorunu elle kaydeder ya da oynanmış bir maçın skorunu düzeltir. Puan
+// durumu maçlardan hesaplandığından eski sonucun etkisi kendiliğinden geri alınır; materialize edilmiş
+// puan durumu aynı işlemde yeniden yazılır ve önbellekteki tahminler geçersiz kılınır. Henüz oynanmamış
+// bir maçın sonucu girilirse maç, haftası oynatılırken simüle edilmez.
+//
+// Yalnızca güncel sezonun normal sezon maçları değiştirilebilir. Arşivlenmiş sezonların ve play-off'u
+// başlamış sezonların sonuçları şampiyonu, yükselen takımları ya da play-off eşleşmelerini
+// belirlediğinden ErrResultLocked döner. Tablosu bölünmüş sezonlarda grupları belirleyen normal sezon
+// maçları da kilitlenir; bölünmenin kendi maçları değiştirilebilir.
+func (s *leagueService) SetMatchResult(matchID, homeGoals, awayGoals int) (*models.Match, error) {
+	if homeGoals < 0 || homeGoals > maxGoals || awayGoals < 0 || awayGoals > maxGoals {
+		return nil, fmt.Errorf("%w: goals must be between 0 and %d, got %d-%d", ErrInvalidResult, maxGoals, homeGoals, awayGoals)
+	}
+
+	match, err := findMatch(s.matchRepo, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.SeasonID == 0 {
+		return nil, fmt.Errorf("%w: match %d is not a league match", ErrInvalidResult, match.ID)
+	}
+	defer s.lockLeague(match.LeagueID)()
+	defer s.predictions.invalidate()
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		league, err := findLeague(repos.Leagues, match.LeagueID)
+		if err != nil {
+			return err
+		}
+		rules, err := leagueRules(league)
+		if err != nil {
+			return err
+		}
+		season, err := currentSeason(repos.Seasons, league.ID)
+		if err != nil {
+			return err
+		}
+		if match, err = findMatch(repos.Matches, match.ID); err != nil {
+			return err
+		}
+		if match.SeasonID != season.ID {
+			return fmt.Errorf("%w: match %d belongs to an archived season", ErrResultLocked, match.ID)
+		}
+		if season.PlayoffCupID != nil {
+			return fmt.Errorf("%w: the playoffs of season %d have started", ErrResultLocked, season.Number)
+		}
+		if season.Split != nil && match.Week < season.Split.Week {
+			return fmt.Errorf("%w: the table of season %d has been split", ErrResultLocked, season.Number)
+		}
+
+		match.HomeGoals, match.AwayGoals = homeGoals, awayGoals
+		match.Played = true
+		match.Seed = 0
+		if err := repos.Matches.UpdateMatch(match); err != nil {
+			return fmt.Errorf("failed to save match %d: %w", match.ID, err)
+		}
+		// Ligin sürümü artırılarak aynı anda oynatılan haftalarla çakışma tespit edilir.
+		if err := repos.Leagues.UpdateLeague(league); err != nil {
+			return fmt.Errorf("failed to save league state: %w", err)
+		}
+		return s.refreshStandingsCache(repos, season, rules)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: league was changed while saving the result of match %d", ErrConflict, matchID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// findMatch maçı döndürür; maç yoksa ErrMatchNotFound döner.
+func findMatch(matchRepo repositories.MatchRepository, id int) (*models.Match, error) {
+	match, err := matchRepo.GetMatchByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: %d", ErrMatchNotFound, id)
+	}
+	return match, nil
+}
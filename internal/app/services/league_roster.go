@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// SetLeagueRoster ligin bir sonraki sıfırlamadan ya da sezon geçişinden itibaren oynayacak takımlarını ayarlar; teamIDs nil ise
+// bekleyen kadro değişikliği iptal edilir. Güncel sezonun fikstürü ve puan durumu değişmez. Kadrodaki
+// takımlar bağımsız olmalı ya da zaten bu ligde oynamalıdır; başka bir ligin takımları ancak sezon geçişiyle
+// lig değiştirir.
+func (s *leagueService) SetLeagueRoster(leagueID int, teamIDs []int) (*models.LeagueState, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.lockLeague(league.ID)()
+
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		league, err = findLeague(repos.Leagues, league.ID)
+		if err != nil {
+			return err
+		}
+		if teamIDs != nil {
+			if err := validateRoster(repos, league, teamIDs); err != nil {
+				return err
+			}
+		}
+		league.NextRoster = slices.Clone(teamIDs)
+		return repos.Leagues.UpdateLeague(league)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: league was changed while updating its roster", ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
+
+// validateRoster kadronun takımlarını ligin kurallarına göre doğrular.
+func validateRoster(repos repositories.Repositories, league *models.LeagueState, teamIDs []int) error {
+	if len(teamIDs) < 2 {
+		return fmt.Errorf("%w: a roster needs at least 2 teams, got %d", ErrInvalidLeague, len(teamIDs))
+	}
+	leagues, err := repos.Leagues.GetAllLeagues()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[int]bool, len(teamIDs))
+	for _, id := range teamIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate team %d in roster", ErrInvalidLeague, id)
+		}
+		seen[id] = true
+
+		team, err := repos.Teams.GetTeamByID(id)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return fmt.Errorf("%w: team %d not found", ErrInvalidLeague, id)
+		}
+		if team.LeagueID != 0 && team.LeagueID != league.ID {
+			return fmt.Errorf("%w: team %d plays in league %d", ErrInvalidLeague, id, team.LeagueID)
+		}
+		if league.Swiss != nil && team.Pot < 1 {
+			return fmt.Errorf("%w: team %q needs a pot in a swiss league", ErrInvalidLeague, team.Name)
+		}
+		for _, other := range leagues {
+			if other.ID != league.ID && slices.Contains(other.NextRoster, id) {
+				return fmt.Errorf("%w: team %d is already in the next roster of league %d", ErrInvalidLeague, id, other.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// applyRoster ligin bekleyen kadrosunu uygular: kadroda olmayan takımlar ligden ayrılır, kadrodaki
+// bağımsız takımlar lige katılır. Kadro ayarlanmadıysa teams olduğu gibi döner. Lig kaydedilmez.
+func applyRoster(repos repositories.Repositories, league *models.LeagueState, teams []models.Team) ([]models.Team, error) {
+	if league.NextRoster == nil {
+		return teams, nil
+	}
+
+	for i := range teams {
+		team := &teams[i]
+		if slices.Contains(league.NextRoster, team.ID) {
+			continue
+		}
+		// Ligden ayrılan takımın materialize edilmiş sayaçları sıfırlanır.
+		team.LeagueID = 0
+		team.Points, team.GoalsFor, team.GoalsAgainst, team.MatchesPlayed = 0, 0, 0, 0
+		team.Wins, team.Draws, team.Loses = 0, 0, 0
+		if err := repos.Teams.UpdateTeam(team); err != nil {
+			return nil, fmt.Errorf("failed to remove team %d from league: %w", team.ID, err)
+		}
+	}
+	for _, id := range league.NextRoster {
+		team, err := findTeam(repos.Teams, id)
+		if err != nil {
+			return nil, err
+		}
+		if team.LeagueID == league.ID {
+			continue
+		}
+		// Kadro ayarlandıktan sonra takım sezon geçişiyle başka bir lige geçmiş olabilir.
+		if team.LeagueID != 0 {
+			return nil, fmt.Errorf("%w: team %d of the next roster now plays in league %d", ErrInvalidLeague, id, team.LeagueID)
+		}
+		team.LeagueID = league.ID
+		if err := repos.Teams.UpdateTeam(team); err != nil {
+			return nil, fmt.Errorf("failed to add team %d to league: %w", team.ID, err)
+		}
+	}
+	league.NextRoster = nil
+	return repos.Teams.GetTeamsByLeague(league.ID)
+}
+
+// rolloverRoster ligin bekleyen kadrosunu sezon geçişinin takım hareketlerine göre günceller: lige çıkan ya
+// da düşen takımlar kadroya eklenir, ligden ayrılanlar çıkarılır. Kadro ayarlanmadıysa nil döner.
+func rolloverRoster(roster []int, leagueID int, moves []models.TeamMove) []int {
+	if roster == nil {
+		return nil
+	}
+	roster = slices.Clone(roster)
+	for _, move := range moves {
+		switch {
+		case move.FromLeagueID == leagueID:
+			roster = slices.DeleteFunc(roster, func(id int) bool { return id == move.TeamID })
+		case move.ToLeagueID == leagueID && !slices.Contains(roster, move.TeamID):
+			roster = append(roster, move.TeamID)
+		}
+	}
+	return roster
+}
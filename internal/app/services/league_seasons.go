@@ -0,0 +1,247 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// currentSeason ligin oynanmakta olan sezonunu döndürür.
+func currentSeason(seasonRepo repositories.SeasonRepository, leagueID int) (*models.Season, error) {
+	season, err := seasonRepo.GetCurrentSeason(leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current season: %w", err)
+	}
+	if season == nil {
+		return nil, fmt.Errorf("league %d has no season", leagueID)
+	}
+	return season, nil
+}
+
+// findSeason ligin verilen numaralı sezonunu döndürür; number 0 ise güncel sezon döner.
+// Sezon yoksa ErrSeasonNotFound döner.
+func findSeason(seasonRepo repositories.SeasonRepository, leagueID, number int) (*models.Season, error) {
+	if number == 0 {
+		return currentSeason(seasonRepo, leagueID)
+	}
+	season, err := seasonRepo.GetSeason(leagueID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+	if season == nil {
+		return nil, fmt.Errorf("%w: season %d of league %d", ErrSeasonNotFound, number, leagueID)
+	}
+	return season, nil
+}
+
+// seasonRules sezonun sıralama kurallarını döndürür. Arşivlenmiş sezonlar kapandıkları andaki eşitlik
+// ölçütleriyle sıralanır; böylece ligin kuralları sonradan değişse de eski tablolar ve şampiyonlar değişmez.
+func seasonRules(season *models.Season, league *models.LeagueState) (standings.Rules, error) {
+	rules, err := leagueRules(league)
+	if err != nil || len(season.Tiebreakers) == 0 {
+		return rules, err
+	}
+	rules.Tiebreakers, err = standings.ParseTiebreakers(strings.Join(season.Tiebreakers, ","))
+	if err != nil {
+		return standings.Rules{}, fmt.Errorf("invalid tiebreakers for season %d: %w", season.Number, err)
+	}
+	return rules, nil
+}
+
+// seasonStatus sezonun durumunu belirler. Güncel sezonun durumu ligin durumudur; arşivlenmiş bir sezon
+// şampiyonu varsa tamamlanmış, yoksa yarıda bırakılmış sayılır.
+func seasonStatus(season *models.Season, league *models.LeagueState) models.LeagueStatus {
+	switch {
+	case season.EndedAt == nil:
+		return league.Status
+	case season.ChampionTeamID != nil:
+		return models.LeagueStatusCompleted
+	default:
+		return models.LeagueStatusAbandoned
+	}
+}
+
+// seasonTeams sezonun maçlarında yer alan takımları döndürür. Takımlar sezon geçişlerinde lig
+// değiştirebildiğinden geçmiş sezonların takımları ligin bugünkü takımlarından farklı olabilir.
+func seasonTeams(teamRepo repositories.TeamRepository, matches []models.Match) ([]models.Team, error) {
+	teams, err := teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	played := make(map[int]bool)
+	for _, match := range matches {
+		played[match.HomeTeamID] = true
+		played[match.AwayTeamID] = true
+	}
+	return slices.DeleteFunc(teams, func(team models.Team) bool { return !played[team.ID] }), nil
+}
+
+// lastPlayedWeek maçı oynanmış en son haftayı döndürür; hiç maç oynanmadıysa 0 döner.
+func lastPlayedWeek(matches []models.Match) int {
+	last := 0
+	for _, match := range matches {
+		if match.Played && match.Week > last {
+			last = match.Week
+		}
+	}
+	return last
+}
+
+// archiveSeason sezonu kapatır: kapanış zamanını ve geçerli eşitlik ölçütlerini kaydeder, sezon
+// tamamlandıysa şampiyonu da yazar.
+func archiveSeason(repos repositories.Repositories, season *models.Season, league *models.LeagueState, teams []models.Team, matches []models.Match, rules standings.Rules) error {
+	endedAt := time.Now().UTC()
+	season.EndedAt = &endedAt
+	season.Tiebreakers = tiebreakerNames(rules.Tiebreakers)
+	if league.Status == models.LeagueStatusCompleted {
+		champion, err := seasonChampion(repos.Cups, season, teams, matches, rules)
+		if err != nil {
+			return err
+		}
+		season.ChampionTeamID = champion
+	}
+	if err := repos.Seasons.UpdateSeason(season); err != nil {
+		return fmt.Errorf("failed to archive season %d: %w", season.Number, err)
+	}
+	return nil
+}
+
+// GetSeasons ligin tüm sezonlarını durumları ve şampiyonlarıyla birlikte döndürür.
+func (s *leagueService) GetSeasons(leagueID int) ([]models.Season, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.teamRepo.GetTeamsByLeague(league.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.leagueSeasons(league, teams)
+}
+
+// leagueSeasons ligin sezonlarının durumlarını ve şampiyonlarını doldurur. Güncel sezon tamamlandıysa,
+// henüz arşivlenmemiş olsa da şampiyonu puan durumundan (ya da şampiyonluk play-off'undan) belirlenir.
+func (s *leagueService) leagueSeasons(league *models.LeagueState, teams []models.Team) ([]models.Season, error) {
+	seasons, err := s.seasonRepo.GetSeasonsByLeague(league.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seasons: %w", err)
+	}
+	// Şampiyonlar sonradan başka bir lige geçmiş olabilir.
+	allTeams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(allTeams))
+	for _, team := range allTeams {
+		names[team.ID] = team.Name
+	}
+
+	for i := range seasons {
+		season := &seasons[i]
+		season.Status = seasonStatus(season, league)
+		if season.EndedAt == nil && season.Status == models.LeagueStatusCompleted {
+			rules, err := leagueRules(league)
+			if err != nil {
+				return nil, err
+			}
+			matches, err := s.matchRepo.GetMatchesBySeason(season.ID)
+			if err != nil {
+				return nil, err
+			}
+			if season.ChampionTeamID, err = seasonChampion(s.cupRepo, season, teams, matches, rules); err != nil {
+				return nil, err
+			}
+		}
+		if season.ChampionTeamID != nil {
+			season.ChampionName = names[*season.ChampionTeamID]
+		}
+	}
+	return seasons, nil
+}
+
+// GetAllTimeTable ligin tüm sezonlarındaki oynanmış maçlardan tarihsel puan durumunu hesaplar. Takımların
+// istatistikleri yalnızca bu ligde oynadıkları maçlardan hesaplanır. Sıralama
+// puana, ardından averaja ve atılan gole göre yapılır; ikili averaj gibi sezona özgü ölçütler kullanılmaz.
+func (s *leagueService) GetAllTimeTable(leagueID int) (*models.AllTimeTable, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.teamRepo.GetTeamsByLeague(league.ID)
+	if err != nil {
+		return nil, err
+	}
+	seasons, err := s.leagueSeasons(league, teams)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.matchRepo.GetMatchesByLeague(league.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Ligde oynamış ancak sonradan başka bir lige geçmiş takımlar da tabloda yer alır.
+	teams, err = seasonTeams(s.teamRepo, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[int]int)
+	for _, season := range seasons {
+		if season.ChampionTeamID != nil {
+			titles[*season.ChampionTeamID]++
+		}
+	}
+	seasonsPlayed := make(map[int]map[int]bool)
+	for _, match := range matches {
+		if !match.Played {
+			continue
+		}
+		for _, teamID := range []int{match.HomeTeamID, match.AwayTeamID} {
+			if seasonsPlayed[teamID] == nil {
+				seasonsPlayed[teamID] = make(map[int]bool)
+			}
+			seasonsPlayed[teamID][match.SeasonID] = true
+		}
+	}
+
+	results := playedResults(matches, 0)
+	rows := standingsRows(teams, results)
+	standings.Sort(rows, nil, standings.Rules{Tiebreakers: standings.DefaultTiebreakers})
+
+	names := make(map[int]string, len(teams))
+	for _, team := range teams {
+		names[team.ID] = team.Name
+	}
+	table := &models.AllTimeTable{
+		LeagueID: league.ID,
+		Name:     league.Name,
+		Seasons:  len(seasons),
+		Teams:    []models.AllTimeRow{},
+	}
+	for _, row := range rows {
+		// Yeni sezonda lige katılan ancak henüz maç oynamamış takımlar tabloda yer almaz.
+		if row.Played == 0 {
+			continue
+		}
+		table.Teams = append(table.Teams, models.AllTimeRow{
+			TeamID:         row.TeamID,
+			TeamName:       names[row.TeamID],
+			Seasons:        len(seasonsPlayed[row.TeamID]),
+			Titles:         titles[row.TeamID],
+			Played:         row.Played,
+			Wins:           row.Wins,
+			Draws:          row.Draws,
+			Losses:         row.Losses,
+			GoalsFor:       row.GoalsFor,
+			GoalsAgainst:   row.GoalsAgainst,
+			GoalDifference: row.GoalDifference(),
+			Points:         row.Points,
+		})
+	}
+	return table, nil
+}
@@ -1,83 +1,323 @@
 package services
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
 	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+	"github.com/muzaffertuna/football-league-sim/internal/app/scheduler"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
 )
 
+// leagueService ligin durumunu (güncel hafta, sezon durumu, kurallar, tohum) süreç içinde tutmaz;
+// her işlemde LeagueRepository'den okur ve durumu değiştiren işlemlerde maçlarla aynı işlemde yazar.
+//
+// Durumu değiştiren işlemler (hafta oynatma, sıfırlama) süreç içinde lig başına bir kilitle sıraya
+// sokulur; farklı ligler birbirini beklemez. Aynı veritabanını kullanan birden çok sunucu için ayrıca
+// ligin sürüm numarası denetlenir: okunduğundan beri başka bir istek ligi değiştirdiyse işlem geri
+// alınır ve ErrConflict döner.
 type leagueService struct {
-	matchRepo   repositories.MatchRepository
-	matchSvc    MatchService
-	teamRepo    repositories.TeamRepository
-	teamSvc     TeamService
-	currentWeek int // Ligin güncel haftasını tutacak alan
+	mu    sync.Mutex
+	locks map[int]*sync.Mutex // Lig kimliğine göre kilitler
+
+	matchRepo  repositories.MatchRepository
+	matchSvc   MatchService
+	teamRepo   repositories.TeamRepository
+	teamSvc    TeamService
+	leagueRepo repositories.LeagueRepository
+	seasonRepo repositories.SeasonRepository
+	cupRepo    repositories.CupRepository // Sezonların play-off kupaları
+	uow        repositories.UnitOfWork
+	defaults   LeagueSettings // Kural ya da tohum verilmeden oluşturulan liglerin varsayılanları
+	// materializeStandings true ise Teams tablosundaki sayaçlar (Points, Wins, GoalsFor vb.) her hafta
+	// sonunda maç sonuçlarından yeniden yazılır. Puan durumu her zaman maçlardan hesaplandığından bu
+	// sayaçlar yalnızca veritabanını doğrudan okuyanlar için tutulan bir önbellektir.
+	materializeStandings bool
+	predictions          *predictionCache
 }
 
-// NewLeagueService Constructor'ı güncellendi ve başlangıç haftası hesaplaması eklendi
-func NewLeagueService(matchRepo repositories.MatchRepository, matchSvc MatchService, teamRepo repositories.TeamRepository, teamSvc TeamService) (LeagueService, error) {
+// NewLeagueService lig servisini oluşturur ve kayıtlı ligleri başlangıç ayarlarıyla hazırlar.
+func NewLeagueService(matchRepo repositories.MatchRepository, matchSvc MatchService, teamRepo repositories.TeamRepository, teamSvc TeamService, leagueRepo repositories.LeagueRepository, seasonRepo repositories.SeasonRepository, cupRepo repositories.CupRepository, uow repositories.UnitOfWork, settings LeagueSettings) (LeagueService, error) {
 	ls := &leagueService{
-		matchRepo: matchRepo,
-		matchSvc:  matchSvc,
-		teamRepo:  teamRepo,
-		teamSvc:   teamSvc,
+		locks:       make(map[int]*sync.Mutex),
+		matchRepo:   matchRepo,
+		matchSvc:    matchSvc,
+		teamRepo:    teamRepo,
+		teamSvc:     teamSvc,
+		leagueRepo:  leagueRepo,
+		seasonRepo:  seasonRepo,
+		cupRepo:     cupRepo,
+		uow:         uow,
+		defaults:    settings,
+		predictions: newPredictionCache(),
+
+		materializeStandings: settings.MaterializeStandings,
 	}
 
-	err := ls.initializeCurrentWeek()
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize current week: %w", err)
+	if err := ls.initializeLeagues(settings); err != nil {
+		return nil, fmt.Errorf("failed to initialize leagues: %w", err)
 	}
 
 	return ls, nil
 }
 
-func (s *leagueService) initializeCurrentWeek() error {
-	maxPlayedWeek, err := s.matchRepo.GetMaxWeekPlayed()
-	if err != nil {
-		return err
-	}
+// initializeLeagues ayarlarda verilen tohum ve eşitlik ölçütlerini varsayılan ligin kayıtlı değerlerinin
+// yerine yazar. Önceki bir çalıştırmada yarıda kalan güncellemeler varsa tüm liglerin materialize edilmiş
+// puan durumu da maçlardan yeniden kurulur.
+func (s *leagueService) initializeLeagues(settings LeagueSettings) error {
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		leagues, err := repos.Leagues.GetAllLeagues()
+		if err != nil {
+			return err
+		}
 
-	// Eğer hiç maç oynanmamışsa, CurrentWeek 1'dir.
-	if maxPlayedWeek == 0 {
-		s.currentWeek = 1
+		for i := range leagues {
+			league := &leagues[i]
+			if i == 0 && (settings.Seed != nil || settings.Tiebreakers != nil) {
+				if settings.Seed != nil {
+					league.Seed = settings.Seed
+				}
+				if settings.Tiebreakers != nil {
+					league.Tiebreakers = tiebreakerNames(settings.Tiebreakers)
+				}
+				if err := repos.Leagues.UpdateLeague(league); err != nil {
+					return err
+				}
+			}
+
+			rules, err := leagueRules(league)
+			if err != nil {
+				return err
+			}
+			season, err := currentSeason(repos.Seasons, league.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.refreshStandingsCache(repos, season, rules); err != nil {
+				return err
+			}
+		}
 		return nil
+	})
+}
+
+// leagueStatus güncel haftaya ve sezonun uzunluğuna göre sezon durumunu belirler.
+func leagueStatus(currentWeek, totalWeeks int) models.LeagueStatus {
+	switch {
+	case totalWeeks > 0 && currentWeek > totalWeeks:
+		return models.LeagueStatusCompleted
+	case currentWeek > 1:
+		return models.LeagueStatusInProgress
+	default:
+		return models.LeagueStatusNotStarted
 	}
+}
 
-	matchesInMaxWeek, err := s.matchRepo.GetMatchesByWeek(maxPlayedWeek)
+// leagueRules ligin kayıtlı eşitlik ölçütlerinden sıralama kurallarını oluşturur. Puan tablosundaki
+// kura çekimi ligin tohumuna bağlıdır; böylece tablo her istekte aynı kalır.
+func leagueRules(league *models.LeagueState) (standings.Rules, error) {
+	tiebreakers, err := standings.ParseTiebreakers(strings.Join(league.Tiebreakers, ","))
 	if err != nil {
-		return err
+		return standings.Rules{}, fmt.Errorf("invalid tiebreakers for league %d: %w", league.ID, err)
+	}
+	rules := standings.Rules{Tiebreakers: tiebreakers}
+	if league.Seed != nil {
+		rules.LotSeed = *league.Seed
 	}
+	return rules, nil
+}
 
-	allPlayedInMaxWeek := true
-	for _, match := range matchesInMaxWeek {
-		if !match.Played {
-			allPlayedInMaxWeek = false
-			break
+// findLeague ligi okur; leagueID DefaultLeagueID ise varsayılan lig döner. Lig yoksa ErrLeagueNotFound döner.
+func findLeague(leagueRepo repositories.LeagueRepository, leagueID int) (*models.LeagueState, error) {
+	var (
+		league *models.LeagueState
+		err    error
+	)
+	if leagueID == DefaultLeagueID {
+		league, err = leagueRepo.GetDefaultLeague()
+	} else {
+		league, err = leagueRepo.GetLeagueByID(leagueID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+	if league == nil {
+		return nil, fmt.Errorf("%w: %d", ErrLeagueNotFound, leagueID)
+	}
+	return league, nil
+}
+
+// lockLeague ligin kilidini alır ve kilidi bırakan fonksiyonu döndürür.
+func (s *leagueService) lockLeague(leagueID int) func() {
+	s.mu.Lock()
+	lock, ok := s.locks[leagueID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[leagueID] = lock
+	}
+	s.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// CreateLeague yeni bir lig ve takımlarını oluşturur, çift devreli fikstürü hazırlar. Kural ya da tohum
+// verilmezse sunucunun varsayılanları kullanılır.
+func (s *leagueService) CreateLeague(input NewLeague) (*models.LeagueState, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	league := &models.LeagueState{
+		Name:             strings.TrimSpace(input.Name),
+		CurrentWeek:      1,
+		Status:           models.LeagueStatusNotStarted,
+		Tiebreakers:      tiebreakerNames(input.Tiebreakers),
+		Seed:             input.Seed,
+		ParentLeagueID:   input.Tier.ParentLeagueID,
+		PromotionPlaces:  input.Tier.PromotionPlaces,
+		RelegationPlaces: input.Tier.RelegationPlaces,
+		Playoffs:         input.Playoffs,
+		Swiss:            input.Swiss,
+		MatchEngine:      input.MatchEngine,
+	}
+	if input.Tiebreakers == nil {
+		league.Tiebreakers = tiebreakerNames(s.defaults.Tiebreakers)
+		if input.Swiss != nil {
+			league.Tiebreakers = tiebreakerNames(standings.SwissTiebreakers)
 		}
 	}
+	if league.Seed == nil {
+		league.Seed = s.defaults.Seed
+	}
 
-	if allPlayedInMaxWeek {
-		s.currentWeek = maxPlayedWeek + 1
-	} else {
-		s.currentWeek = maxPlayedWeek
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		leagues, err := repos.Leagues.GetAllLeagues()
+		if err != nil {
+			return err
+		}
+		if err := input.Tier.validate(leagues, 0); err != nil {
+			return err
+		}
+		if league.Playoffs != nil {
+			if err := validatePlayoffs(league.Playoffs, league, len(input.Teams)); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Leagues.CreateLeague(league); err != nil {
+			return fmt.Errorf("failed to create league: %w", err)
+		}
+		season := &models.Season{LeagueID: league.ID, Number: 1, StartedAt: time.Now().UTC()}
+		if err := repos.Seasons.CreateSeason(season); err != nil {
+			return fmt.Errorf("failed to create season: %w", err)
+		}
+
+		teams := make([]models.Team, len(input.Teams))
+		for i, t := range input.Teams {
+			teams[i] = models.Team{LeagueID: league.ID}
+			t.apply(&teams[i])
+			err := repos.Teams.CreateTeam(&teams[i])
+			if errors.Is(err, repositories.ErrDuplicateName) {
+				return fmt.Errorf("%w: team name %q is already used", ErrInvalidLeague, teams[i].Name)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create team %q: %w", t.Name, err)
+			}
+		}
+
+		return generateMatches(repos.Matches, league, season, teams)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return league, nil
 }
 
-func (s *leagueService) GetCurrentWeek() (int, error) {
-	return s.currentWeek, nil
+// GetLeagues tüm ligleri kimlik sırasıyla döndürür.
+func (s *leagueService) GetLeagues() ([]models.LeagueState, error) {
+	return s.leagueRepo.GetAllLeagues()
+}
+
+func (s *leagueService) GetCurrentWeek(leagueID int) (int, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return 0, err
+	}
+	return league.CurrentWeek, nil
 }
 
-func (s *leagueService) PlayWeek(week int) error {
-	if week != s.currentWeek {
-		return fmt.Errorf("it's not week %d, current week is %d", week, s.currentWeek)
+// GetTotalWeeks sezonun uzunluğunu ligin kayıtlı fikstüründen hesaplar.
+func (s *leagueService) GetTotalWeeks(leagueID int) (int, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return 0, err
+	}
+	season, err := currentSeason(s.seasonRepo, league.ID)
+	if err != nil {
+		return 0, err
 	}
+	return s.matchRepo.GetTotalWeeks(season.ID)
+}
 
-	matches, err := s.matchRepo.GetMatchesByWeek(week)
+// PlayWeek haftanın maçlarını simüle eder. Haftanın tohumu istek tohumundan (yoksa ligin tohumundan)
+// hafta numarasıyla türetilir; her maçın tohumu da haftanın tohumundan ve takım kimliklerinden türetilir.
+// Böylece aynı tohumla oynatılan bir sezon, haftalar tek tek ya da topluca oynansa da aynı sonuçları verir.
+// Lig play-off oynuyorsa normal sezonun son haftasıyla play-off başlar ve sonraki her hafta bir tur oynanır.
+func (s *leagueService) PlayWeek(leagueID, week int, seed *int64) error {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return err
+	}
+	defer s.lockLeague(league.ID)()
+
+	return s.playWeek(league.ID, week, seed)
+}
+
+// playWeek PlayWeek'in lig kilidi alınmış halde çağrılan gövdesidir.
+func (s *leagueService) playWeek(leagueID, week int, seed *int64) error {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return err
+	}
+	rules, err := leagueRules(league)
+	if err != nil {
+		return err
+	}
+
+	season, err := currentSeason(s.seasonRepo, league.ID)
+	if err != nil {
+		return err
+	}
+
+	totalWeeks, err := s.matchRepo.GetTotalWeeks(season.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get total weeks: %w", err)
+	}
+	if league.Status == models.LeagueStatusCompleted || (totalWeeks > 0 && week > totalWeeks && season.PlayoffCupID == nil) {
+		return fmt.Errorf("%w. current week: %d", ErrLeagueCompleted, league.CurrentWeek)
+	}
+
+	if week != league.CurrentWeek {
+		return fmt.Errorf("%w: it's not week %d, current week is %d", ErrWeekMismatch, week, league.CurrentWeek)
+	}
+
+	// Normal sezondan sonraki haftalarda play-off turları oynanır.
+	if week > totalWeeks {
+		return s.playPlayoffWeek(league, season, week, seed)
+	}
+
+	matches, err := s.matchRepo.GetMatchesByWeek(season.ID, week)
 	if err != nil {
 		return err
 	}
@@ -86,174 +326,347 @@ func (s *leagueService) PlayWeek(week int) error {
 		return fmt.Errorf("no matches found for week %d", week)
 	}
 
-	for i := range matches {
-		match := &matches[i]
+	engine, err := s.leagueEngine(league)
+	if err != nil {
+		return err
+	}
+	matchSvc := s.matchSvc.WithEngine(engine)
+
+	weekSeed := deriveSeed(resolveSeed(seed, league.Seed), int64(week))
+	// Hafta geri alınsa bile önbellekteki tahminlerin yeniden hesaplanması zararsızdır.
+	defer s.predictions.invalidate()
+
+	// Haftanın tüm maçları, ligin yeni durumu ve materialize edilmiş puan durumu tek bir işlemde
+	// kaydedilir; herhangi bir adım başarısız olursa hafta hiç oynanmamış gibi geri alınır.
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		for i := range matches {
+			match := &matches[i]
+
+			if match.Played {
+				continue
+			}
+
+			homeTeam, err := repos.Teams.GetTeamByID(match.HomeTeamID)
+			if err != nil {
+				return err
+			}
+			awayTeam, err := repos.Teams.GetTeamByID(match.AwayTeamID)
+			if err != nil {
+				return err
+			}
+			if homeTeam == nil || awayTeam == nil {
+				return fmt.Errorf("match %d references unknown team", match.ID)
+			}
 
-		if match.Played {
-			continue
+			matchSeed := deriveSeed(weekSeed, int64(match.HomeTeamID), int64(match.AwayTeamID))
+			if err := matchSvc.SimulateMatch(match, homeTeam, awayTeam, matchSeed); err != nil {
+				return err
+			}
+			if err := repos.Matches.UpdateMatch(match); err != nil {
+				return fmt.Errorf("failed to save match %d: %w", match.ID, err)
+			}
 		}
 
-		homeTeam, err := s.teamSvc.GetTeamByID(match.HomeTeamID)
-		if err != nil {
-			return err
+		league.CurrentWeek = week + 1
+		league.Status = leagueStatus(league.CurrentWeek, totalWeeks)
+		// Bölünmenin son haftası oynandığında sezon tamamlanır; tablo ikinci kez bölünmez.
+		if league.Status == models.LeagueStatusCompleted && league.Playoffs != nil && season.Split == nil {
+			if err := startPlayoffs(repos, league, season, rules, weekSeed); err != nil {
+				return err
+			}
 		}
-		awayTeam, err := s.teamSvc.GetTeamByID(match.AwayTeamID)
-		if err != nil {
-			return err
+		if err := repos.Leagues.UpdateLeague(league); err != nil {
+			return fmt.Errorf("failed to save league state: %w", err)
 		}
 
-		if err := s.matchSvc.SimulateMatch(match, homeTeam, awayTeam); err != nil {
-			return err
-		}
+		return s.refreshStandingsCache(repos, season, rules)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return fmt.Errorf("%w: week %d was played by another request", ErrConflict, week)
+	}
+	if err != nil {
+		return fmt.Errorf("week %d rolled back: %w", week, err)
 	}
-
-	s.currentWeek = week + 1
 	return nil
 }
 
-func (s *leagueService) SimulateAllWeeks() ([]models.Match, error) {
-	var allSimulatedMatches []models.Match
-	const totalWeeks = 6
+// SimulateAllWeeks kalan haftaları ve varsa play-off'u sonuna kadar oynatır ve bu çağrıda oynanan maçları döndürür.
+func (s *leagueService) SimulateAllWeeks(leagueID int, seed *int64) ([]models.Match, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.lockLeague(league.ID)()
 
-	currentWeek, err := s.GetCurrentWeek()
+	// Kilit alınana kadar başka bir istek haftaları oynatmış olabileceğinden lig yeniden okunur.
+	league, err = findLeague(s.leagueRepo, league.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current week: %w", err)
+		return nil, err
 	}
 
-	if currentWeek > totalWeeks {
-		allMatches, err := s.matchRepo.GetAllMatches()
+	season, err := currentSeason(s.seasonRepo, league.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var allSimulatedMatches []models.Match
+
+	if league.Status == models.LeagueStatusCompleted {
+		allMatches, err := s.matchRepo.GetMatchesBySeason(season.ID)
 		if err != nil {
 			return nil, err
 		}
-		return allMatches, fmt.Errorf("league has already completed. current week: %d", currentWeek)
+		return allMatches, fmt.Errorf("%w. current week: %d", ErrLeagueCompleted, league.CurrentWeek)
 	}
 
-	for week := currentWeek; week <= totalWeeks; week++ {
-		err := s.PlayWeek(week)
+	// Bu çağrıdan önce oynanmış play-off maçları sonuca katılmaz.
+	playedBefore, err := s.playoffMatches(season, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// İstekte tohum yoksa tüm haftalar aynı ana tohumu paylaşır; kaydedilen maç tohumlarıyla sezon tekrar üretilebilir.
+	baseSeed := resolveSeed(seed, league.Seed)
+	for week := league.CurrentWeek; league.Status != models.LeagueStatusCompleted; week++ {
+		err := s.playWeek(league.ID, week, &baseSeed)
 		if err != nil {
 			return nil, fmt.Errorf("failed to play week %d: %w", week, err)
 		}
 
-		playedMatches, err := s.matchRepo.GetMatchesByWeek(week)
+		// Play-off haftalarının maçları kupaya ait olduğundan burada boş döner; bölünmenin maçları ise sezonun fikstüründedir.
+		playedMatches, err := s.matchRepo.GetMatchesByWeek(season.ID, week)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get matches for played week %d: %w", week, err)
 		}
 		allSimulatedMatches = append(allSimulatedMatches, playedMatches...)
 
+		if league, err = findLeague(s.leagueRepo, league.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Play-off normal sezonun son haftasında başladığından sezon yeniden okunur.
+	if season, err = currentSeason(s.seasonRepo, league.ID); err != nil {
+		return nil, err
+	}
+	playoffMatches, err := s.playoffMatches(season, playedBefore)
+	if err != nil {
+		return nil, err
 	}
+	return append(allSimulatedMatches, playoffMatches...), nil
+}
 
-	return allSimulatedMatches, nil
+// playoffMatches sezonun play-off'unda oynanmış, exclude'da olmayan maçları oynanma sırasıyla döndürür.
+func (s *leagueService) playoffMatches(season *models.Season, exclude []models.Match) ([]models.Match, error) {
+	if season.PlayoffCupID == nil {
+		return nil, nil
+	}
+	matches, err := s.matchRepo.GetMatchesByCup(*season.PlayoffCupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playoff matches: %w", err)
+	}
+	matches = slices.DeleteFunc(matches, func(m models.Match) bool {
+		return !m.Played || slices.ContainsFunc(exclude, func(e models.Match) bool { return e.ID == m.ID })
+	})
+	slices.SortFunc(matches, func(a, b models.Match) int {
+		if c := cmp.Compare(a.Week, b.Week); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return matches, nil
 }
 
-func (s *leagueService) GetLeagueTable() (*models.League, error) {
-	fmt.Println("GetLeagueTable: Starting...")
-	teams, err := s.teamSvc.GetAllTeams()
+// GetLeagueTable puan durumunu döndürür. Takım istatistikleri her zaman kayıtlı maç sonuçlarından
+// hesaplanır; opts.Season verilirse arşivlenmiş bir sezonun tablosu, opts.AsOfWeek verilirse tablo o haftanın
+// sonundaki haliyle kurulur. Monte Carlo şampiyonluk tahminleri maliyetli olduğundan yalnızca
+// opts.IncludePredictions true ise ve yalnızca güncel sezon için hesaplanır (ve önbellekten sunulabilir).
+func (s *leagueService) GetLeagueTable(ctx context.Context, leagueID int, opts LeagueTableOptions) (*models.League, error) {
+	state, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	season, err := findSeason(s.seasonRepo, state.ID, opts.Season)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := seasonRules(season, state)
 	if err != nil {
-		fmt.Printf("GetLeagueTable: Error getting all teams: %v\n", err)
 		return nil, err
 	}
-	fmt.Println("GetLeagueTable: Teams retrieved.")
 
-	allMatches, err := s.matchRepo.GetAllMatches()
+	allMatches, err := s.matchRepo.GetMatchesBySeason(season.ID)
 	if err != nil {
-		fmt.Printf("GetLeagueTable: Error getting all matches: %v\n", err)
 		return nil, err
 	}
-	fmt.Println("GetLeagueTable: Matches retrieved.")
 
-	sort.Slice(teams, func(i, j int) bool {
-		if teams[i].Points != teams[j].Points {
-			return teams[i].Points > teams[j].Points
-		}
-		if teams[i].GoalDifference() != teams[j].GoalDifference() {
-			return teams[i].GoalDifference() > teams[j].GoalDifference()
-		}
-		return teams[i].GoalsFor > teams[j].GoalsFor
-	})
-	fmt.Println("GetLeagueTable: Teams sorted.")
+	current := season.EndedAt == nil
+	var teams []models.Team
+	if current {
+		teams, err = s.teamRepo.GetTeamsByLeague(state.ID)
+	} else {
+		teams, err = seasonTeams(s.teamRepo, allMatches)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	const numSimulationsForTable = 1000
-	fmt.Printf("GetLeagueTable: Calling PredictOutcomes with %d simulations.\n", numSimulationsForTable)
-	predictionResult, err := s.PredictOutcomes(numSimulationsForTable)
+	totalWeeks, err := s.matchRepo.GetTotalWeeks(season.ID)
 	if err != nil {
-		fmt.Printf("GetLeagueTable: Error predicting outcomes: %v\n", err)
-		return nil, fmt.Errorf("failed to predict outcomes for league table: %w", err)
+		return nil, err
 	}
-	fmt.Println("GetLeagueTable: PredictOutcomes completed successfully.")
 
 	league := &models.League{
-		Teams:                   teams,
-		Matches:                 allMatches,
-		CurrentWeek:             s.currentWeek,
-		ChampionshipPredictions: predictionResult.ChampionshipPredictions,
+		ID:          state.ID,
+		Name:        state.Name,
+		Season:      season.Number,
+		Teams:       rankSeason(season, teams, allMatches, opts.AsOfWeek, rules),
+		Matches:     allMatches,
+		Status:      seasonStatus(season, state),
+		CurrentWeek: state.CurrentWeek,
+		TotalWeeks:  totalWeeks,
+		MatchEngine: s.leagueEngineName(state),
+		Seed:        state.Seed,
+		Tiebreakers: tiebreakerNames(rules.Tiebreakers),
+		AsOfWeek:    opts.AsOfWeek,
+		Split:       season.Split,
+	}
+	if !current {
+		league.CurrentWeek = lastPlayedWeek(allMatches) + 1
+	}
+	if season.PlayoffCupID != nil {
+		cup, err := findCup(s.cupRepo, *season.PlayoffCupID)
+		if err != nil {
+			return nil, err
+		}
+		repos := repositories.Repositories{Teams: s.teamRepo, Matches: s.matchRepo, Cups: s.cupRepo}
+		if league.Playoffs, err = cupBracket(repos, cup); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.IncludePredictions && current {
+		const numSimulationsForTable = 1000
+		predictionResult, err := s.PredictOutcomes(ctx, state.ID, PredictionOptions{Simulations: numSimulationsForTable, Seed: opts.Seed})
+		if err != nil {
+			return nil, fmt.Errorf("failed to predict outcomes for league table: %w", err)
+		}
+		league.ChampionshipPredictions = predictionResult.ChampionshipPredictions
 	}
-	fmt.Println("GetLeagueTable: League table constructed. Returning.")
 	return league, nil
 }
 
-func (s *leagueService) ResetLeague() error {
-	teams, err := s.teamSvc.GetAllTeams()
+// ResetLeague yeni bir sezon başlatır. Güncel sezonda oynanmış maç varsa sezon arşivlenir (tamamlandıysa
+// şampiyonu kaydedilir) ve yeni sezon için fikstür oluşturulur; önceki sezonların maçları silinmez.
+// Hiç maç oynanmadıysa güncel sezonun fikstürü yeniden oluşturulur. Ligin sonraki kadrosu ayarlandıysa yeni
+// fikstür bu kadroyla oluşturulur. Puan durumu maçlardan hesaplandığından
+// takım sayaçlarının ayrıca sıfırlanması gerekmez; materialize edilmişse önbellek yeniden yazılır.
+func (s *leagueService) ResetLeague(leagueID int) error {
+	league, err := findLeague(s.leagueRepo, leagueID)
 	if err != nil {
 		return err
 	}
+	defer s.lockLeague(league.ID)()
+	defer s.predictions.invalidate()
 
-	for _, team := range teams {
-		team.Points = 0
-		team.GoalsFor = 0
-		team.GoalsAgainst = 0
-		team.MatchesPlayed = 0
-		team.Wins = 0
-		team.Draws = 0
-		team.Loses = 0
-		if err := s.teamRepo.UpdateTeam(&team); err != nil {
+	err = s.uow.Do(func(repos repositories.Repositories) error {
+		league, err := findLeague(repos.Leagues, league.ID)
+		if err != nil {
 			return err
 		}
+		rules, err := leagueRules(league)
+		if err != nil {
+			return err
+		}
+
+		teams, err := repos.Teams.GetTeamsByLeague(league.ID)
+		if err != nil {
+			return err
+		}
+
+		season, err := currentSeason(repos.Seasons, league.ID)
+		if err != nil {
+			return err
+		}
+		matches, err := repos.Matches.GetMatchesBySeason(season.ID)
+		if err != nil {
+			return err
+		}
+
+		if slices.ContainsFunc(matches, func(m models.Match) bool { return m.Played }) {
+			if err := archiveSeason(repos, season, league, teams, matches, rules); err != nil {
+				return err
+			}
+			season = &models.Season{LeagueID: league.ID, Number: season.Number + 1, StartedAt: time.Now().UTC()}
+			if err := repos.Seasons.CreateSeason(season); err != nil {
+				return fmt.Errorf("failed to create season: %w", err)
+			}
+		} else if err := repos.Matches.DeleteMatchesBySeason(season.ID); err != nil {
+			return err
+		}
+
+		if teams, err = applyRoster(repos, league, teams); err != nil {
+			return err
+		}
+		if err := generateMatches(repos.Matches, league, season, teams); err != nil {
+			return err
+		}
+
+		league.CurrentWeek = 1
+		league.Status = models.LeagueStatusNotStarted
+		if err := repos.Leagues.UpdateLeague(league); err != nil {
+			return err
+		}
+
+		return s.refreshStandingsCache(repos, season, rules)
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return fmt.Errorf("%w: league was changed while resetting", ErrConflict)
 	}
+	return err
+}
 
-	if err := s.matchRepo.DeleteAllMatches(); err != nil {
-		return err
+// refreshStandingsCache ligin materialize edilmiş takım sayaçlarını güncel sezonun maç sonuçlarından yeniden yazar.
+// Sayaçlar artırılmak yerine her seferinde baştan hesaplanır ve maçlarla aynı işlemde kaydedilir.
+func (s *leagueService) refreshStandingsCache(repos repositories.Repositories, season *models.Season, rules standings.Rules) error {
+	if !s.materializeStandings {
+		return nil
 	}
 
-	if err := s.generateMatches(teams); err != nil {
+	teams, err := repos.Teams.GetTeamsByLeague(season.LeagueID)
+	if err != nil {
+		return err
+	}
+	matches, err := repos.Matches.GetMatchesBySeason(season.ID)
+	if err != nil {
 		return err
 	}
 
-	s.currentWeek = 1
+	for _, team := range rankTeams(teams, matches, 0, rules) {
+		if err := repos.Teams.UpdateTeam(&team); err != nil {
+			return fmt.Errorf("failed to update standings of team %d: %w", team.ID, err)
+		}
+	}
 	return nil
 }
 
-func (s *leagueService) generateMatches(teams []models.Team) error {
-	if len(teams) != 4 {
-		return fmt.Errorf("expected 4 teams, got %d", len(teams))
-	}
-
-	matches := []struct {
-		homeTeamID int
-		awayTeamID int
-		week       int
-	}{
-		{teams[0].ID, teams[1].ID, 1},
-		{teams[2].ID, teams[3].ID, 1},
-		{teams[0].ID, teams[2].ID, 2},
-		{teams[1].ID, teams[3].ID, 2},
-		{teams[0].ID, teams[3].ID, 3},
-		{teams[1].ID, teams[2].ID, 3},
-		{teams[1].ID, teams[0].ID, 4},
-		{teams[3].ID, teams[2].ID, 4},
-		{teams[2].ID, teams[0].ID, 5},
-		{teams[3].ID, teams[1].ID, 5},
-		{teams[3].ID, teams[0].ID, 6},
-		{teams[2].ID, teams[1].ID, 6},
-	}
-
-	for _, m := range matches {
+// generateMatches ligin takımları için sezonun fikstürünü oluşturur ve maçları kaydeder.
+func generateMatches(matchRepo repositories.MatchRepository, league *models.LeagueState, season *models.Season, teams []models.Team) error {
+	fixtures, err := seasonFixture(league, season, teams)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fixtures {
 		match := &models.Match{
-			HomeTeamID: m.homeTeamID,
-			AwayTeamID: m.awayTeamID,
-			Week:       m.week,
+			LeagueID:   season.LeagueID,
+			SeasonID:   season.ID,
+			HomeTeamID: f.HomeTeamID,
+			AwayTeamID: f.AwayTeamID,
+			Week:       f.Week,
 			Played:     false,
 		}
-		if err := s.matchRepo.CreateMatch(match); err != nil {
+		if err := matchRepo.CreateMatch(match); err != nil {
 			return err
 		}
 	}
@@ -261,208 +674,343 @@ func (s *leagueService) generateMatches(teams []models.Team) error {
 	return nil
 }
 
-func (s *leagueService) GetMatchesByWeek(week int) ([]models.Match, error) {
-	return s.matchRepo.GetMatchesByWeek(week)
+// seasonFixture ligin biçimine göre çift devreli ya da İsviçre usulü fikstürü üretir.
+func seasonFixture(league *models.LeagueState, season *models.Season, teams []models.Team) ([]scheduler.Fixture, error) {
+	if league.Swiss != nil {
+		return swissFixture(league, season, teams)
+	}
+
+	teamIDs := make([]int, len(teams))
+	for i, team := range teams {
+		teamIDs[i] = team.ID
+	}
+	// Aynı takımlarla her sıfırlamada aynı fikstürün (ve aynı tohumla aynı sezonun) üretilmesi için sıralanır.
+	sort.Ints(teamIDs)
+
+	fixtures, err := scheduler.DoubleRoundRobin(teamIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate fixture: %w", err)
+	}
+	return fixtures, nil
+}
+
+func (s *leagueService) GetMatchesByWeek(leagueID, week int) ([]models.Match, error) {
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	season, err := currentSeason(s.seasonRepo, league.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.matchRepo.GetMatchesByWeek(season.ID, week)
 }
 
 func (s *leagueService) GetTeamByID(id int) (*models.Team, error) {
 	return s.teamSvc.GetTeamByID(id)
 }
 
-func calculatePoints(homeGoals, awayGoals int) int {
-	if homeGoals > awayGoals {
-		return 3 // Galibiyet
-	} else if homeGoals == awayGoals {
-		return 1 // Beraberlik
-	} else {
-		return 0 // Mağlubiyet
+func tiebreakerNames(tiebreakers []standings.Tiebreaker) []string {
+	names := make([]string, len(tiebreakers))
+	for i, t := range tiebreakers {
+		names[i] = string(t)
 	}
+	return names
 }
 
-func (s *leagueService) PredictOutcomes(numSimulations int) (models.PredictionResult, error) {
-	fmt.Printf("PredictOutcomes: Starting %d simulations...\n", numSimulations)
-	startTime := time.Now() // Debug için zaman tutucu
+// PredictOutcomes ligin kalan maçlarını opts.Simulations kez simüle eder ve her simülasyonun
+// tam sıralamasını kaydeder. Sonuçta her takımın her sırada bitirme olasılığı ile bunlardan türetilen
+// şampiyonluk, ilk N, küme düşme ve beklenen puan değerleri döner. Lig play-off oynuyorsa play-off da
+// her simülasyonda oynatılır; şampiyonluk ya da üst lige çıkma olasılıkları play-off'un sonucunu içerir.
+// Tablo bölünecekse bölünmenin maçları da oynatılır ve olasılıklar bölünmeden sonraki sıralamadan hesaplanır.
+//
+// Simülasyonlar GOMAXPROCS boyutunda sınırlı bir işçi havuzunda, depo kopyaları yerine dizilerle
+// çalışan seasonSimulator ile yapılır. Her simülasyonun tohumu ana tohumdan simülasyon sırasıyla
+// türetildiğinden sonuç, işçi sayısından ve zamanlamadan bağımsız olarak aynı tohumla aynıdır.
+// ctx iptal edildiğinde (örneğin istemci bağlantıyı kapattığında) işçiler durur ve hata döner.
+func (s *leagueService) PredictOutcomes(ctx context.Context, leagueID int, opts PredictionOptions) (models.PredictionResult, error) {
+	numSimulations := opts.Simulations
+	if numSimulations <= 0 {
+		return models.PredictionResult{}, fmt.Errorf("number of simulations must be positive, got %d", numSimulations)
+	}
+
+	league, err := findLeague(s.leagueRepo, leagueID)
+	if err != nil {
+		return models.PredictionResult{}, err
+	}
+	rules, err := leagueRules(league)
+	if err != nil {
+		return models.PredictionResult{}, err
+	}
 
-	initialTeams, err := s.teamRepo.GetAllTeams()
+	teams, err := s.teamRepo.GetTeamsByLeague(league.ID)
 	if err != nil {
-		fmt.Printf("PredictOutcomes: Failed to get initial teams: %v\n", err)
 		return models.PredictionResult{}, fmt.Errorf("failed to get initial teams for prediction: %w", err)
 	}
-	initialMatches, err := s.matchRepo.GetAllMatches()
+	season, err := currentSeason(s.seasonRepo, league.ID)
+	if err != nil {
+		return models.PredictionResult{}, err
+	}
+	matches, err := s.matchRepo.GetMatchesBySeason(season.ID)
 	if err != nil {
-		fmt.Printf("PredictOutcomes: Failed to get initial matches: %v\n", err)
 		return models.PredictionResult{}, fmt.Errorf("failed to get initial matches for prediction: %w", err)
 	}
-	initialCurrentWeek := s.currentWeek
-	fmt.Printf("PredictOutcomes: Initial league state captured (current week: %d).\n", initialCurrentWeek)
 
-	championshipCounts := &sync.Map{}
+	// Play-off başladıysa kupanın eşleşmeleri ve maçları da tahmine (ve önbellek anahtarına) girer.
+	var (
+		playoffCup     *models.Cup
+		playoffTies    []models.CupTie
+		playoffMatches []models.Match
+	)
+	if season.PlayoffCupID != nil {
+		if playoffCup, err = findCup(s.cupRepo, *season.PlayoffCupID); err != nil {
+			return models.PredictionResult{}, err
+		}
+		if playoffTies, err = s.cupRepo.GetTiesByCup(playoffCup.ID); err != nil {
+			return models.PredictionResult{}, fmt.Errorf("failed to get playoff ties: %w", err)
+		}
+		if playoffMatches, err = s.matchRepo.GetMatchesByCup(playoffCup.ID); err != nil {
+			return models.PredictionResult{}, fmt.Errorf("failed to get playoff matches: %w", err)
+		}
+	}
 
-	var wg sync.WaitGroup
-	resultsChan := make(chan int, numSimulations)
-	errorChan := make(chan error, numSimulations)
+	// Lig bir piramitteyse küme düşme hattı varsayılan olarak alt lige düşen takım sayısıdır; üst lige
+	// çıkma olasılığı da ligin üst lige çıkan takım sayısına göre hesaplanır.
+	leagues, err := s.leagueRepo.GetAllLeagues()
+	if err != nil {
+		return models.PredictionResult{}, err
+	}
+	if opts.RelegationPlaces == 0 && league.RelegationPlaces > 0 && childLeague(leagues, league.ID) != nil {
+		opts.RelegationPlaces = league.RelegationPlaces
+	}
+	promotionPlaces := 0
+	if league.ParentLeagueID != nil {
+		promotionPlaces = min(league.PromotionPlaces, len(teams))
+	}
+
+	cacheKey := predictionCacheKey{
+		seasonID:         season.ID,
+		state:            leagueStateHash(teams, append(slices.Clone(matches), playoffMatches...)),
+		engine:           s.leagueEngineName(league),
+		tiebreakers:      strings.Join(tiebreakerNames(rules.Tiebreakers), ","),
+		simulations:      numSimulations,
+		topN:             opts.TopN,
+		relegationPlaces: opts.RelegationPlaces,
+		promotionPlaces:  promotionPlaces,
+		playoffPrize:     season.PlayoffPrize,
+	}
+	if league.Playoffs != nil {
+		cacheKey.playoffs = *league.Playoffs
+	}
+	if opts.Seed != nil || league.Seed != nil {
+		cacheKey.seeded = true
+		cacheKey.seed = resolveSeed(opts.Seed, league.Seed)
+	}
+	if cached, ok := s.predictions.get(cacheKey); ok {
+		return cached, nil
+	}
+
+	engine, err := s.leagueEngine(league)
+	if err != nil {
+		return models.PredictionResult{}, err
+	}
 
-	for i := 0; i < numSimulations; i++ {
+	sim, err := newSeasonSimulator(teams, matches, engine, rules)
+	if err != nil {
+		return models.PredictionResult{}, fmt.Errorf("failed to prepare season simulator: %w", err)
+	}
+	if err := sim.withSplit(league, season, engine); err != nil {
+		return models.PredictionResult{}, fmt.Errorf("failed to prepare season simulator: %w", err)
+	}
+	playoffs, err := newPlayoffSimulator(sim, league, season, playoffCup, playoffTies, engine)
+	if err != nil {
+		return models.PredictionResult{}, fmt.Errorf("failed to prepare playoff simulator: %w", err)
+	}
+
+	numTeams := len(sim.teams)
+	topN, relegationPlaces := opts.zones(numTeams)
+	baseSeed := resolveSeed(opts.Seed, league.Seed)
+
+	positionCounts := make([][]int, numTeams)
+	for i := range positionCounts {
+		positionCounts[i] = make([]int, numTeams)
+	}
+	pointTotals := make([]int, numTeams)
+	// Şampiyonluk ve üst lige çıkma play-off'a bağlı olabildiğinden sıralamadan ayrıca sayılır.
+	titleCounts := make([]int, numTeams)
+	promotionCounts := make([]int, numTeams)
+	playoffCounts := make([]int, numTeams)
+	playoffWinCounts := make([]int, numTeams)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		next atomic.Int64
+	)
+	workers := min(runtime.GOMAXPROCS(0), numSimulations)
+	for w := 0; w < workers; w++ {
 		wg.Add(1)
-		go func(simIndex int) {
+		go func() {
 			defer wg.Done()
-			fmt.Printf("PredictOutcomes: Goroutine %d started (Sim #%d).\n", simIndex, simIndex+1)
-
-			tempTeamRepo := repositories.NewInMemoryTeamRepository()
-			tempMatchRepo := repositories.NewInMemoryMatchRepository()
-
-			for _, team := range initialTeams {
-				copiedTeam := models.Team{ // Deep copy
-					ID:            team.ID,
-					Name:          team.Name,
-					Strength:      team.Strength,
-					Points:        team.Points,
-					GoalsFor:      team.GoalsFor,
-					GoalsAgainst:  team.GoalsAgainst,
-					MatchesPlayed: team.MatchesPlayed,
-					Wins:          team.Wins,
-					Draws:         team.Draws,
-					Loses:         team.Loses,
-				}
-				if err := tempTeamRepo.CreateTeam(&copiedTeam); err != nil {
-					errMsg := fmt.Sprintf("PredictOutcomes: Sim %d failed to copy team %d: %v", simIndex, team.ID, err)
-					fmt.Println(errMsg)
-					errorChan <- fmt.Errorf(errMsg)
-					return
-				}
+
+			localCounts := make([][]int, numTeams)
+			for i := range localCounts {
+				localCounts[i] = make([]int, numTeams)
 			}
-			fmt.Printf("PredictOutcomes: Sim %d teams copied.\n", simIndex)
-
-			for _, match := range initialMatches {
-				copiedMatch := models.Match{ // Deep copy
-					ID:         match.ID,
-					HomeTeamID: match.HomeTeamID,
-					AwayTeamID: match.AwayTeamID,
-					Week:       match.Week,
-					HomeGoals:  match.HomeGoals,
-					AwayGoals:  match.AwayGoals,
-					Played:     match.Played,
-				}
-				if err := tempMatchRepo.CreateMatch(&copiedMatch); err != nil {
-					errMsg := fmt.Sprintf("PredictOutcomes: Sim %d failed to copy match %d: %v", simIndex, match.ID, err)
-					fmt.Println(errMsg)
-					errorChan <- fmt.Errorf(errMsg)
-					return
-				}
+			localPoints := make([]int, numTeams)
+			localTitles := make([]int, numTeams)
+			localPromotions := make([]int, numTeams)
+			localPlayoffs := make([]int, numTeams)
+			localPlayoffWins := make([]int, numTeams)
+			st := sim.newState()
+			rng := newRand(0)
+
+			var (
+				ko        *knockoutState
+				qualified []bool
+			)
+			if playoffs != nil {
+				ko = playoffs.knockout.newState()
+				qualified = make([]bool, numTeams)
 			}
-			fmt.Printf("PredictOutcomes: Sim %d matches copied.\n", simIndex)
-
-			tempTeamSvc := NewTeamService(tempTeamRepo)
-			tempMatchSvc := NewMatchService(tempMatchRepo, tempTeamRepo)
 
-			tempLeagueSvc := &leagueService{
-				matchRepo:   tempMatchRepo,
-				matchSvc:    tempMatchSvc,
-				teamRepo:    tempTeamRepo,
-				teamSvc:     tempTeamSvc,
-				currentWeek: initialCurrentWeek,
-			}
+			for ctx.Err() == nil {
+				simIndex := next.Add(1) - 1
+				if simIndex >= int64(numSimulations) {
+					break
+				}
+				rng.Seed(deriveSeed(baseSeed, simIndex))
+				sim.run(rng, st)
+				for pos, team := range st.order {
+					localCounts[team][pos]++
+					localPoints[team] += st.rows[team].Points
+				}
 
-			fmt.Printf("PredictOutcomes: Sim %d calling SimulateAllWeeks...\n", simIndex)
-			_, simErr := tempLeagueSvc.SimulateAllWeeks()
-			if simErr != nil && simErr.Error() != fmt.Sprintf("league has already completed. current week: %d", tempLeagueSvc.currentWeek) {
-				errMsg := fmt.Sprintf("PredictOutcomes: Sim %d simulation failed: %v", simIndex, simErr)
-				fmt.Println(errMsg)
-				errorChan <- fmt.Errorf(errMsg)
-				return
-			}
-			fmt.Printf("PredictOutcomes: Sim %d SimulateAllWeeks completed.\n", simIndex)
-
-			finalTeams, simErr := tempTeamRepo.GetAllTeams() // Doğrudan repo'dan al
-			if simErr != nil {
-				errMsg := fmt.Sprintf("PredictOutcomes: Sim %d failed to get final teams from tempRepo: %v", simIndex, simErr)
-				fmt.Println(errMsg)
-				errorChan <- fmt.Errorf(errMsg)
-				return
-			}
+				// Bölünmede play-off'a kalmak üst gruba kalmaktır; şampiyonu final sıralaması belirler.
+				for team, ok := range st.upper {
+					if ok {
+						localPlayoffs[team]++
+					}
+				}
 
-			// Takımları sırala (şimdiki GetLeagueTable mantığının aynısı)
-			sort.Slice(finalTeams, func(i, j int) bool {
-				if finalTeams[i].Points != finalTeams[j].Points {
-					return finalTeams[i].Points > finalTeams[j].Points
+				champion, automatic, playoffWinner := st.order[0], promotionPlaces, -1
+				if playoffs != nil {
+					playoffWinner = playoffs.run(rng, ko, st.order, qualified)
+					for team, ok := range qualified {
+						if ok {
+							localPlayoffs[team]++
+						}
+					}
+					localPlayoffWins[playoffWinner]++
+					switch playoffs.prize {
+					case models.PlayoffPrizeTitle:
+						champion = playoffWinner
+					case models.PlayoffPrizePromotion:
+						if promotionPlaces > 0 {
+							automatic = promotionPlaces - 1
+						}
+					}
 				}
-				if finalTeams[i].GoalDifference() != finalTeams[j].GoalDifference() {
-					return finalTeams[i].GoalDifference() > finalTeams[j].GoalDifference()
+				localTitles[champion]++
+				for _, team := range st.order[:automatic] {
+					localPromotions[team]++
 				}
-				return finalTeams[i].GoalsFor > finalTeams[j].GoalsFor
-			})
+				if automatic < promotionPlaces {
+					// Play-off'un kazananı doğrudan çıkanlar arasındaysa sıradaki takım çıkar (bkz. promotedTeams).
+					if slices.Contains(st.order[:automatic], playoffWinner) {
+						localPromotions[st.order[automatic]]++
+					} else {
+						localPromotions[playoffWinner]++
+					}
+				}
+			}
 
-			if len(finalTeams) > 0 {
-				resultsChan <- finalTeams[0].ID
-				fmt.Printf("PredictOutcomes: Sim %d identified winner Team ID: %d. Completed.\n", simIndex, finalTeams[0].ID)
-			} else {
-				fmt.Printf("PredictOutcomes: Sim %d found no teams in final state. This should not happen if league has teams.\n", simIndex)
+			mu.Lock()
+			defer mu.Unlock()
+			for team := range localCounts {
+				for pos, count := range localCounts[team] {
+					positionCounts[team][pos] += count
+				}
+				pointTotals[team] += localPoints[team]
+				titleCounts[team] += localTitles[team]
+				promotionCounts[team] += localPromotions[team]
+				playoffCounts[team] += localPlayoffs[team]
+				playoffWinCounts[team] += localPlayoffWins[team]
 			}
-		}(i)
+		}()
 	}
-
 	wg.Wait()
-	fmt.Printf("PredictOutcomes: All %d goroutines finished. Closing channels.\n", numSimulations)
-	close(resultsChan)
-	close(errorChan)
 
-	select {
-	case err := <-errorChan:
-		if err != nil {
-			fmt.Printf("PredictOutcomes: Main error channel received critical error from goroutine: %v\n", err)
-			return models.PredictionResult{}, err
+	if err := ctx.Err(); err != nil {
+		return models.PredictionResult{}, fmt.Errorf("prediction cancelled: %w", err)
+	}
+
+	result := models.PredictionResult{
+		Simulations:      numSimulations,
+		Seed:             baseSeed,
+		TopN:             topN,
+		RelegationPlaces: relegationPlaces,
+		PromotionPlaces:  promotionPlaces,
+	}
+	if playoffs != nil || isSplit(league.Playoffs) {
+		result.Playoffs = league.Playoffs
+		if playoffCup != nil {
+			// Başlamış play-off'un biçimi kupadan alınır; ligin ayarı sonradan değişmiş olabilir.
+			result.Playoffs = &models.PlayoffFormat{Legs: playoffCup.Legs, Prize: season.PlayoffPrize}
+			for pos, team := range rankTeams(teams, matches, 0, rules) {
+				switch team.ID {
+				case playoffCup.TeamIDs[0]:
+					result.Playoffs.FirstPlace = pos + 1
+				case playoffCup.TeamIDs[len(playoffCup.TeamIDs)-1]:
+					result.Playoffs.LastPlace = pos + 1
+				}
+			}
 		}
-	default:
-		// Hata yok
 	}
-	fmt.Println("PredictOutcomes: Processing simulation results from resultsChan.")
-
-	for teamID := range resultsChan {
-		actualCount, ok := championshipCounts.Load(teamID)
-		if !ok {
-			actualCount = 0
-		}
-		championshipCounts.Store(teamID, actualCount.(int)+1)
-		fmt.Printf("PredictOutcomes: Counted team %d. Current count: %d\n", teamID, actualCount.(int)+1)
+	percent := func(count int) float64 {
+		return float64(count) / float64(numSimulations) * 100
 	}
-	fmt.Println("PredictOutcomes: Finished counting championship wins.")
-
-	var championshipPredictions []models.Prediction
-	fmt.Println("PredictOutcomes: Populating championship predictions slice.")
-	championshipCounts.Range(func(key, value interface{}) bool {
-		teamID := key.(int)
-		count := value.(int)
-
-		team, err := s.teamRepo.GetTeamByID(teamID) // Orijinal repo'dan takım bilgisini al
-		if err != nil {
+	for i, team := range sim.teams {
+		counts := positionCounts[i]
+		prediction := models.TeamPrediction{
+			TeamID:                 team.ID,
+			TeamName:               team.Name,
+			PositionProbabilities:  make([]float64, numTeams),
+			ChampionshipLikelihood: percent(titleCounts[i]),
+			PromotionLikelihood:    percent(promotionCounts[i]),
+			PlayoffLikelihood:      percent(playoffCounts[i]),
+			PlayoffWinLikelihood:   percent(playoffWinCounts[i]),
+			ExpectedPoints:         float64(pointTotals[i]) / float64(numSimulations),
+		}
+		for pos, count := range counts {
+			probability := percent(count)
+			prediction.PositionProbabilities[pos] = probability
+			prediction.ExpectedPosition += float64(pos+1) * float64(count) / float64(numSimulations)
+			if pos < topN {
+				prediction.TopNLikelihood += probability
+			}
+			if pos >= numTeams-relegationPlaces {
+				prediction.RelegationLikelihood += probability
+			}
+		}
+		result.Teams = append(result.Teams, prediction)
 
-			fmt.Printf("PredictOutcomes: ERROR: Failed to get team by ID %d for prediction result: %v. This team's prediction will be skipped.\n", teamID, err)
-			return true // Hata olsa bile diğer takımlar için devam et
+		if titleCounts[i] > 0 {
+			result.ChampionshipPredictions = append(result.ChampionshipPredictions, models.Prediction{
+				TeamID:                 team.ID,
+				TeamName:               team.Name,
+				ChampionshipLikelihood: prediction.ChampionshipLikelihood,
+			})
 		}
+	}
 
-		fmt.Printf("PredictOutcomes: Adding prediction for Team %s (ID: %d), wins: %d\n", team.Name, teamID, count)
-		championshipPredictions = append(championshipPredictions, models.Prediction{
-			TeamID:                 teamID,
-			TeamName:               team.Name,
-			ChampionshipLikelihood: float64(count) / float64(numSimulations) * 100,
-		})
-		return true
+	sort.SliceStable(result.Teams, func(i, j int) bool {
+		return result.Teams[i].ExpectedPosition < result.Teams[j].ExpectedPosition
+	})
+	sort.SliceStable(result.ChampionshipPredictions, func(i, j int) bool {
+		return result.ChampionshipPredictions[i].ChampionshipLikelihood > result.ChampionshipPredictions[j].ChampionshipLikelihood
 	})
 
-	fmt.Printf("PredictOutcomes: Number of predictions gathered: %d\n", len(championshipPredictions))
-
-	if len(championshipPredictions) > 0 {
-		sort.Slice(championshipPredictions, func(i, j int) bool {
-			return championshipPredictions[i].ChampionshipLikelihood > championshipPredictions[j].ChampionshipLikelihood
-		})
-		fmt.Println("PredictOutcomes: Championship predictions sorted.")
-	} else {
-		fmt.Println("PredictOutcomes: No championship predictions to sort (slice is empty).")
-	}
-
-	elapsedTime := time.Since(startTime)
-	fmt.Printf("PredictOutcomes: Completed %d simulations in %s. Returning prediction result.\n", numSimulations, elapsedTime)
-	return models.PredictionResult{
-		ChampionshipPredictions: championshipPredictions,
-	}, nil
+	s.predictions.put(cacheKey, result)
+	return result, nil
 }
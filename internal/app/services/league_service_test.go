@@ -0,0 +1,204 @@
+package services
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+)
+
+// testRepos testlerde servislerin üzerinde çalıştığı bellek içi depolardır.
+type testRepos struct {
+	teams       *repositories.InMemoryTeamRepository
+	matches     *repositories.InMemoryMatchRepository
+	leagues     *repositories.InMemoryLeagueRepository
+	seasons     *repositories.InMemorySeasonRepository
+	cups        *repositories.InMemoryCupRepository
+	tournaments *repositories.InMemoryTournamentRepository
+	uow         *failingUnitOfWork
+}
+
+func newTestRepos() testRepos {
+	teams := repositories.NewInMemoryTeamRepository()
+	matches := repositories.NewInMemoryMatchRepository()
+	leagues := repositories.NewInMemoryLeagueRepository()
+	seasons := repositories.NewInMemorySeasonRepository()
+	cups := repositories.NewInMemoryCupRepository()
+	tournaments := repositories.NewInMemoryTournamentRepository()
+	uow := &failingUnitOfWork{UnitOfWork: repositories.NewInMemoryUnitOfWork(teams, matches, leagues, seasons, cups, tournaments)}
+	return testRepos{teams: teams, matches: matches, leagues: leagues, seasons: seasons, cups: cups, tournaments: tournaments, uow: uow}
+}
+
+func (r testRepos) leagueService(t *testing.T, settings LeagueSettings) LeagueService {
+	t.Helper()
+	svc, err := NewLeagueService(r.matches, NewMatchService(r.matches, NewPoissonEngine()), r.teams, NewTeamService(r.teams, r.uow), r.leagues, r.seasons, r.cups, r.uow, settings)
+	if err != nil {
+		t.Fatalf("NewLeagueService: %v", err)
+	}
+	return svc
+}
+
+var errInjected = errors.New("injected failure")
+
+// failingUnitOfWork işlemlere verilen depoları inject ile sarar; böylece bir işlemin ortasında hata üretilebilir.
+type failingUnitOfWork struct {
+	repositories.UnitOfWork
+	inject func(repos repositories.Repositories) repositories.Repositories
+}
+
+func (u *failingUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	return u.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		if u.inject != nil {
+			repos = u.inject(repos)
+		}
+		return fn(repos)
+	})
+}
+
+// failAfter ilk n çağrıya izin verir, sonrakilerde errInjected döndürür.
+type failAfter struct{ n int }
+
+func (f *failAfter) hit() error {
+	if f.n == 0 {
+		return errInjected
+	}
+	f.n--
+	return nil
+}
+
+type failingMatchRepository struct {
+	repositories.MatchRepository
+	fail *failAfter
+}
+
+func (r failingMatchRepository) UpdateMatch(match *models.Match) error {
+	if err := r.fail.hit(); err != nil {
+		return err
+	}
+	return r.MatchRepository.UpdateMatch(match)
+}
+
+type failingLeagueRepository struct {
+	repositories.LeagueRepository
+	fail *failAfter
+}
+
+func (r failingLeagueRepository) UpdateLeague(league *models.LeagueState) error {
+	if err := r.fail.hit(); err != nil {
+		return err
+	}
+	return r.LeagueRepository.UpdateLeague(league)
+}
+
+type failingTeamRepository struct {
+	repositories.TeamRepository
+	fail *failAfter
+}
+
+func (r failingTeamRepository) UpdateTeam(team *models.Team) error {
+	if err := r.fail.hit(); err != nil {
+		return err
+	}
+	return r.TeamRepository.UpdateTeam(team)
+}
+
+// leagueSnapshot bir ligin depolardaki tüm durumudur: maçlar, lig kaydı ve materialize edilmiş puan durumu.
+type leagueSnapshot struct {
+	matches []models.Match
+	league  *models.LeagueState
+	teams   []models.Team
+}
+
+func (r testRepos) snapshot(t *testing.T, leagueID int) leagueSnapshot {
+	t.Helper()
+	matches, err := r.matches.GetMatchesByLeague(leagueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	league, err := r.leagues.GetLeagueByID(leagueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teams, err := r.teams.GetTeamsByLeague(leagueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bellek içi depolar kayıtları belirli bir sırayla döndürmediğinden karşılaştırma kimlik sırasıyla yapılır.
+	slices.SortFunc(matches, func(a, b models.Match) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(teams, func(a, b models.Team) int { return cmp.Compare(a.ID, b.ID) })
+	return leagueSnapshot{matches: matches, league: league, teams: teams}
+}
+
+func TestPlayWeekRollsBackOnFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		inject func(repos repositories.Repositories) repositories.Repositories
+	}{
+		{
+			name: "second match of the week",
+			inject: func(repos repositories.Repositories) repositories.Repositories {
+				repos.Matches = failingMatchRepository{MatchRepository: repos.Matches, fail: &failAfter{n: 1}}
+				return repos
+			},
+		},
+		{
+			name: "league state",
+			inject: func(repos repositories.Repositories) repositories.Repositories {
+				repos.Leagues = failingLeagueRepository{LeagueRepository: repos.Leagues, fail: &failAfter{}}
+				return repos
+			},
+		},
+		{
+			name: "standings cache",
+			inject: func(repos repositories.Repositories) repositories.Repositories {
+				repos.Teams = failingTeamRepository{TeamRepository: repos.Teams, fail: &failAfter{n: 2}}
+				return repos
+			},
+		},
+	}
+	for _, tt := range tests {
+		repos := newTestRepos()
+		svc := repos.leagueService(t, LeagueSettings{MaterializeStandings: true})
+
+		seed := int64(11)
+		league, err := svc.CreateLeague(NewLeague{Name: "Rollback", Seed: &seed, Teams: []NewTeam{
+			{Name: "A", Strength: 80}, {Name: "B", Strength: 70}, {Name: "C", Strength: 60}, {Name: "D", Strength: 50},
+		}})
+		if err != nil {
+			t.Fatalf("%s: CreateLeague: %v", tt.name, err)
+		}
+		if err := svc.PlayWeek(league.ID, 1, nil); err != nil {
+			t.Fatalf("%s: week 1: %v", tt.name, err)
+		}
+		before := repos.snapshot(t, league.ID)
+
+		repos.uow.inject = tt.inject
+		if err := svc.PlayWeek(league.ID, 2, nil); !errors.Is(err, errInjected) {
+			t.Fatalf("%s: week 2 error = %v, want injected failure", tt.name, err)
+		}
+		repos.uow.inject = nil
+
+		after := repos.snapshot(t, league.ID)
+		if !reflect.DeepEqual(after.matches, before.matches) {
+			t.Errorf("%s: matches were not rolled back", tt.name)
+		}
+		if !reflect.DeepEqual(after.league, before.league) {
+			t.Errorf("%s: league state was not rolled back: got %+v, want %+v", tt.name, after.league, before.league)
+		}
+		if !reflect.DeepEqual(after.teams, before.teams) {
+			t.Errorf("%s: standings cache was not rolled back: got %+v, want %+v", tt.name, after.teams, before.teams)
+		}
+
+		// Geri alınan hafta tekrar oynanabilir.
+		if err := svc.PlayWeek(league.ID, 2, nil); err != nil {
+			t.Fatalf("%s: replaying week 2: %v", tt.name, err)
+		}
+		if got := repos.snapshot(t, league.ID).league.CurrentWeek; got != 3 {
+			t.Errorf("%s: current week after replay = %d, want 3", tt.name, got)
+		}
+	}
+}
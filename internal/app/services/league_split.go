@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+	"github.com/muzaffertuna/football-league-sim/internal/app/scheduler"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// validateSplit bölünmenin iki grubunda da en az iki takım kalmasını sağlar. Bölünmede şampiyonu ve lig
+// değiştiren takımları final sıralaması belirlediğinden ödül ve eleme sıraları verilmez.
+func validateSplit(format *models.PlayoffFormat, numTeams int) error {
+	if format.FirstPlace != 0 || format.LastPlace != 0 || format.Prize != "" {
+		return fmt.Errorf("%w: split playoffs take split_place and legs only; the final table decides the title, promotion and relegation", ErrInvalidLeague)
+	}
+	if format.SplitPlace < 2 || format.SplitPlace > numTeams-2 {
+		return fmt.Errorf("%w: split_place must leave at least two teams in each group, got %d of %d teams", ErrInvalidLeague, format.SplitPlace, numTeams)
+	}
+	return nil
+}
+
+// isSplit ligin play-off'unun bölünme olup olmadığını döndürür.
+func isSplit(format *models.PlayoffFormat) bool {
+	return format != nil && format.Kind == models.PlayoffKindSplit
+}
+
+// startSplit normal sezonu biten ligin tablosunu ilk SplitPlace takımdan sonra ikiye böler ve grupların
+// maçlarını normal sezonun ardından gelen haftalara ekler. ranked normal sezonun final sıralamasıdır. Takım
+// sayısı kadro değişikliğiyle azaldıysa ve gruplardan birinde ikiden az takım kalıyorsa tablo bölünmez.
+// Lig kaydedilmez.
+func startSplit(repos repositories.Repositories, league *models.LeagueState, season *models.Season, ranked []models.Team) error {
+	format := league.Playoffs
+	if format.SplitPlace < 2 || format.SplitPlace > len(ranked)-2 {
+		return nil
+	}
+
+	totalWeeks, err := repos.Matches.GetTotalWeeks(season.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get total weeks: %w", err)
+	}
+	order := make([]int, len(ranked))
+	for i, team := range ranked {
+		order[i] = team.ID
+	}
+	fixtures, err := splitFixtures(order, format.SplitPlace, format.Legs)
+	if err != nil {
+		return err
+	}
+	for _, f := range fixtures {
+		match := &models.Match{
+			LeagueID:   season.LeagueID,
+			SeasonID:   season.ID,
+			HomeTeamID: f.HomeTeamID,
+			AwayTeamID: f.AwayTeamID,
+			Week:       totalWeeks + f.Week,
+		}
+		if err := repos.Matches.CreateMatch(match); err != nil {
+			return fmt.Errorf("failed to create split match: %w", err)
+		}
+	}
+
+	season.Split = &models.SeasonSplit{Week: totalWeeks + 1, UpperTeamIDs: slices.Clone(order[:format.SplitPlace])}
+	if err := repos.Seasons.UpdateSeason(season); err != nil {
+		return fmt.Errorf("failed to save season %d: %w", season.Number, err)
+	}
+	league.Status = models.LeagueStatusInProgress
+	return nil
+}
+
+// splitFixtures bölünmenin fikstürünü üretir: order'daki ilk splitPlace takım ve kalanlar kendi aralarında
+// legs devreli lig usulüyle oynar. Haftalar 1'den başlar ve iki grup aynı haftalarda oynar. Fikstür yalnızca
+// takımların order'daki sırasına bağlıdır; tahminler de aynı eşleşmeleri sıralamadaki yerlere göre kurar.
+func splitFixtures(order []int, splitPlace, legs int) ([]scheduler.Fixture, error) {
+	roundRobin := scheduler.SingleRoundRobin
+	if legs == 2 {
+		roundRobin = scheduler.DoubleRoundRobin
+	}
+	var fixtures []scheduler.Fixture
+	for _, group := range [][]int{order[:splitPlace], order[splitPlace:]} {
+		groupFixtures, err := roundRobin(group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate split fixture: %w", err)
+		}
+		fixtures = append(fixtures, groupFixtures...)
+	}
+	return fixtures, nil
+}
+
+// rankSeason sezonun takımlarını rankTeams gibi sıralar. Sezonun tablosu bölündüyse ve uptoWeek bölünmeden
+// önceki bir hafta değilse üst gruptaki takımlar puanlarından bağımsız olarak alt gruptakilerin önünde yer alır.
+func rankSeason(season *models.Season, teams []models.Team, matches []models.Match, uptoWeek int, rules standings.Rules) []models.Team {
+	ranked := rankTeams(teams, matches, uptoWeek, rules)
+	split := season.Split
+	if split == nil || (uptoWeek > 0 && uptoWeek < split.Week) {
+		return ranked
+	}
+	slices.SortStableFunc(ranked, func(a, b models.Team) int {
+		return splitGroup(split, a.ID) - splitGroup(split, b.ID)
+	})
+	return ranked
+}
+
+// splitGroup takımın bölünmedeki grubunu döndürür: üst grup için 0, alt grup için 1.
+func splitGroup(split *models.SeasonSplit, teamID int) int {
+	if slices.Contains(split.UpperTeamIDs, teamID) {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+func TestSplitPlayoffs(t *testing.T) {
+	tests := []struct {
+		name       string
+		legs       int
+		splitWeeks int
+	}{
+		{name: "single round robin", legs: 1, splitWeeks: 3},
+		{name: "double round robin", legs: 2, splitWeeks: 6},
+	}
+	for _, tt := range tests {
+		repos := newTestRepos()
+		svc := repos.leagueService(t, LeagueSettings{})
+
+		seed := int64(5)
+		var teams []NewTeam
+		for i, name := range []string{"A", "B", "C", "D", "E", "F", "G", "H"} {
+			teams = append(teams, NewTeam{Name: name, Strength: 90 - 5*i})
+		}
+		league, err := svc.CreateLeague(NewLeague{
+			Name:     "Split",
+			Seed:     &seed,
+			Teams:    teams,
+			Playoffs: &models.PlayoffFormat{Kind: models.PlayoffKindSplit, SplitPlace: 4, Legs: tt.legs},
+		})
+		if err != nil {
+			t.Fatalf("%s: CreateLeague: %v", tt.name, err)
+		}
+		regularWeeks, err := svc.GetTotalWeeks(league.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prediction, err := svc.PredictOutcomes(context.Background(), league.ID, PredictionOptions{Simulations: 200, Seed: &seed})
+		if err != nil {
+			t.Fatalf("%s: PredictOutcomes: %v", tt.name, err)
+		}
+		upper := 0.0
+		for _, team := range prediction.Teams {
+			upper += team.PlayoffLikelihood
+		}
+		if upper < 399.9 || upper > 400.1 {
+			t.Errorf("%s: upper group likelihoods sum to %.1f, want 400", tt.name, upper)
+		}
+
+		if _, err := svc.SimulateAllWeeks(league.ID, nil); err != nil {
+			t.Fatalf("%s: SimulateAllWeeks: %v", tt.name, err)
+		}
+		if total, err := svc.GetTotalWeeks(league.ID); err != nil || total != regularWeeks+tt.splitWeeks {
+			t.Fatalf("%s: total weeks = %d (%v), want %d", tt.name, total, err, regularWeeks+tt.splitWeeks)
+		}
+
+		season, err := currentSeason(repos.seasons, league.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		split := season.Split
+		if split == nil || split.Week != regularWeeks+1 || len(split.UpperTeamIDs) != 4 {
+			t.Fatalf("%s: season split = %+v, want four teams from week %d", tt.name, split, regularWeeks+1)
+		}
+
+		// Gruplar normal sezonun ilk dört ve son dört takımıdır ve yalnızca kendi aralarında oynar.
+		before, err := svc.GetLeagueTable(context.Background(), league.ID, LeagueTableOptions{AsOfWeek: regularWeeks})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, team := range before.Teams[:4] {
+			if !slices.Contains(split.UpperTeamIDs, team.ID) {
+				t.Errorf("%s: team %d finished %d. in the regular season but is not in the upper group", tt.name, team.ID, i+1)
+			}
+		}
+		matches, err := repos.matches.GetMatchesBySeason(season.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var regularMatch, splitMatch *models.Match
+		for _, match := range matches {
+			if match.Week < split.Week {
+				regularMatch = &match
+				continue
+			}
+			if !match.Played {
+				t.Errorf("%s: split match %d was not played", tt.name, match.ID)
+			}
+			if splitGroup(split, match.HomeTeamID) != splitGroup(split, match.AwayTeamID) {
+				t.Errorf("%s: split match %d is between the groups", tt.name, match.ID)
+			}
+			splitMatch = &match
+		}
+
+		// Bölünmeden sonra üst gruptaki takımlar puanlarından bağımsız olarak önde kalır.
+		table, err := svc.GetLeagueTable(context.Background(), league.ID, LeagueTableOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if table.Status != models.LeagueStatusCompleted {
+			t.Errorf("%s: league status = %s, want completed", tt.name, table.Status)
+		}
+		for i, team := range table.Teams {
+			if want := i >= 4; (splitGroup(split, team.ID) == 1) != want {
+				t.Errorf("%s: team %d is %d. in the final table but in the wrong group", tt.name, team.ID, i+1)
+			}
+		}
+
+		// Grupları belirleyen normal sezon maçları kilitlenir; bölünmenin maçları değiştirilebilir.
+		if _, err := svc.SetMatchResult(regularMatch.ID, 0, 0); !errors.Is(err, ErrResultLocked) {
+			t.Errorf("%s: changing a regular-season match: err = %v, want ErrResultLocked", tt.name, err)
+		}
+		if _, err := svc.SetMatchResult(splitMatch.ID, 3, 0); err != nil {
+			t.Errorf("%s: changing a split match: %v", tt.name, err)
+		}
+	}
+}
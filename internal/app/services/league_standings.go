@@ -0,0 +1,64 @@
+package services
+
+import (
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// playedResults oynanmış maçların sonuçlarını döndürür. uptoWeek > 0 ise yalnızca o haftaya
+// kadar (dahil) oynanan maçlar alınır; böylece puan durumu geçmiş herhangi bir hafta için kurulabilir.
+func playedResults(matches []models.Match, uptoWeek int) []standings.Result {
+	var results []standings.Result
+	for _, match := range matches {
+		if !match.Played || (uptoWeek > 0 && match.Week > uptoWeek) {
+			continue
+		}
+		results = append(results, standings.Result{
+			HomeTeamID: match.HomeTeamID,
+			AwayTeamID: match.AwayTeamID,
+			HomeGoals:  match.HomeGoals,
+			AwayGoals:  match.AwayGoals,
+		})
+	}
+	return results
+}
+
+// standingsRows takımların puan durumu satırlarını maç sonuçlarından hesaplar. Satırlar teams sırasındadır;
+// sonuçlardan çıkarılamayan fair-play puanları takımlardan alınır.
+func standingsRows(teams []models.Team, results []standings.Result) []standings.Row {
+	teamIDs := make([]int, len(teams))
+	for i, team := range teams {
+		teamIDs[i] = team.ID
+	}
+	rows := standings.Compute(teamIDs, results)
+	for i, team := range teams {
+		rows[i].FairPlayPoints = team.FairPlayPoints
+	}
+	return rows
+}
+
+// rankTeams takımların istatistiklerini Teams tablosundaki sayaçlar yerine maç sonuçlarından yeniden
+// hesaplar ve takımları ligin sıralama kurallarına göre sıralanmış olarak döndürür.
+func rankTeams(teams []models.Team, matches []models.Match, uptoWeek int, rules standings.Rules) []models.Team {
+	results := playedResults(matches, uptoWeek)
+	rows := standingsRows(teams, results)
+	standings.Sort(rows, results, rules)
+
+	byID := make(map[int]models.Team, len(teams))
+	for _, team := range teams {
+		byID[team.ID] = team
+	}
+	ranked := make([]models.Team, len(rows))
+	for i, row := range rows {
+		team := byID[row.TeamID]
+		team.MatchesPlayed = row.Played
+		team.Wins = row.Wins
+		team.Draws = row.Draws
+		team.Loses = row.Losses
+		team.GoalsFor = row.GoalsFor
+		team.GoalsAgainst = row.GoalsAgainst
+		team.Points = row.Points
+		ranked[i] = team
+	}
+	return ranked
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/scheduler"
+)
+
+// validateSwiss İsviçre usulü ligin biçimini ve takımların torbalarını doğrular. Torbaların eşit büyüklükte
+// olması gibi fikstüre ilişkin kısıtlar fikstür üretilirken denetlenir.
+func validateSwiss(format *models.SwissFormat, teams []NewTeam) error {
+	if format.OpponentsPerPot < 1 {
+		return fmt.Errorf("%w: swiss opponents per pot must be positive, got %d", ErrInvalidLeague, format.OpponentsPerPot)
+	}
+	for _, t := range teams {
+		if t.Pot < 1 {
+			return fmt.Errorf("%w: team %q needs a pot in a swiss league", ErrInvalidLeague, t.Name)
+		}
+	}
+	return nil
+}
+
+// swissFixture ligin takımlarının torbalarından ve ülkelerinden İsviçre usulü bir fikstür üretir. Kura ligin
+// tohumundan ve sezon numarasından türetilir; böylece oynanmamış bir sezon sıfırlandığında aynı fikstür çıkar.
+// Kısıtları sağlayan bir fikstür bulunamazsa ErrInvalidLeague döner.
+func swissFixture(league *models.LeagueState, season *models.Season, teams []models.Team) ([]scheduler.Fixture, error) {
+	swissTeams := make([]scheduler.SwissTeam, len(teams))
+	for i, team := range teams {
+		swissTeams[i] = scheduler.SwissTeam{ID: team.ID, Pot: team.Pot, Country: team.Country}
+	}
+	slices.SortFunc(swissTeams, func(a, b scheduler.SwissTeam) int { return cmp.Compare(a.ID, b.ID) })
+
+	rng := newRand(deriveSeed(resolveSeed(nil, league.Seed), int64(season.Number)))
+	fixtures, err := scheduler.Swiss(swissTeams, league.Swiss.OpponentsPerPot, rng)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to generate swiss fixture: %v", ErrInvalidLeague, err)
+	}
+	return fixtures, nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+const (
+	EngineLegacy  = "legacy"
+	EnginePoisson = "poisson"
+)
+
+// MatchEngine iki takım arasındaki bir maçın skorunu üretir.
+// MatchService skor üretimini bu arayüze devreder; lig bazında farklı motorlar seçilebilir.
+// Motorlar yalnızca verilen rastgele sayı üretecini kullanır; böylece aynı tohum aynı skoru verir
+// ve eşzamanlı simülasyonlar ortak bir kaynağı paylaşmaz.
+type MatchEngine interface {
+	Name() string
+	SimulateScore(rng *rand.Rand, homeTeam, awayTeam *models.Team) (homeGoals, awayGoals int)
+	// SimulateExtraTime 30 dakikalık uzatmada atılan golleri üretir (kupa eşleşmeleri için).
+	SimulateExtraTime(rng *rand.Rand, homeTeam, awayTeam *models.Team) (homeGoals, awayGoals int)
+	// SimulatePenalties bir seri penaltı atışını oynatır; sonuç hiçbir zaman eşit olmaz.
+	SimulatePenalties(rng *rand.Rand, homeTeam, awayTeam *models.Team) (homeGoals, awayGoals int)
+}
+
+// extraTimeFraction uzatmanın normal süreye oranıdır (30 / 90 dakika).
+const extraTimeFraction = 30.0 / 90.0
+
+const (
+	penaltyConversion    = 0.75 // Eşit güçteki takımlar için bir penaltının gole dönme olasılığı
+	penaltyRatingScale   = 200  // Atıcı takımın hücumu ile kalecinin takımının savunması arasındaki farkın etkisi
+	minPenaltyConversion = 0.6
+	maxPenaltyConversion = 0.9
+	penaltyKicks         = 5 // Ani ölüme geçilmeden önce her takımın kullandığı atış sayısı
+)
+
+// NewMatchEngine adı verilen maç motorunu varsayılan parametreleriyle oluşturur. Ad boşsa, mevcut kurulumların
+// skorları değişmesin diye eski motor kullanılır.
+func NewMatchEngine(name string) (MatchEngine, error) {
+	switch name {
+	case EngineLegacy, "":
+		return NewLegacyEngine(), nil
+	case EnginePoisson:
+		return NewPoissonEngine(), nil
+	case EngineDixonColes:
+		return NewDixonColesEngine(), nil
+	default:
+		return nil, fmt.Errorf("unknown match engine %q", name)
+	}
+}
+
+// LegacyEngine eski "her takıma 5 şut" modelidir. Skorlar 5 ile sınırlıdır.
+type LegacyEngine struct {
+	HomeAdvantage int
+}
+
+func NewLegacyEngine() *LegacyEngine {
+	return &LegacyEngine{HomeAdvantage: 10}
+}
+
+func (e *LegacyEngine) Name() string {
+	return EngineLegacy
+}
+
+func (e *LegacyEngine) SimulateScore(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	return e.Prepare(homeTeam, awayTeam)(rng)
+}
+
+// Prepare ev sahibinin şut başına gol şansını bir kez hesaplayan bir örnekleyici döndürür.
+func (e *LegacyEngine) Prepare(homeTeam, awayTeam *models.Team) ScoreSampler {
+	homeChance := float64(homeTeam.Strength+e.HomeAdvantage) / float64(homeTeam.Strength+awayTeam.Strength+e.HomeAdvantage)
+
+	return func(rng *rand.Rand) (int, int) {
+		homeGoals := 0
+		awayGoals := 0
+
+		// Her takım için 5'er şut, her şut için gol olup olmadığını kontrol et
+		for i := 0; i < 5; i++ {
+			if rng.Float64() < homeChance {
+				homeGoals++
+			}
+			if rng.Float64() < (1 - homeChance) {
+				awayGoals++
+			}
+		}
+		return homeGoals, awayGoals
+	}
+}
+
+// SimulateExtraTime şut sayısını uzatmanın süresine göre azaltır: her şutun gole dönme şansı üçte birine düşer.
+func (e *LegacyEngine) SimulateExtraTime(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	homeChance := float64(homeTeam.Strength+e.HomeAdvantage) / float64(homeTeam.Strength+awayTeam.Strength+e.HomeAdvantage)
+
+	homeGoals := 0
+	awayGoals := 0
+	for i := 0; i < 5; i++ {
+		if rng.Float64() < homeChance*extraTimeFraction {
+			homeGoals++
+		}
+		if rng.Float64() < (1-homeChance)*extraTimeFraction {
+			awayGoals++
+		}
+	}
+	return homeGoals, awayGoals
+}
+
+func (e *LegacyEngine) SimulatePenalties(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	return simulateShootout(rng, homeTeam, awayTeam)
+}
+
+// PoissonEngine her takımın gol sayısını, beklenen gol (xG) değeri hücum/savunma güçlerinden
+// ve iç saha avantajından hesaplanan bağımsız Poisson dağılımlarından çeker.
+type PoissonEngine struct {
+	BaseGoals     float64 // Eşit güçteki iki takım için maç başına beklenen gol
+	HomeAdvantage float64 // Ev sahibinin beklenen golüne uygulanan çarpan
+	RatingScale   float64 // Güç farkının beklenen gole etkisini belirleyen ölçek
+}
+
+func NewPoissonEngine() *PoissonEngine {
+	return &PoissonEngine{
+		BaseGoals:     1.3,
+		HomeAdvantage: 1.15,
+		RatingScale:   25,
+	}
+}
+
+func (e *PoissonEngine) Name() string {
+	return EnginePoisson
+}
+
+// ExpectedGoals ev sahibi ve deplasman takımlarının beklenen gol sayılarını döndürür.
+func (e *PoissonEngine) ExpectedGoals(homeTeam, awayTeam *models.Team) (float64, float64) {
+	homeXG := e.BaseGoals * e.HomeAdvantage * math.Exp(float64(homeTeam.AttackRating()-awayTeam.DefenseRating())/e.RatingScale)
+	awayXG := e.BaseGoals * math.Exp(float64(awayTeam.AttackRating()-homeTeam.DefenseRating())/e.RatingScale)
+	return homeXG, awayXG
+}
+
+func (e *PoissonEngine) SimulateScore(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	return e.Prepare(homeTeam, awayTeam)(rng)
+}
+
+// Prepare beklenen golleri bir kez hesaplayan bir örnekleyici döndürür.
+func (e *PoissonEngine) Prepare(homeTeam, awayTeam *models.Team) ScoreSampler {
+	homeXG, awayXG := e.ExpectedGoals(homeTeam, awayTeam)
+	homeLimit, awayLimit := math.Exp(-homeXG), math.Exp(-awayXG)
+	return func(rng *rand.Rand) (int, int) {
+		return samplePoisson(rng, homeLimit), samplePoisson(rng, awayLimit)
+	}
+}
+
+// SimulateExtraTime gollerini beklenen gollerin uzatmanın süresiyle orantılı kısmından çeker.
+func (e *PoissonEngine) SimulateExtraTime(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	homeXG, awayXG := e.ExpectedGoals(homeTeam, awayTeam)
+	return sampleExtraTime(rng, homeXG, awayXG)
+}
+
+func (e *PoissonEngine) SimulatePenalties(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	return simulateShootout(rng, homeTeam, awayTeam)
+}
+
+// sampleExtraTime 90 dakikalık beklenen gollerden uzatma golleri üretir.
+func sampleExtraTime(rng *rand.Rand, homeXG, awayXG float64) (int, int) {
+	return samplePoisson(rng, math.Exp(-homeXG*extraTimeFraction)), samplePoisson(rng, math.Exp(-awayXG*extraTimeFraction))
+}
+
+// simulateShootout önce her takıma beşer atış kullandırır, eşitlik sürerse ani ölüme geçer.
+// Seri, geride kalan takım kalan atışlarla yetişemeyecek duruma gelince erken biter.
+// Bir atışın gole dönme olasılığı atıcı takımın hücumu ile rakibin savunmasına göre ayarlanır.
+func simulateShootout(rng *rand.Rand, homeTeam, awayTeam *models.Team) (int, int) {
+	homeChance := penaltyChance(homeTeam, awayTeam)
+	awayChance := penaltyChance(awayTeam, homeTeam)
+
+	homeGoals, awayGoals := 0, 0
+	for kick := 1; kick <= penaltyKicks; kick++ {
+		if rng.Float64() < homeChance {
+			homeGoals++
+		}
+		if homeGoals > awayGoals+penaltyKicks-kick+1 || awayGoals > homeGoals+penaltyKicks-kick {
+			return homeGoals, awayGoals
+		}
+		if rng.Float64() < awayChance {
+			awayGoals++
+		}
+		if homeGoals > awayGoals+penaltyKicks-kick || awayGoals > homeGoals+penaltyKicks-kick {
+			return homeGoals, awayGoals
+		}
+	}
+	for homeGoals == awayGoals {
+		if rng.Float64() < homeChance {
+			homeGoals++
+		}
+		if rng.Float64() < awayChance {
+			awayGoals++
+		}
+	}
+	return homeGoals, awayGoals
+}
+
+func penaltyChance(shooter, keeper *models.Team) float64 {
+	chance := penaltyConversion + float64(shooter.AttackRating()-keeper.DefenseRating())/penaltyRatingScale
+	return min(max(chance, minPenaltyConversion), maxPenaltyConversion)
+}
+
+// samplePoisson Knuth algoritmasıyla ortalaması lambda olan bir Poisson değişkeni üretir; limit exp(-lambda)'dır.
+// Futbol skorlarındaki küçük lambda değerleri için yeterince hızlıdır.
+func samplePoisson(rng *rand.Rand, limit float64) int {
+	if limit >= 1 {
+		return 0
+	}
+	k := 0
+	p := rng.Float64()
+	for p > limit {
+		k++
+		p *= rng.Float64()
+	}
+	return k
+}
@@ -1,24 +1,33 @@
 package services
 
 import (
-	"math/rand"
-	"time"
-
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
 	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
 )
 
 type matchService struct {
 	matchRepo repositories.MatchRepository
-	teamRepo  repositories.TeamRepository
+	engine    MatchEngine
+}
+
+func NewMatchService(matchRepo repositories.MatchRepository, engine MatchEngine) MatchService {
+	return &matchService{matchRepo: matchRepo, engine: engine}
+}
+
+// Engine skorları üretmek için kullanılan maç motorunu döndürür.
+func (s *matchService) Engine() MatchEngine {
+	return s.engine
 }
 
-func NewMatchService(matchRepo repositories.MatchRepository, teamRepo repositories.TeamRepository) MatchService {
-	return &matchService{matchRepo: matchRepo, teamRepo: teamRepo}
+// WithEngine skorları verilen motorla üreten bir kopya döndürür (örneğin kendi motorunu seçmiş bir ligin maçları için).
+func (s *matchService) WithEngine(engine MatchEngine) MatchService {
+	return &matchService{matchRepo: s.matchRepo, engine: engine}
 }
 
-func (s *matchService) CreateMatch(homeTeamID, awayTeamID, week int) (*models.Match, error) {
+func (s *matchService) CreateMatch(leagueID, seasonID, homeTeamID, awayTeamID, week int) (*models.Match, error) {
 	match := &models.Match{
+		LeagueID:   leagueID,
+		SeasonID:   seasonID,
 		HomeTeamID: homeTeamID,
 		AwayTeamID: awayTeamID,
 		Week:       week,
@@ -30,68 +39,23 @@ func (s *matchService) CreateMatch(homeTeamID, awayTeamID, week int) (*models.Ma
 	return match, nil
 }
 
-func (s *matchService) SimulateMatch(match *models.Match, homeTeam, awayTeam *models.Team) error {
+// SimulateMatch maçı verilen tohumla simüle eder ve skoru tohumla birlikte maça yazar.
+// Aynı tohum, aynı takımlar ve aynı motorla maç birebir tekrar üretilebilir. Maç burada kaydedilmez;
+// çağıran taraf (örneğin bir haftanın tüm maçlarını tek işlemde kaydeden lig servisi) kaydeder.
+func (s *matchService) SimulateMatch(match *models.Match, homeTeam, awayTeam *models.Team, seed int64) error {
 	if match.Played {
 		return nil
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	homeAdvantage := 10
-	homeChance := float64(homeTeam.Strength+homeAdvantage) / float64(homeTeam.Strength+awayTeam.Strength+homeAdvantage)
-
-	homeGoals := 0
-	awayGoals := 0
-
-	// Her takım için 5'er şut, her şut için gol olup olmadığını kontrol et
-	for i := 0; i < 5; i++ {
-		if rand.Float64() < homeChance {
-			homeGoals++
-		}
-		if rand.Float64() < (1 - homeChance) {
-			awayGoals++
-		}
-	}
+	homeGoals, awayGoals := s.engine.SimulateScore(newRand(seed), homeTeam, awayTeam)
 
 	match.HomeGoals = homeGoals
 	match.AwayGoals = awayGoals
 	match.Played = true
-	if err := s.matchRepo.UpdateMatch(match); err != nil {
-		return err
-	}
-
-	homeTeam.MatchesPlayed++
-	awayTeam.MatchesPlayed++
-
-	homeTeam.GoalsFor += homeGoals
-	homeTeam.GoalsAgainst += awayGoals
-	awayTeam.GoalsFor += awayGoals
-	awayTeam.GoalsAgainst += homeGoals
-
-	if homeGoals > awayGoals {
-		homeTeam.Wins++
-		awayTeam.Loses++
-		homeTeam.Points += 3
-	} else if homeGoals < awayGoals {
-		homeTeam.Loses++
-		awayTeam.Wins++
-		awayTeam.Points += 3
-	} else {
-		homeTeam.Draws++
-		awayTeam.Draws++
-		homeTeam.Points += 1
-		awayTeam.Points += 1
-	}
-
-	if err := s.teamRepo.UpdateTeam(homeTeam); err != nil {
-		return err
-	}
-	if err := s.teamRepo.UpdateTeam(awayTeam); err != nil {
-		return err
-	}
-
+	match.Seed = seed
 	return nil
 }
 
-func (s *matchService) GetMatchesByWeek(week int) ([]models.Match, error) {
-	return s.matchRepo.GetMatchesByWeek(week)
+func (s *matchService) GetMatchesByWeek(seasonID, week int) ([]models.Match, error) {
+	return s.matchRepo.GetMatchesByWeek(seasonID, week)
 }
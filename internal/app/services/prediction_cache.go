@@ -0,0 +1,99 @@
+package services
+
+import (
+	"cmp"
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+// maxPredictionCacheEntries aynı lig durumu için saklanacak en fazla farklı istek sayısıdır (seed, simülasyon sayısı vb.).
+// Sınır aşıldığında önbellek tamamen temizlenir.
+const maxPredictionCacheEntries = 64
+
+// predictionCache Monte Carlo tahmin sonuçlarını lig durumuna ve istek ayarlarına göre saklar.
+// Lig durumu, takımların ve maçların özetinden (hash) hesaplandığından veritabanı dışarıdan
+// değişse bile eski bir sonuç dönmez; yine de durumu değiştiren işlemler önbelleği açıkça temizler.
+type predictionCache struct {
+	mu      sync.Mutex
+	entries map[predictionCacheKey]models.PredictionResult
+}
+
+type predictionCacheKey struct {
+	seasonID         int
+	state            uint64
+	engine           string
+	tiebreakers      string
+	simulations      int
+	seeded           bool // false ise tohum rastgeledir ve aynı durum için herhangi bir sonuç kullanılabilir
+	seed             int64
+	topN             int
+	relegationPlaces int
+	promotionPlaces  int
+	playoffs         models.PlayoffFormat // Ligin play-off ayarı; play-off yoksa sıfır değer
+	playoffPrize     models.PlayoffPrize  // Sezonun başlamış play-off'unun ödülü
+}
+
+func newPredictionCache() *predictionCache {
+	return &predictionCache{entries: make(map[predictionCacheKey]models.PredictionResult)}
+}
+
+func (c *predictionCache) get(key predictionCacheKey) (models.PredictionResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, ok := c.entries[key]
+	return result, ok
+}
+
+func (c *predictionCache) put(key predictionCacheKey, result models.PredictionResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxPredictionCacheEntries {
+		clear(c.entries)
+	}
+	c.entries[key] = result
+}
+
+// invalidate lig durumu değiştiğinde (hafta oynandığında, lig sıfırlandığında, sonuç düzeltildiğinde) çağrılır.
+func (c *predictionCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// leagueStateHash tahminleri etkileyen lig durumunun (takım güçleri ve maç sonuçları) özetini hesaplar.
+// Puanlar maç sonuçlarından hesaplandığından takım sayaçları özete katılmaz.
+// Takımlar ve maçlar kimliğe göre sıralanarak depodan gelen sıradan bağımsız hale getirilir.
+func leagueStateHash(teams []models.Team, matches []models.Match) uint64 {
+	h := fnv.New64a()
+	write := func(values ...int) {
+		var buf [8]byte
+		for _, v := range values {
+			binary.LittleEndian.PutUint64(buf[:], uint64(v))
+			h.Write(buf[:])
+		}
+	}
+
+	sortedTeams := slices.Clone(teams)
+	slices.SortFunc(sortedTeams, func(a, b models.Team) int { return cmp.Compare(a.ID, b.ID) })
+	for _, t := range sortedTeams {
+		write(t.ID, t.Strength, t.Attack, t.Defense, t.FairPlayPoints)
+	}
+
+	sortedMatches := slices.Clone(matches)
+	slices.SortFunc(sortedMatches, func(a, b models.Match) int { return cmp.Compare(a.ID, b.ID) })
+	for _, m := range sortedMatches {
+		played := 0
+		if m.Played {
+			played = 1
+		}
+		write(m.ID, m.HomeTeamID, m.AwayTeamID, m.Week, played, m.HomeGoals, m.AwayGoals)
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/scheduler"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// ScoreSampler tek bir eşleşme için önceden hazırlanmış skor üreticisidir.
+type ScoreSampler func(rng *rand.Rand) (homeGoals, awayGoals int)
+
+// PreparableEngine, aynı eşleşme binlerce kez simüle edileceğinde beklenen golleri ve olasılık
+// tablolarını bir kez hesaplayıp tekrar kullanabilen motorlardır. Bu arayüzü uygulamayan motorlar
+// için SimulateScore doğrudan çağrılır.
+type PreparableEngine interface {
+	Prepare(homeTeam, awayTeam *models.Team) ScoreSampler
+}
+
+// seasonSimulator Monte Carlo tahminleri için kalan maçları, depo kopyaları oluşturmadan
+// takım indeksleriyle çalışan dizilerde simüle eder. Bir kez oluşturulur ve yalnızca okunduğu
+// için birden çok işçi tarafından aynı anda kullanılabilir; her işçi kendi simState'ini tutar.
+type seasonSimulator struct {
+	teams       []models.Team // Kimliğe göre sıralı
+	index       map[int]int   // Takım kimliğinden teams indeksine
+	baseRows    []standings.Row
+	baseResults []standings.Result // Oynanmış maçlar; ikili ölçütler için simüle edilen sonuçlar bunlara eklenir
+	fixtures    []simFixture       // Oynanmamış maçlar
+	rules       standings.Rules
+	upper       []bool    // Tablo bölündüyse üst gruptaki takımlar; sıralamada alt gruptakilerin önünde yer alırlar
+	split       *simSplit // Tablo normal sezonun sonunda bölünecekse bölünmenin maçları
+}
+
+// simSplit henüz başlamamış bir bölünmedir. Gruplar her simülasyonun normal sezon sıralamasından
+// kurulduğundan fikstür takımlara değil sıralamadaki yerlere göre tutulur.
+type simSplit struct {
+	place    int
+	fixtures []scheduler.Fixture // Takım kimlikleri yerine 1'den başlayan sıralama yerleri
+	samplers [][]ScoreSampler    // [ev sahibi][deplasman] takım indeksleri
+}
+
+type simFixture struct {
+	home, away int // teams dizisindeki indeksler
+	sample     ScoreSampler
+}
+
+// simState tek bir işçinin simülasyon başına sıfırlanan çalışma alanıdır.
+type simState struct {
+	rows    []standings.Row // teams ile aynı sırada
+	results []standings.Result
+	ranked  []standings.Row
+	order   []int  // Sezon sonu sıralaması (takım indeksleri)
+	upper   []bool // Tablo bölündüyse üst gruptaki takımlar
+	split   []int  // Bölünme anındaki sıralama
+}
+
+func newSeasonSimulator(teams []models.Team, matches []models.Match, engine MatchEngine, rules standings.Rules) (*seasonSimulator, error) {
+	sorted := slices.Clone(teams)
+	slices.SortFunc(sorted, func(a, b models.Team) int { return cmp.Compare(a.ID, b.ID) })
+
+	sim := &seasonSimulator{
+		teams: sorted,
+		index: make(map[int]int, len(sorted)),
+		rules: rules,
+	}
+	for i, team := range sorted {
+		sim.index[team.ID] = i
+	}
+	sim.baseResults = playedResults(matches, 0)
+	sim.baseRows = standingsRows(sorted, sim.baseResults)
+
+	// Aynı tohumla aynı sonucun alınması için maçlar depodan gelen sıradan bağımsız, sabit bir sırada oynatılır.
+	ordered := slices.Clone(matches)
+	slices.SortFunc(ordered, func(a, b models.Match) int {
+		if c := cmp.Compare(a.Week, b.Week); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	for _, match := range ordered {
+		if match.Played {
+			continue
+		}
+		home, okHome := sim.index[match.HomeTeamID]
+		away, okAway := sim.index[match.AwayTeamID]
+		if !okHome || !okAway {
+			return nil, fmt.Errorf("match %d references unknown team", match.ID)
+		}
+		sim.fixtures = append(sim.fixtures, simFixture{
+			home:   home,
+			away:   away,
+			sample: prepareScore(engine, &sim.teams[home], &sim.teams[away]),
+		})
+	}
+	return sim, nil
+}
+
+func (sim *seasonSimulator) newState() *simState {
+	n := len(sim.teams)
+	size := len(sim.baseResults) + len(sim.fixtures)
+	if sim.split != nil {
+		size += len(sim.split.fixtures)
+	}
+	st := &simState{
+		rows:    make([]standings.Row, n),
+		results: append(make([]standings.Result, 0, size), sim.baseResults...),
+		ranked:  make([]standings.Row, n),
+		order:   make([]int, n),
+	}
+	switch {
+	case sim.split != nil:
+		st.upper = make([]bool, n)
+		st.split = make([]int, n)
+	case sim.upper != nil:
+		st.upper = slices.Clone(sim.upper)
+	}
+	return st
+}
+
+// withSplit simülatörü ligin bölünmesine göre hazırlar. Sezonun tablosu bölündüyse gruplar sabittir;
+// lig bölünmeyle oynuyor ve tablo henüz bölünmediyse bölünme her simülasyonda normal sezonun ardından
+// oynatılır. startSplit gibi, gruplardan birinde ikiden az takım kalacaksa tablo bölünmez.
+func (sim *seasonSimulator) withSplit(league *models.LeagueState, season *models.Season, engine MatchEngine) error {
+	n := len(sim.teams)
+	if season.Split != nil {
+		sim.upper = make([]bool, n)
+		for _, teamID := range season.Split.UpperTeamIDs {
+			if i, ok := sim.index[teamID]; ok {
+				sim.upper[i] = true
+			}
+		}
+		return nil
+	}
+	format := league.Playoffs
+	if !isSplit(format) || league.Status == models.LeagueStatusCompleted || format.SplitPlace < 2 || format.SplitPlace > n-2 {
+		return nil
+	}
+
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = i + 1
+	}
+	fixtures, err := splitFixtures(positions, format.SplitPlace, format.Legs)
+	if err != nil {
+		return err
+	}
+	split := &simSplit{place: format.SplitPlace, fixtures: fixtures, samplers: make([][]ScoreSampler, n)}
+	for home := range sim.teams {
+		split.samplers[home] = make([]ScoreSampler, n)
+		for away := range sim.teams {
+			if home != away {
+				split.samplers[home][away] = prepareScore(engine, &sim.teams[home], &sim.teams[away])
+			}
+		}
+	}
+	sim.split = split
+	return nil
+}
+
+// run kalan maçları bir kez oynatır ve st.order'a ligin sıralama kurallarına göre sezon sonu sıralamasını yazar.
+// Tablo bölünecekse normal sezonun sıralamasından gruplar kurulur, bölünmenin maçları oynatılır ve
+// st.order bölünmeden sonraki final sıralaması olur.
+func (sim *seasonSimulator) run(rng *rand.Rand, st *simState) {
+	copy(st.rows, sim.baseRows)
+
+	st.results = st.results[:len(sim.baseResults)]
+	for _, f := range sim.fixtures {
+		homeGoals, awayGoals := f.sample(rng)
+		st.play(f.home, f.away, homeGoals, awayGoals)
+	}
+
+	// Kura çekimi her simülasyonda yeniden yapılır.
+	rules := sim.rules
+	rules.LotSeed = rng.Int63()
+	if sim.split == nil {
+		sim.rank(st, rules)
+		return
+	}
+	// Gruplar henüz kurulmadığından normal sezonun sıralamasında önceki simülasyonun grupları kullanılmaz.
+	clear(st.upper)
+	sim.rank(st, rules)
+	copy(st.split, st.order)
+	for pos, team := range st.split {
+		st.upper[team] = pos < sim.split.place
+	}
+	for _, f := range sim.split.fixtures {
+		home, away := st.split[f.HomeTeamID-1], st.split[f.AwayTeamID-1]
+		homeGoals, awayGoals := sim.split.samplers[home][away](rng)
+		st.play(home, away, homeGoals, awayGoals)
+	}
+	sim.rank(st, rules)
+}
+
+// play maçın sonucunu st.results'a ekler ve iki takımın satırına işler.
+func (st *simState) play(home, away, homeGoals, awayGoals int) {
+	result := standings.Result{
+		HomeTeamID: st.rows[home].TeamID,
+		AwayTeamID: st.rows[away].TeamID,
+		HomeGoals:  homeGoals,
+		AwayGoals:  awayGoals,
+	}
+	st.results = append(st.results, result)
+	standings.Apply(&st.rows[home], &st.rows[away], result)
+}
+
+// rank st.order'a güncel satırların sıralamasını yazar; tablo bölündüyse üst grup önde kalır (bkz. rankSeason).
+func (sim *seasonSimulator) rank(st *simState, rules standings.Rules) {
+	copy(st.ranked, st.rows)
+	standings.Sort(st.ranked, st.results, rules)
+	if st.upper != nil {
+		slices.SortStableFunc(st.ranked, func(a, b standings.Row) int {
+			return splitRank(st.upper[sim.index[a.TeamID]]) - splitRank(st.upper[sim.index[b.TeamID]])
+		})
+	}
+	for pos, row := range st.ranked {
+		st.order[pos] = sim.index[row.TeamID]
+	}
+}
+
+// splitRank üst gruptaki takımlar için 0, alt gruptakiler için 1 döndürür.
+func splitRank(upper bool) int {
+	if upper {
+		return 0
+	}
+	return 1
+}
+
+// prepareScore motor destekliyorsa eşleşmeye özel bir örnekleyici hazırlar.
+func prepareScore(engine MatchEngine, homeTeam, awayTeam *models.Team) ScoreSampler {
+	if p, ok := engine.(PreparableEngine); ok {
+		return p.Prepare(homeTeam, awayTeam)
+	}
+	return func(rng *rand.Rand) (int, int) {
+		return engine.SimulateScore(rng, homeTeam, awayTeam)
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"math/rand"
+	"time"
+)
+
+// deriveSeed bir ana tohumdan ve ek bileşenlerden (hafta, takım kimlikleri, simülasyon sırası vb.)
+// yeni bir tohum türetir. SplitMix64 karıştırıcısı kullanıldığından yakın girdiler birbirinden
+// bağımsız görünen tohumlar üretir ve aynı girdiler her zaman aynı tohumu verir.
+func deriveSeed(seed int64, parts ...int64) int64 {
+	x := uint64(seed)
+	for _, p := range parts {
+		x = splitMix64(x ^ splitMix64(uint64(p)))
+	}
+	return int64(x)
+}
+
+const splitMixGamma = 0x9e3779b97f4a7c15
+
+func splitMix64(x uint64) uint64 {
+	x += splitMixGamma
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// resolveSeed isteğin tohumunu, yoksa ligin tohumunu, o da yoksa zamana bağlı yeni bir tohum döndürür.
+func resolveSeed(requestSeed, leagueSeed *int64) int64 {
+	if requestSeed != nil {
+		return *requestSeed
+	}
+	if leagueSeed != nil {
+		return *leagueSeed
+	}
+	return time.Now().UnixNano()
+}
+
+// newRand verilen tohumla, yalnızca tek bir simülasyona ait bir rastgele sayı üreteci oluşturur.
+func newRand(seed int64) *rand.Rand {
+	src := &splitMixSource{}
+	src.Seed(seed)
+	return rand.New(src)
+}
+
+// splitMixSource math/rand için hafif bir rand.Source64 uygulamasıdır. Varsayılan kaynağın aksine
+// tohumlama maliyetsizdir; Monte Carlo işçileri aynı üreteci her simülasyon için yeniden tohumlayabilir.
+type splitMixSource struct {
+	state uint64
+}
+
+func (s *splitMixSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *splitMixSource) Uint64() uint64 {
+	z := splitMix64(s.state)
+	s.state += splitMixGamma
+	return z
+}
+
+func (s *splitMixSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
@@ -1,25 +1,281 @@
 package services
 
-import "github.com/muzaffertuna/football-league-sim/internal/app/models"
+import (
+	"context"
+	"fmt"
+	"strings"
 
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// TeamService takımları yönetir. Takım adları tüm ligler arasında benzersizdir. Yeni takımlar bir lige bağlı
+// olmadan oluşturulur; liglerin kadroları LeagueService.SetLeagueRoster ile değiştirilir.
 type TeamService interface {
-	CreateTeam(name string, strength int) (*models.Team, error)
+	CreateTeam(input NewTeam) (*models.Team, error)
 	GetTeamByID(id int) (*models.Team, error)
 	GetAllTeams() ([]models.Team, error)
+	UpdateTeam(id int, input NewTeam) (*models.Team, error)
+	DeleteTeam(id int) error
 }
 
 type MatchService interface {
-	CreateMatch(homeTeamID, awayTeamID, week int) (*models.Match, error)
-	SimulateMatch(match *models.Match, homeTeam, awayTeam *models.Team) error
-	GetMatchesByWeek(week int) ([]models.Match, error)
+	CreateMatch(leagueID, seasonID, homeTeamID, awayTeamID, week int) (*models.Match, error)
+	SimulateMatch(match *models.Match, homeTeam, awayTeam *models.Team, seed int64) error
+	Engine() MatchEngine
+	WithEngine(engine MatchEngine) MatchService
+	GetMatchesByWeek(seasonID, week int) ([]models.Match, error)
 }
 
+// DefaultLeagueID lig kimliği yerine verildiğinde varsayılan ligi (en küçük kimlikli lig) seçer.
+// Lig kimliği içermeyen eski uç noktalar bu ligi kullanır.
+const DefaultLeagueID = 0
+
+// LeagueService ligleri yönetir. Lig kimliği alan tüm işlemler yalnızca o ligin takımlarına ve maçlarına
+// dokunur; ligler birbirinden bağımsız oynatılır.
 type LeagueService interface {
-	PlayWeek(week int) error
-	GetLeagueTable() (*models.League, error)
-	ResetLeague() error
-	GetMatchesByWeek(week int) ([]models.Match, error)
+	CreateLeague(input NewLeague) (*models.LeagueState, error)
+	GetLeagues() ([]models.LeagueState, error)
+	PlayWeek(leagueID, week int, seed *int64) error
+	GetLeagueTable(ctx context.Context, leagueID int, opts LeagueTableOptions) (*models.League, error)
+	ResetLeague(leagueID int) error
+	GetMatchesByWeek(leagueID, week int) ([]models.Match, error)
+	GetFixtures(leagueID int, query FixtureQuery) (*models.Fixtures, error)
+	GetWeekResults(ctx context.Context, leagueID, week int) (*models.WeekResults, error)
+	SetMatchResult(matchID, homeGoals, awayGoals int) (*models.Match, error)
 	GetTeamByID(id int) (*models.Team, error)
-	GetCurrentWeek() (int, error)
-	SimulateAllWeeks() ([]models.Match, error)
+	GetCurrentWeek(leagueID int) (int, error)
+	GetTotalWeeks(leagueID int) (int, error)
+	SimulateAllWeeks(leagueID int, seed *int64) ([]models.Match, error)
+	PredictOutcomes(ctx context.Context, leagueID int, opts PredictionOptions) (models.PredictionResult, error)
+	GetSeasons(leagueID int) ([]models.Season, error)
+	GetAllTimeTable(leagueID int) (*models.AllTimeTable, error)
+	SetLeagueTier(leagueID int, tier LeagueTier) (*models.LeagueState, error)
+	SetLeaguePlayoffs(leagueID int, format *models.PlayoffFormat) (*models.LeagueState, error)
+	SetLeagueRoster(leagueID int, teamIDs []int) (*models.LeagueState, error)
+	SetLeagueMatchEngine(leagueID int, name string) (*models.LeagueState, error)
+	RolloverSeason(leagueID int) (*models.Rollover, error)
+}
+
+// CupService eleme usulü kupaları yönetir. Kupalar liglerden bağımsızdır; herhangi bir ligin takımları katılabilir.
+// Her tur önce çekilir (DrawNextRound), sonra oynatılır (PlayRound).
+type CupService interface {
+	CreateCup(input NewCup) (*models.Cup, error)
+	GetCups() ([]models.Cup, error)
+	GetBracket(cupID int) (*models.CupBracket, error)
+	DrawNextRound(cupID int, seed *int64) (*models.CupRound, error)
+	PlayRound(cupID int, seed *int64) (*models.CupRound, error)
+}
+
+// NewCup oluşturulacak bir kupanın bilgilerini taşır. TeamIDs seri başı sırasıyla verilir: ilk takım
+// birinci seri başıdır. Legs 0 ise eşleşmeler tek maç, Draw boşsa seri başlı kura kullanılır.
+type NewCup struct {
+	Name    string
+	TeamIDs []int
+	Legs    int
+	Draw    models.CupDraw
+	Seed    *int64
+}
+
+// TournamentService grup aşaması ve eleme aşamasıyla oynanan turnuvaları yönetir. Grup aşamasında her
+// PlayNextRound çağrısı tüm grupların sıradaki maç gününü, eleme aşamasında ise sıradaki turu oynatır.
+type TournamentService interface {
+	CreateTournament(input NewTournament) (*models.Tournament, error)
+	GetTournaments() ([]models.Tournament, error)
+	GetTournament(id int) (*models.Tournament, error)
+	PlayNextRound(id int, seed *int64) (*models.Tournament, error)
+	PredictStages(ctx context.Context, id int, opts PredictionOptions) (models.TournamentPrediction, error)
+}
+
+// NewTournament oluşturulacak bir turnuvanın bilgilerini taşır. Groups her grubun takım kimlikleridir;
+// grup sayısı ikinin kuvveti olmalıdır ki grup birincileri ve ikincileri tam bir eleme ağacı oluştursun.
+// GroupLegs ve KnockoutLegs 0 ise 1, Tiebreakers nil ise varsayılan ölçütler kullanılır.
+type NewTournament struct {
+	Name         string
+	Groups       [][]int
+	GroupLegs    int
+	KnockoutLegs int
+	Tiebreakers  []standings.Tiebreaker
+	Seed         *int64
+}
+
+// NewLeague oluşturulacak bir ligin bilgilerini taşır. Tiebreakers ve Seed nil ise sunucunun
+// varsayılanları (İsviçre usulü liglerde standings.SwissTiebreakers) kullanılır; Playoffs nil ise lig
+// play-off oynamaz, Swiss nil ise çift devreli fikstür üretilir.
+type NewLeague struct {
+	Name        string
+	Teams       []NewTeam
+	Tiebreakers []standings.Tiebreaker
+	Seed        *int64
+	Tier        LeagueTier
+	Playoffs    *models.PlayoffFormat
+	Swiss       *models.SwissFormat
+	MatchEngine string // Boşsa lig sunucunun varsayılan motorunu kullanır
+}
+
+// LeagueTier bir ligin piramitteki yeridir: bağlı olduğu üst lig ve sezon sonunda lig değiştiren takım sayıları.
+type LeagueTier struct {
+	ParentLeagueID   *int
+	PromotionPlaces  int // Üst lige çıkan takım sayısı; üst lig yoksa 0 olmalıdır
+	RelegationPlaces int // Alt lige düşen takım sayısı; alt lig bağlanana kadar kullanılmaz
+}
+
+// NewTeam yeni bir ligle birlikte oluşturulacak bir takımdır. Attack ve Defense 0 ise maç motorları Strength'i kullanır.
+// Pot ve Country yalnızca İsviçre usulü liglerde kullanılır.
+type NewTeam struct {
+	Name     string
+	Strength int
+	Attack   int
+	Defense  int
+	Pot      int
+	Country  string
+}
+
+func (l NewLeague) validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidLeague)
+	}
+	if len(l.Teams) < 2 {
+		return fmt.Errorf("%w: at least 2 teams are required, got %d", ErrInvalidLeague, len(l.Teams))
+	}
+	names := make(map[string]bool, len(l.Teams))
+	for _, t := range l.Teams {
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidLeague, err)
+		}
+		// Takım adları büyük/küçük harf farkı gözetmeden benzersizdir.
+		name := strings.ToLower(strings.TrimSpace(t.Name))
+		if names[name] {
+			return fmt.Errorf("%w: duplicate team name %q", ErrInvalidLeague, strings.TrimSpace(t.Name))
+		}
+		names[name] = true
+	}
+	if err := validateEngineName(l.MatchEngine); err != nil {
+		return err
+	}
+	if l.Swiss != nil {
+		return validateSwiss(l.Swiss, l.Teams)
+	}
+	return nil
+}
+
+// Takım güçlerinin (Strength, Attack, Defense) geçerli aralığı.
+const (
+	minTeamRating = 1
+	maxTeamRating = 100
+)
+
+func (t NewTeam) validate() error {
+	name := strings.TrimSpace(t.Name)
+	if name == "" {
+		return fmt.Errorf("team name is required")
+	}
+	if t.Strength < minTeamRating || t.Strength > maxTeamRating {
+		return fmt.Errorf("strength of team %q must be between %d and %d, got %d", name, minTeamRating, maxTeamRating, t.Strength)
+	}
+	// Attack ve Defense 0 ise Strength kullanılır.
+	for _, rating := range []int{t.Attack, t.Defense} {
+		if rating != 0 && (rating < minTeamRating || rating > maxTeamRating) {
+			return fmt.Errorf("attack and defense of team %q must be 0 or between %d and %d", name, minTeamRating, maxTeamRating)
+		}
+	}
+	if t.Pot < 0 {
+		return fmt.Errorf("pot of team %q must not be negative, got %d", name, t.Pot)
+	}
+	return nil
+}
+
+// apply takımın bilgilerini team'e yazar; takımın ligi ve puan durumu değişmez.
+func (t NewTeam) apply(team *models.Team) {
+	team.Name = strings.TrimSpace(t.Name)
+	team.Strength = t.Strength
+	team.Attack = t.Attack
+	team.Defense = t.Defense
+	team.Pot = t.Pot
+	team.Country = strings.TrimSpace(t.Country)
+}
+
+// PredictionOptions bir Monte Carlo tahmin isteğinin ayarlarını taşır.
+type PredictionOptions struct {
+	Simulations      int
+	Seed             *int64
+	TopN             int // İlk N olasılığı için N; 0 ise varsayılan kullanılır
+	RelegationPlaces int // Küme düşme hattındaki takım sayısı; 0 ise varsayılan kullanılır
+}
+
+const (
+	defaultTopN             = 4
+	defaultRelegationPlaces = 3
+)
+
+// zones ilk N ve küme düşme hattının büyüklüklerini takım sayısına göre belirler.
+// Varsayılanlar küçük liglerde anlamlı kalacak şekilde (ilk N en fazla yarı, küme düşme en fazla çeyrek) sınırlanır.
+func (o PredictionOptions) zones(numTeams int) (topN, relegationPlaces int) {
+	topN = o.TopN
+	if topN <= 0 {
+		topN = min(defaultTopN, max(numTeams/2, 1))
+	}
+	relegationPlaces = o.RelegationPlaces
+	if relegationPlaces <= 0 {
+		relegationPlaces = min(defaultRelegationPlaces, max(numTeams/4, 1))
+	}
+	return min(topN, numTeams), min(relegationPlaces, numTeams)
+}
+
+// LeagueTableOptions puan durumu isteğinin ayarlarını taşır.
+type LeagueTableOptions struct {
+	IncludePredictions bool   // Şampiyonluk tahminleri de hesaplansın mı
+	Seed               *int64 // Tahminler için rastgele sayı tohumu
+	AsOfWeek           int    // 0'dan büyükse puan durumu bu haftanın sonundaki haliyle hesaplanır
+	Season             int    // 0'dan büyükse bu numaralı sezonun tablosu döner; 0 ise güncel sezon
+}
+
+// FixtureQuery fikstür isteğinin ölçütlerini taşır. Sıfır değerli alanlar filtre uygulamaz.
+type FixtureQuery struct {
+	Season     int               // 0'dan büyükse bu numaralı sezonun fikstürü döner; 0 ise güncel sezon
+	Week       int               // 0'dan büyükse yalnızca bu haftanın maçları
+	TeamID     int               // 0'dan büyükse yalnızca bu takımın maçları
+	Venue      models.MatchVenue // TeamID ile birlikte takımın yalnızca iç saha ya da deplasman maçları
+	Played     *bool             // Yalnızca oynanmış ya da oynanmamış maçlar
+	Sort       models.MatchSort  // Boşsa haftaya göre
+	Descending bool
+	Limit      int // 0 ise defaultFixtureLimit
+	Offset     int
+}
+
+const (
+	defaultFixtureLimit = 50
+	maxFixtureLimit     = 500
+)
+
+func (q FixtureQuery) validate() error {
+	switch q.Venue {
+	case "", models.MatchVenueHome, models.MatchVenueAway:
+	default:
+		return fmt.Errorf("%w: unknown venue %q, expected home or away", ErrInvalidFixtureQuery, q.Venue)
+	}
+	if q.Venue != "" && q.TeamID == 0 {
+		return fmt.Errorf("%w: venue requires a team", ErrInvalidFixtureQuery)
+	}
+	switch q.Sort {
+	case "", models.MatchSortWeek, models.MatchSortID:
+	default:
+		return fmt.Errorf("%w: unknown sort %q, expected week or id", ErrInvalidFixtureQuery, q.Sort)
+	}
+	if q.Limit < 0 || q.Limit > maxFixtureLimit {
+		return fmt.Errorf("%w: limit must be between 0 and %d (0 = default %d), got %d", ErrInvalidFixtureQuery, maxFixtureLimit, defaultFixtureLimit, q.Limit)
+	}
+	if q.Season < 0 || q.Week < 0 || q.TeamID < 0 || q.Offset < 0 {
+		return fmt.Errorf("%w: season, week, team and offset must not be negative", ErrInvalidFixtureQuery)
+	}
+	return nil
+}
+
+// LeagueSettings lig servisinin başlangıç ayarlarını taşır. Seed ve Tiebreakers verilirse
+// Leagues tablosundaki kayıtlı değerlerin yerine yazılır; nil ise kayıtlı değerler korunur.
+type LeagueSettings struct {
+	Seed        *int64                 // Ligin rastgele sayı tohumu; kayıtlı tohum da yoksa her istek için yeni tohum üretilir
+	Tiebreakers []standings.Tiebreaker // Puan eşitliğinde sırayla uygulanan ölçütler
+	// MaterializeStandings true ise maç sonuçlarından hesaplanan puan durumu Teams tablosuna da yazılır
+	MaterializeStandings bool
 }
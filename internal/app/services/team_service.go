@@ -1,37 +1,163 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/muzaffertuna/football-league-sim/internal/app/models"
 	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
 )
 
 type teamService struct {
 	teamRepo repositories.TeamRepository
+	uow      repositories.UnitOfWork
 }
 
-func NewTeamService(teamRepo repositories.TeamRepository) TeamService {
-	return &teamService{teamRepo: teamRepo}
+func NewTeamService(teamRepo repositories.TeamRepository, uow repositories.UnitOfWork) TeamService {
+	return &teamService{teamRepo: teamRepo, uow: uow}
 }
 
-func (s *teamService) CreateTeam(name string, strength int) (*models.Team, error) {
-	team := &models.Team{
-		Name:          name,
-		Strength:      strength,
-		Points:        0,
-		GoalsFor:      0,
-		GoalsAgainst:  0,
-		MatchesPlayed: 0,
+// CreateTeam bir lige bağlı olmayan yeni bir takım oluşturur. Takım kupalarda ve turnuvalarda hemen
+// kullanılabilir; bir lige SetLeagueRoster ile katılır.
+func (s *teamService) CreateTeam(input NewTeam) (*models.Team, error) {
+	if err := input.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTeam, err)
 	}
-	if err := s.teamRepo.CreateTeam(team); err != nil {
+
+	team := &models.Team{}
+	input.apply(team)
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		if err := checkTeamName(repos.Teams, team.Name, 0); err != nil {
+			return err
+		}
+		return teamNameError(repos.Teams.CreateTeam(team), team.Name)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return team, nil
 }
 
+// GetTeamByID takımı döndürür; takım yoksa ErrTeamNotFound döner.
 func (s *teamService) GetTeamByID(id int) (*models.Team, error) {
-	return s.teamRepo.GetTeamByID(id)
+	return findTeam(s.teamRepo, id)
 }
 
 func (s *teamService) GetAllTeams() ([]models.Team, error) {
 	return s.teamRepo.GetAllTeams()
 }
+
+// UpdateTeam takımın adını, güçlerini, torbasını ve ülkesini günceller. Güçler sonraki maçlardan ve
+// tahminlerden itibaren kullanılır; takımın ligi ve puan durumu değişmez.
+func (s *teamService) UpdateTeam(id int, input NewTeam) (*models.Team, error) {
+	if err := input.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTeam, err)
+	}
+
+	var team *models.Team
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		var err error
+		if team, err = findTeam(repos.Teams, id); err != nil {
+			return err
+		}
+		input.apply(team)
+		if err := checkTeamName(repos.Teams, team.Name, team.ID); err != nil {
+			return err
+		}
+		return teamNameError(repos.Teams.UpdateTeam(team), team.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// DeleteTeam takımı siler. Fikstürde maçı olan, bir ligde oynayan ya da bir ligin sonraki kadrosunda, bir
+// kupada veya turnuvada yer alan takımlar silinemez; bu durumda ErrTeamInUse döner.
+func (s *teamService) DeleteTeam(id int) error {
+	return s.uow.Do(func(repos repositories.Repositories) error {
+		team, err := findTeam(repos.Teams, id)
+		if err != nil {
+			return err
+		}
+		if team.LeagueID != 0 {
+			return fmt.Errorf("%w: team %d plays in league %d", ErrTeamInUse, team.ID, team.LeagueID)
+		}
+		matches, err := repos.Matches.CountMatchesByTeam(team.ID)
+		if err != nil {
+			return err
+		}
+		if matches > 0 {
+			return fmt.Errorf("%w: team %d has %d matches in its fixtures", ErrTeamInUse, team.ID, matches)
+		}
+
+		leagues, err := repos.Leagues.GetAllLeagues()
+		if err != nil {
+			return err
+		}
+		for _, league := range leagues {
+			if slices.Contains(league.NextRoster, team.ID) {
+				return fmt.Errorf("%w: team %d is in the next roster of league %d", ErrTeamInUse, team.ID, league.ID)
+			}
+		}
+		cups, err := repos.Cups.GetAllCups()
+		if err != nil {
+			return err
+		}
+		for _, cup := range cups {
+			if slices.Contains(cup.TeamIDs, team.ID) {
+				return fmt.Errorf("%w: team %d takes part in cup %d", ErrTeamInUse, team.ID, cup.ID)
+			}
+		}
+		tournaments, err := repos.Tournaments.GetAllTournaments()
+		if err != nil {
+			return err
+		}
+		for _, tournament := range tournaments {
+			for _, group := range tournament.Groups {
+				if slices.Contains(group.TeamIDs, team.ID) {
+					return fmt.Errorf("%w: team %d takes part in tournament %d", ErrTeamInUse, team.ID, tournament.ID)
+				}
+			}
+		}
+
+		return repos.Teams.DeleteTeam(team.ID)
+	})
+}
+
+// findTeam takımı döndürür; takım yoksa ErrTeamNotFound döner.
+func findTeam(teamRepo repositories.TeamRepository, id int) (*models.Team, error) {
+	team, err := teamRepo.GetTeamByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("%w: %d", ErrTeamNotFound, id)
+	}
+	return team, nil
+}
+
+// checkTeamName adın (büyük/küçük harf farkı gözetmeden) exceptID dışındaki hiçbir takımda kullanılmadığını denetler.
+func checkTeamName(teamRepo repositories.TeamRepository, name string, exceptID int) error {
+	teams, err := teamRepo.GetAllTeams()
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		if team.ID != exceptID && strings.EqualFold(team.Name, name) {
+			return fmt.Errorf("%w: team name %q is already used by team %d", ErrInvalidTeam, name, team.ID)
+		}
+	}
+	return nil
+}
+
+// teamNameError aynı adı eşzamanlı olarak kaydeden iki isteğin checkTeamName'i birlikte geçmesi halinde
+// veritabanının benzersiz dizininden dönen hatayı ErrInvalidTeam'e çevirir.
+func teamNameError(err error, name string) error {
+	if errors.Is(err, repositories.ErrDuplicateName) {
+		return fmt.Errorf("%w: team name %q is already used", ErrInvalidTeam, name)
+	}
+	return err
+}
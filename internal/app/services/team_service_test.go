@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+)
+
+func TestTeamNamesAreUnique(t *testing.T) {
+	repos := newTestRepos()
+	svc := NewTeamService(repos.teams, repos.uow)
+	leagueSvc := repos.leagueService(t, LeagueSettings{})
+
+	first, err := svc.CreateTeam(NewTeam{Name: "Rovers", Strength: 50})
+	if err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	other, err := svc.CreateTeam(NewTeam{Name: "United", Strength: 50})
+	if err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	if _, err := svc.CreateTeam(NewTeam{Name: " ROVERS ", Strength: 60}); !errors.Is(err, ErrInvalidTeam) {
+		t.Errorf("creating a team with a used name: err = %v, want ErrInvalidTeam", err)
+	}
+	if _, err := svc.UpdateTeam(other.ID, NewTeam{Name: "rovers", Strength: 60}); !errors.Is(err, ErrInvalidTeam) {
+		t.Errorf("renaming a team to a used name: err = %v, want ErrInvalidTeam", err)
+	}
+	if _, err := svc.UpdateTeam(first.ID, NewTeam{Name: "ROVERS", Strength: 60}); err != nil {
+		t.Errorf("changing the case of a team's own name: %v", err)
+	}
+	if _, err := leagueSvc.CreateLeague(NewLeague{Name: "League", Teams: []NewTeam{
+		{Name: "City", Strength: 50}, {Name: "united", Strength: 50},
+	}}); !errors.Is(err, ErrInvalidLeague) {
+		t.Errorf("creating a league with a used team name: err = %v, want ErrInvalidLeague", err)
+	}
+	if _, err := leagueSvc.CreateLeague(NewLeague{Name: "League", Teams: []NewTeam{
+		{Name: "City", Strength: 50}, {Name: "CITY", Strength: 50},
+	}}); !errors.Is(err, ErrInvalidLeague) {
+		t.Errorf("creating a league with the same team name twice: err = %v, want ErrInvalidLeague", err)
+	}
+
+	// Veritabanının benzersiz dizinine takılan eşzamanlı bir kayıt da ErrInvalidTeam döner.
+	if err := teamNameError(repos.teams.CreateTeam(&models.Team{Name: "united"}), "united"); !errors.Is(err, ErrInvalidTeam) {
+		t.Errorf("duplicate name from the repository: err = %v, want ErrInvalidTeam", err)
+	}
+}
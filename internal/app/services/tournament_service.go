@@ -0,0 +1,663 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/repositories"
+	"github.com/muzaffertuna/football-league-sim/internal/app/scheduler"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// maxTournamentGroups bir turnuvadaki en fazla grup sayısıdır (gruplar A'dan P'ye adlandırılır).
+const maxTournamentGroups = 16
+
+// tournamentService turnuvaların grup aşamasını grup maçlarıyla, eleme aşamasını bir kupayla yürütür.
+// Turnuvanın aşaması ve güncel maç günü saklanmaz; grup maçlarından ve eleme kupasından türetilir.
+// Durumu değiştiren işlemler süreç içinde tek bir kilitle sıraya sokulur ve turnuvanın sürüm
+// numarasıyla denetlenir.
+type tournamentService struct {
+	mu sync.Mutex
+
+	tournamentRepo repositories.TournamentRepository
+	cupRepo        repositories.CupRepository
+	matchRepo      repositories.MatchRepository
+	teamRepo       repositories.TeamRepository
+	matchSvc       MatchService
+	uow            repositories.UnitOfWork
+}
+
+func NewTournamentService(tournamentRepo repositories.TournamentRepository, cupRepo repositories.CupRepository, matchRepo repositories.MatchRepository, teamRepo repositories.TeamRepository, matchSvc MatchService, uow repositories.UnitOfWork) TournamentService {
+	return &tournamentService{
+		tournamentRepo: tournamentRepo,
+		cupRepo:        cupRepo,
+		matchRepo:      matchRepo,
+		teamRepo:       teamRepo,
+		matchSvc:       matchSvc,
+		uow:            uow,
+	}
+}
+
+func (t NewTournament) validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTournament)
+	}
+	numGroups := len(t.Groups)
+	if numGroups < 2 || numGroups > maxTournamentGroups || numGroups&(numGroups-1) != 0 {
+		return fmt.Errorf("%w: number of groups must be a power of two between 2 and %d, got %d", ErrInvalidTournament, maxTournamentGroups, numGroups)
+	}
+	seen := make(map[int]bool)
+	for i, group := range t.Groups {
+		if len(group) < 2 {
+			return fmt.Errorf("%w: group %s must have at least 2 teams, got %d", ErrInvalidTournament, groupName(i), len(group))
+		}
+		for _, id := range group {
+			if seen[id] {
+				return fmt.Errorf("%w: team %d is drawn in more than one group", ErrInvalidTournament, id)
+			}
+			seen[id] = true
+		}
+	}
+	if t.GroupLegs != 1 && t.GroupLegs != 2 {
+		return fmt.Errorf("%w: group legs must be 1 or 2, got %d", ErrInvalidTournament, t.GroupLegs)
+	}
+	if t.KnockoutLegs != 1 && t.KnockoutLegs != 2 {
+		return fmt.Errorf("%w: knockout legs must be 1 or 2, got %d", ErrInvalidTournament, t.KnockoutLegs)
+	}
+	return nil
+}
+
+func (s *tournamentService) CreateTournament(input NewTournament) (*models.Tournament, error) {
+	if input.GroupLegs == 0 {
+		input.GroupLegs = 1
+	}
+	if input.KnockoutLegs == 0 {
+		input.KnockoutLegs = 1
+	}
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	for _, group := range input.Groups {
+		for _, id := range group {
+			team, err := s.teamRepo.GetTeamByID(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get team %d: %w", id, err)
+			}
+			if team == nil {
+				return nil, fmt.Errorf("%w: team %d not found", ErrInvalidTournament, id)
+			}
+		}
+	}
+	tiebreakers := input.Tiebreakers
+	if tiebreakers == nil {
+		tiebreakers = standings.DefaultTiebreakers
+	}
+
+	tournament := &models.Tournament{
+		Name:         strings.TrimSpace(input.Name),
+		GroupLegs:    input.GroupLegs,
+		KnockoutLegs: input.KnockoutLegs,
+		Tiebreakers:  tiebreakerNames(tiebreakers),
+		Seed:         input.Seed,
+		Status:       models.LeagueStatusNotStarted,
+	}
+	for i, teamIDs := range input.Groups {
+		tournament.Groups = append(tournament.Groups, models.TournamentGroup{Name: groupName(i), TeamIDs: slices.Sorted(slices.Values(teamIDs))})
+	}
+
+	var created *models.Tournament
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		if err := repos.Tournaments.CreateTournament(tournament); err != nil {
+			return fmt.Errorf("failed to save tournament: %w", err)
+		}
+		for _, group := range tournament.Groups {
+			if err := generateGroupMatches(repos.Matches, group, tournament.GroupLegs); err != nil {
+				return err
+			}
+		}
+		var err error
+		created, err = tournamentDetails(repos, tournament, false)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tournament: %w", err)
+	}
+	return created, nil
+}
+
+func (s *tournamentService) GetTournaments() ([]models.Tournament, error) {
+	tournaments, err := s.tournamentRepo.GetAllTournaments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournaments: %w", err)
+	}
+	for i := range tournaments {
+		summary, err := tournamentDetails(s.repositories(), &tournaments[i], false)
+		if err != nil {
+			return nil, err
+		}
+		tournaments[i] = *summary
+	}
+	return tournaments, nil
+}
+
+// GetTournament turnuvayı grupların puan durumları, maçları ve (kurası çekildiyse) eleme ağacıyla birlikte döndürür.
+func (s *tournamentService) GetTournament(id int) (*models.Tournament, error) {
+	tournament, err := findTournament(s.tournamentRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	return tournamentDetails(s.repositories(), tournament, true)
+}
+
+// PlayNextRound grup aşamasında tüm grupların sıradaki maç gününü oynatır; son maç günüyle birlikte eleme
+// aşamasının ilk turunun kurası çekilir. Eleme aşamasında güncel tur oynatılır ve final değilse sonraki
+// turun kurası çekilir.
+func (s *tournamentService) PlayNextRound(id int, seed *int64) (*models.Tournament, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		result  *models.Tournament
+		decided bool
+	)
+	err := s.uow.Do(func(repos repositories.Repositories) error {
+		tournament, err := findTournament(repos.Tournaments, id)
+		if err != nil {
+			return err
+		}
+		if tournament.Status == models.LeagueStatusCompleted {
+			return ErrTournamentCompleted
+		}
+
+		engine, err := s.tournamentEngine(repos.Matches, tournament)
+		if err != nil {
+			return err
+		}
+		matchSvc := s.matchSvc.WithEngine(engine)
+		if tournament.CupID == nil {
+			err = playGroupMatchday(repos, matchSvc, tournament, seed)
+		} else {
+			err = playKnockoutRound(repos, matchSvc, tournament, seed)
+		}
+		// Eleme kupası zaten bitmişse tur oynatılmaz ama turnuvanın tamamlandığı kaydedilir.
+		decided = errors.Is(err, ErrTournamentCompleted)
+		if err != nil && !decided {
+			return err
+		}
+
+		if err := repos.Tournaments.UpdateTournament(tournament); err != nil {
+			return fmt.Errorf("failed to save tournament state: %w", err)
+		}
+		if decided {
+			return nil
+		}
+		result, err = tournamentDetails(repos, tournament, true)
+		return err
+	})
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: tournament %d was changed by another request", ErrConflict, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if decided {
+		return nil, ErrTournamentCompleted
+	}
+	return result, nil
+}
+
+// playGroupMatchday tüm grupların oynanmamış en erken maç gününü oynatır. Grup aşaması bittiyse eleme
+// aşamasının kurasını çeker.
+func playGroupMatchday(repos repositories.Repositories, matchSvc MatchService, tournament *models.Tournament, seed *int64) error {
+	groupMatches, err := loadGroupMatches(repos.Matches, tournament.Groups)
+	if err != nil {
+		return err
+	}
+	matchday := 0
+	for _, matches := range groupMatches {
+		if day := nextMatchday(matches); day > 0 && (matchday == 0 || day < matchday) {
+			matchday = day
+		}
+	}
+
+	baseSeed := resolveSeed(seed, tournament.Seed)
+	if matchday > 0 {
+		daySeed := deriveSeed(baseSeed, int64(matchday))
+		for _, matches := range groupMatches {
+			for i := range matches {
+				match := &matches[i]
+				if match.Played || match.Week != matchday {
+					continue
+				}
+				homeTeam, err := repos.Teams.GetTeamByID(match.HomeTeamID)
+				if err != nil {
+					return err
+				}
+				awayTeam, err := repos.Teams.GetTeamByID(match.AwayTeamID)
+				if err != nil {
+					return err
+				}
+				if homeTeam == nil || awayTeam == nil {
+					return fmt.Errorf("match %d references unknown team", match.ID)
+				}
+				matchSeed := deriveSeed(daySeed, int64(match.HomeTeamID), int64(match.AwayTeamID))
+				if err := matchSvc.SimulateMatch(match, homeTeam, awayTeam, matchSeed); err != nil {
+					return err
+				}
+				if err := repos.Matches.UpdateMatch(match); err != nil {
+					return fmt.Errorf("failed to save match %d: %w", match.ID, err)
+				}
+			}
+		}
+		tournament.Status = models.LeagueStatusInProgress
+	}
+
+	for _, matches := range groupMatches {
+		if nextMatchday(matches) > 0 {
+			return nil
+		}
+	}
+	return drawKnockout(repos, tournament, groupMatches, newRand(deriveSeed(baseSeed, int64(matchday), cupDrawSeedPart)))
+}
+
+// playKnockoutRound eleme kupasının güncel turunu oynatır ve final değilse sonraki turun kurasını çeker.
+func playKnockoutRound(repos repositories.Repositories, matchSvc MatchService, tournament *models.Tournament, seed *int64) error {
+	cup, err := findCup(repos.Cups, *tournament.CupID)
+	if err != nil {
+		return err
+	}
+	// Sahipliği kaydedilmeden önce kupa uç noktalarıyla bitirilmiş bir eleme aşamasında tur oynatılmaz;
+	// turnuva tamamlanmış sayılır.
+	if cup.Status == models.LeagueStatusCompleted {
+		tournament.Status = models.LeagueStatusCompleted
+		return ErrTournamentCompleted
+	}
+	if err := advanceCup(repos, matchSvc, cup, seed); err != nil {
+		return err
+	}
+	if cup.Status == models.LeagueStatusCompleted {
+		tournament.Status = models.LeagueStatusCompleted
+	}
+	return nil
+}
+
+// drawKnockout grup birincileri ve ikincileriyle eleme kupasını oluşturur ve ilk turun kurasını çeker.
+// Her grup birincisi başka bir grubun ikincisiyle eşleşir ve ilk maçı evinde oynar; böylece ilk turda
+// aynı gruptan iki takım karşılaşmaz. Sonraki turların kurası serbesttir.
+func drawKnockout(repos repositories.Repositories, tournament *models.Tournament, groupMatches [][]models.Match, rng *rand.Rand) error {
+	rules, err := tournamentRules(tournament)
+	if err != nil {
+		return err
+	}
+
+	numGroups := len(tournament.Groups)
+	winners := make([]int, numGroups)
+	runnersUp := make([]int, numGroups)
+	for i, group := range tournament.Groups {
+		teams, err := groupTeams(repos.Teams, group)
+		if err != nil {
+			return err
+		}
+		ranked := rankTeams(teams, groupMatches[i], 0, rules)
+		winners[i], runnersUp[i] = ranked[0].ID, ranked[1].ID
+	}
+
+	cup := &models.Cup{
+		Name:         tournament.Name,
+		Legs:         tournament.KnockoutLegs,
+		Draw:         models.CupDrawRandom,
+		Seed:         tournament.Seed,
+		Owner:        models.CupOwnerTournament,
+		Status:       models.LeagueStatusInProgress,
+		CurrentRound: 1,
+		TeamIDs:      append(slices.Clone(winners), runnersUp...),
+	}
+	if err := repos.Cups.CreateCup(cup); err != nil {
+		return fmt.Errorf("failed to create knockout cup: %w", err)
+	}
+	cup.TotalRounds = cupRounds(len(cup.TeamIDs))
+
+	for i, opponent := range knockoutOpponents(rng, numGroups) {
+		if err := createCupTie(repos, cup, 1, i+1, [2]int{winners[i], runnersUp[opponent]}); err != nil {
+			return err
+		}
+	}
+	tournament.CupID = &cup.ID
+	return nil
+}
+
+// knockoutOpponents i. grup birincisinin eşleşeceği ikincinin grubunu döndürür. Hiçbir birinci kendi
+// grubunun ikincisiyle eşleşmez (rastgele bir düzensiz permütasyon); permütasyonların yaklaşık 1/e'si
+// bu koşulu sağladığından kabul-ret yöntemi birkaç denemede sonuç verir.
+func knockoutOpponents(rng *rand.Rand, numGroups int) []int {
+	for {
+		perm := rng.Perm(numGroups)
+		ok := true
+		for i, p := range perm {
+			if i == p {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return perm
+		}
+	}
+}
+
+// tournamentDetails turnuvanın türetilen alanlarını (aşama, maç günü, kazanan) doldurur. full true ise
+// grupların puan durumları ve maçları ile eleme ağacı da eklenir.
+func tournamentDetails(repos repositories.Repositories, tournament *models.Tournament, full bool) (*models.Tournament, error) {
+	result := *tournament
+	result.Groups = slices.Clone(tournament.Groups)
+
+	groupMatches, err := loadGroupMatches(repos.Matches, result.Groups)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := tournamentRules(tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	result.CurrentRound, result.TotalRounds = 0, 0
+	for i, matches := range groupMatches {
+		for _, match := range matches {
+			result.TotalRounds = max(result.TotalRounds, match.Week)
+		}
+		if day := nextMatchday(matches); day > 0 && (result.CurrentRound == 0 || day < result.CurrentRound) {
+			result.CurrentRound = day
+		}
+		if full {
+			teams, err := groupTeams(repos.Teams, result.Groups[i])
+			if err != nil {
+				return nil, err
+			}
+			slices.SortFunc(matches, func(a, b models.Match) int {
+				return cmp.Or(cmp.Compare(a.Week, b.Week), cmp.Compare(a.ID, b.ID))
+			})
+			result.Groups[i].Table = rankTeams(teams, matches, 0, rules)
+			result.Groups[i].Matches = matches
+		}
+	}
+	if result.CurrentRound == 0 {
+		result.CurrentRound = result.TotalRounds + 1
+	}
+
+	result.Stage = models.TournamentStageGroup
+	if result.CupID != nil {
+		result.Stage = models.TournamentStageKnockout
+		cup, err := findCup(repos.Cups, *result.CupID)
+		if err != nil {
+			return nil, err
+		}
+		result.WinnerTeamID = cup.WinnerTeamID
+		if cup.Status == models.LeagueStatusCompleted {
+			result.Status = models.LeagueStatusCompleted
+		}
+		if full {
+			if result.Knockout, err = cupBracket(repos, cup); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &result, nil
+}
+
+func (s *tournamentService) repositories() repositories.Repositories {
+	return repositories.Repositories{Teams: s.teamRepo, Matches: s.matchRepo, Cups: s.cupRepo, Tournaments: s.tournamentRepo}
+}
+
+// tournamentEngine turnuvanın maçlarını simüle eden motoru döndürür. Geçmiş sonuçlardan beslenen motorlar
+// (Dixon-Coles) yalnızca turnuvanın grup ve eleme maçlarıyla eğitilir.
+func (s *tournamentService) tournamentEngine(matchRepo repositories.MatchRepository, tournament *models.Tournament) (MatchEngine, error) {
+	groupMatches, err := loadGroupMatches(matchRepo, tournament.Groups)
+	if err != nil {
+		return nil, err
+	}
+	var matches []models.Match
+	for _, group := range groupMatches {
+		matches = append(matches, group...)
+	}
+	if tournament.CupID != nil {
+		cupMatches, err := matchRepo.GetMatchesByCup(*tournament.CupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get knockout matches: %w", err)
+		}
+		matches = append(matches, cupMatches...)
+	}
+	return fitEngine(s.matchSvc.Engine(), matches)
+}
+
+func findTournament(tournamentRepo repositories.TournamentRepository, id int) (*models.Tournament, error) {
+	tournament, err := tournamentRepo.GetTournamentByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+	if tournament == nil {
+		return nil, fmt.Errorf("%w: %d", ErrTournamentNotFound, id)
+	}
+	return tournament, nil
+}
+
+// tournamentRules turnuvanın grup sıralaması kurallarını oluşturur; kura çekimi turnuvanın tohumuna bağlıdır.
+func tournamentRules(tournament *models.Tournament) (standings.Rules, error) {
+	tiebreakers, err := standings.ParseTiebreakers(strings.Join(tournament.Tiebreakers, ","))
+	if err != nil {
+		return standings.Rules{}, fmt.Errorf("invalid tiebreakers for tournament %d: %w", tournament.ID, err)
+	}
+	rules := standings.Rules{Tiebreakers: tiebreakers}
+	if tournament.Seed != nil {
+		rules.LotSeed = *tournament.Seed
+	}
+	return rules, nil
+}
+
+// generateGroupMatches grubun tek ya da çift devreli fikstürünü oluşturur. Hafta numarası maç günüdür.
+func generateGroupMatches(matchRepo repositories.MatchRepository, group models.TournamentGroup, legs int) error {
+	roundRobin := scheduler.SingleRoundRobin
+	if legs == 2 {
+		roundRobin = scheduler.DoubleRoundRobin
+	}
+	fixtures, err := roundRobin(group.TeamIDs)
+	if err != nil {
+		return fmt.Errorf("failed to generate fixture for group %s: %w", group.Name, err)
+	}
+	for _, f := range fixtures {
+		match := &models.Match{GroupID: group.ID, HomeTeamID: f.HomeTeamID, AwayTeamID: f.AwayTeamID, Week: f.Week}
+		if err := matchRepo.CreateMatch(match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadGroupMatches grupların maçlarını grupların sırasıyla döndürür.
+func loadGroupMatches(matchRepo repositories.MatchRepository, groups []models.TournamentGroup) ([][]models.Match, error) {
+	groupMatches := make([][]models.Match, len(groups))
+	for i, group := range groups {
+		matches, err := matchRepo.GetMatchesByGroup(group.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get matches of group %s: %w", group.Name, err)
+		}
+		groupMatches[i] = matches
+	}
+	return groupMatches, nil
+}
+
+func groupTeams(teamRepo repositories.TeamRepository, group models.TournamentGroup) ([]models.Team, error) {
+	teams := make([]models.Team, 0, len(group.TeamIDs))
+	for _, id := range group.TeamIDs {
+		team, err := teamRepo.GetTeamByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team %d: %w", id, err)
+		}
+		if team == nil {
+			return nil, fmt.Errorf("group %s references unknown team %d", group.Name, id)
+		}
+		teams = append(teams, *team)
+	}
+	return teams, nil
+}
+
+// nextMatchday oynanmamış maçların en erken maç gününü döndürür; tüm maçlar oynandıysa 0 döner.
+func nextMatchday(matches []models.Match) int {
+	matchday := 0
+	for _, match := range matches {
+		if !match.Played && (matchday == 0 || match.Week < matchday) {
+			matchday = match.Week
+		}
+	}
+	return matchday
+}
+
+func groupName(i int) string {
+	return string(rune('A' + i))
+}
+
+// PredictStages turnuvanın kalanını opts.Simulations kez simüle eder ve her takımın her aşamaya ulaşma
+// olasılığını döndürür. Grup aşaması sürüyorsa kalan grup maçları ve eleme kurası da simüle edilir;
+// eleme aşamasında sonuçlanmış eşleşmeler olduğu gibi alınır. Simülasyonlar PredictOutcomes'taki gibi
+// sınırlı bir işçi havuzunda, simülasyon sırasından türetilen tohumlarla yapılır.
+func (s *tournamentService) PredictStages(ctx context.Context, id int, opts PredictionOptions) (models.TournamentPrediction, error) {
+	numSimulations := opts.Simulations
+	if numSimulations <= 0 {
+		return models.TournamentPrediction{}, fmt.Errorf("number of simulations must be positive, got %d", numSimulations)
+	}
+
+	tournament, err := findTournament(s.tournamentRepo, id)
+	if err != nil {
+		return models.TournamentPrediction{}, err
+	}
+	rules, err := tournamentRules(tournament)
+	if err != nil {
+		return models.TournamentPrediction{}, err
+	}
+	groupMatches, err := loadGroupMatches(s.matchRepo, tournament.Groups)
+	if err != nil {
+		return models.TournamentPrediction{}, err
+	}
+	teams := make([][]models.Team, len(tournament.Groups))
+	for i, group := range tournament.Groups {
+		if teams[i], err = groupTeams(s.teamRepo, group); err != nil {
+			return models.TournamentPrediction{}, err
+		}
+	}
+	var (
+		cup  *models.Cup
+		ties []models.CupTie
+	)
+	if tournament.CupID != nil {
+		if cup, err = findCup(s.cupRepo, *tournament.CupID); err != nil {
+			return models.TournamentPrediction{}, err
+		}
+		if ties, err = s.cupRepo.GetTiesByCup(cup.ID); err != nil {
+			return models.TournamentPrediction{}, fmt.Errorf("failed to get cup ties: %w", err)
+		}
+	}
+
+	engine, err := s.tournamentEngine(s.matchRepo, tournament)
+	if err != nil {
+		return models.TournamentPrediction{}, err
+	}
+	sim, err := newTournamentSimulator(tournament, teams, groupMatches, cup, ties, engine, rules)
+	if err != nil {
+		return models.TournamentPrediction{}, fmt.Errorf("failed to prepare tournament simulator: %w", err)
+	}
+
+	numTeams := len(sim.teams)
+	stages := sim.stageNames()
+	baseSeed := resolveSeed(opts.Seed, tournament.Seed)
+
+	// stageCounts[takım][aşama] takımın o aşamaya ulaştığı simülasyon sayısıdır.
+	stageCounts := make([][]int, numTeams)
+	for i := range stageCounts {
+		stageCounts[i] = make([]int, len(stages))
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		next atomic.Int64
+	)
+	workers := min(runtime.GOMAXPROCS(0), numSimulations)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			localCounts := make([][]int, numTeams)
+			for i := range localCounts {
+				localCounts[i] = make([]int, len(stages))
+			}
+			st := sim.newState()
+			rng := newRand(0)
+
+			for ctx.Err() == nil {
+				simIndex := next.Add(1) - 1
+				if simIndex >= int64(numSimulations) {
+					break
+				}
+				rng.Seed(deriveSeed(baseSeed, simIndex))
+				sim.run(rng, st)
+				for team, reached := range st.stages {
+					for stage := 0; stage <= reached; stage++ {
+						localCounts[team][stage]++
+					}
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for team := range localCounts {
+				for stage, count := range localCounts[team] {
+					stageCounts[team][stage] += count
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return models.TournamentPrediction{}, fmt.Errorf("prediction cancelled: %w", err)
+	}
+
+	result := models.TournamentPrediction{
+		Simulations: numSimulations,
+		Seed:        baseSeed,
+		Stages:      stages,
+	}
+	for i, team := range sim.teams {
+		prediction := models.TeamStagePrediction{
+			TeamID:             team.ID,
+			TeamName:           team.Name,
+			Group:              sim.groupOf[i],
+			StageProbabilities: make([]float64, len(stages)),
+		}
+		for stage, count := range stageCounts[i] {
+			prediction.StageProbabilities[stage] = float64(count) / float64(numSimulations) * 100
+		}
+		prediction.WinLikelihood = prediction.StageProbabilities[len(stages)-1]
+		result.Teams = append(result.Teams, prediction)
+	}
+
+	// Takımlar şampiyonluk olasılığına, eşitlikte sırasıyla daha önceki aşamalara ulaşma olasılığına göre sıralanır.
+	slices.SortStableFunc(result.Teams, func(a, b models.TeamStagePrediction) int {
+		for stage := len(stages) - 1; stage >= 0; stage-- {
+			if c := cmp.Compare(b.StageProbabilities[stage], a.StageProbabilities[stage]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return result, nil
+}
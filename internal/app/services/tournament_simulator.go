@@ -0,0 +1,142 @@
+package services
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+
+	"github.com/muzaffertuna/football-league-sim/internal/app/models"
+	"github.com/muzaffertuna/football-league-sim/internal/app/standings"
+)
+
+// tournamentSimulator Monte Carlo tahminleri için turnuvanın kalanını oynatır: grup aşaması bitmediyse
+// kalan grup maçlarını seasonSimulator'larla, ardından eleme turlarını knockoutSimulator ile simüle eder.
+// seasonSimulator gibi yalnızca okunur; her işçi kendi tournamentState'ini tutar.
+//
+// Aşamalar 0 (grup aşaması), 1..totalRounds (eleme turları) ve totalRounds+1 (şampiyonluk) olarak numaralanır.
+type tournamentSimulator struct {
+	teams    []models.Team // Kimliğe göre sıralı
+	index    map[int]int   // Takım kimliğinden teams indeksine
+	groupOf  []string      // Takımın grubunun adı
+	knockout *knockoutSimulator
+
+	// Grup aşaması sürüyorsa gruplar ve grup simülatörlerinin takım indekslerinden teams indekslerine eşleme
+	groups     []*seasonSimulator
+	groupIndex [][]int
+
+	// Eleme aşamasındaysa simülasyonun başladığı tur, o turun eşleşmeleri ve takımların ulaştığı aşamalar
+	startRound int
+	startTies  []simTie
+	baseStages []int
+}
+
+// tournamentState tek bir işçinin simülasyon başına sıfırlanan çalışma alanıdır.
+type tournamentState struct {
+	groups   []*simState
+	knockout *knockoutState
+	stages   []int // Takımların ulaştığı son aşama
+}
+
+func newTournamentSimulator(tournament *models.Tournament, groupTeams [][]models.Team, groupMatches [][]models.Match, cup *models.Cup, ties []models.CupTie, engine MatchEngine, rules standings.Rules) (*tournamentSimulator, error) {
+	sim := &tournamentSimulator{
+		index:      make(map[int]int),
+		startRound: 1,
+	}
+	groupOf := make(map[int]string)
+	for i, teams := range groupTeams {
+		sim.teams = append(sim.teams, teams...)
+		for _, team := range teams {
+			groupOf[team.ID] = tournament.Groups[i].Name
+		}
+	}
+	slices.SortFunc(sim.teams, func(a, b models.Team) int { return cmp.Compare(a.ID, b.ID) })
+	sim.groupOf = make([]string, len(sim.teams))
+	for i, team := range sim.teams {
+		sim.index[team.ID] = i
+		sim.groupOf[i] = groupOf[team.ID]
+	}
+	sim.baseStages = make([]int, len(sim.teams))
+	sim.knockout = &knockoutSimulator{
+		teams:       sim.teams,
+		engine:      engine,
+		legs:        tournament.KnockoutLegs,
+		draw:        models.CupDrawRandom,
+		totalRounds: cupRounds(2 * len(tournament.Groups)),
+	}
+
+	if cup == nil {
+		for i, teams := range groupTeams {
+			groupSim, err := newSeasonSimulator(teams, groupMatches[i], engine, rules)
+			if err != nil {
+				return nil, err
+			}
+			indices := make([]int, len(groupSim.teams))
+			for j, team := range groupSim.teams {
+				indices[j] = sim.index[team.ID]
+			}
+			sim.groups = append(sim.groups, groupSim)
+			sim.groupIndex = append(sim.groupIndex, indices)
+		}
+		return sim, nil
+	}
+
+	sim.knockout.totalRounds = cup.TotalRounds
+	sim.startRound = cup.CurrentRound
+	for _, tie := range ties {
+		if tie.Round > cup.CurrentRound {
+			continue
+		}
+		st, err := newSimTie(tie, sim.index)
+		if err != nil {
+			return nil, err
+		}
+		sim.knockout.reach(sim.baseStages, st, tie.Round)
+		if tie.Round == cup.CurrentRound {
+			sim.startTies = append(sim.startTies, st)
+		}
+	}
+	return sim, nil
+}
+
+func (sim *tournamentSimulator) newState() *tournamentState {
+	st := &tournamentState{
+		knockout: sim.knockout.newState(),
+		stages:   make([]int, len(sim.teams)),
+	}
+	for _, groupSim := range sim.groups {
+		st.groups = append(st.groups, groupSim.newState())
+	}
+	return st
+}
+
+// run turnuvanın kalanını bir kez oynatır ve st.stages'e takımların ulaştığı son aşamayı yazar.
+func (sim *tournamentSimulator) run(rng *rand.Rand, st *tournamentState) {
+	copy(st.stages, sim.baseStages)
+	ko := st.knockout
+	ko.ties = append(ko.ties[:0], sim.startTies...)
+
+	if sim.groups != nil {
+		winners := make([]int, len(sim.groups))
+		runnersUp := make([]int, len(sim.groups))
+		for i, groupSim := range sim.groups {
+			groupSim.run(rng, st.groups[i])
+			winners[i] = sim.groupIndex[i][st.groups[i].order[0]]
+			runnersUp[i] = sim.groupIndex[i][st.groups[i].order[1]]
+		}
+		for i, opponent := range knockoutOpponents(rng, len(sim.groups)) {
+			ko.ties = append(ko.ties, simTie{home: winners[i], away: runnersUp[opponent], winner: -1})
+		}
+	}
+
+	sim.knockout.run(rng, ko, sim.startRound, st.stages)
+}
+
+// stageNames aşamaların adlarını sırasıyla döndürür.
+func (sim *tournamentSimulator) stageNames() []string {
+	totalRounds := sim.knockout.totalRounds
+	names := []string{"Group stage"}
+	for round := 1; round <= totalRounds; round++ {
+		names = append(names, cupRoundName(totalRounds, round))
+	}
+	return append(names, "Winner")
+}
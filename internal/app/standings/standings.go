@@ -0,0 +1,282 @@
+package standings
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Tiebreaker puanları eşit olan takımları ayırmak için kullanılan bir ölçüttür.
+type Tiebreaker string
+
+const (
+	GoalDifference           Tiebreaker = "goal_difference"
+	GoalsFor                 Tiebreaker = "goals_for"
+	HeadToHeadPoints         Tiebreaker = "head_to_head_points"
+	HeadToHeadGoalDifference Tiebreaker = "head_to_head_goal_difference"
+	AwayGoals                Tiebreaker = "away_goals"
+	Wins                     Tiebreaker = "wins"
+	FairPlay                 Tiebreaker = "fair_play"
+	DrawingOfLots            Tiebreaker = "drawing_of_lots"
+	AwayWins                 Tiebreaker = "away_wins"
+	// Rakip ölçütleri takımların karşılaştığı rakiplerin toplam puanına, averajına ve attığı gole bakar;
+	// herkesin herkesle oynamadığı (İsviçre usulü) liglerde fikstürün zorluğunu ölçer.
+	OpponentPoints         Tiebreaker = "opponent_points"
+	OpponentGoalDifference Tiebreaker = "opponent_goal_difference"
+	OpponentGoalsFor       Tiebreaker = "opponent_goals_for"
+)
+
+// DefaultTiebreakers puan eşitliğinde önce averaja, sonra atılan gole bakar.
+var DefaultTiebreakers = []Tiebreaker{GoalDifference, GoalsFor}
+
+// SwissTiebreakers UEFA'nın lig aşamasındaki sıralama ölçütleridir. Takımlar aynı rakiplerle oynamadığından
+// ikili averaj yerine rakiplerin toplam başarısına bakılır.
+var SwissTiebreakers = []Tiebreaker{GoalDifference, GoalsFor, AwayGoals, Wins, AwayWins, OpponentPoints, OpponentGoalDifference, OpponentGoalsFor, FairPlay}
+
+var knownTiebreakers = map[Tiebreaker]bool{
+	GoalDifference:           true,
+	GoalsFor:                 true,
+	HeadToHeadPoints:         true,
+	HeadToHeadGoalDifference: true,
+	AwayGoals:                true,
+	Wins:                     true,
+	FairPlay:                 true,
+	DrawingOfLots:            true,
+	AwayWins:                 true,
+	OpponentPoints:           true,
+	OpponentGoalDifference:   true,
+	OpponentGoalsFor:         true,
+}
+
+// ParseTiebreakers virgülle ayrılmış ölçüt listesini doğrular. Boş liste için varsayılan ölçütler döner.
+func ParseTiebreakers(raw string) ([]Tiebreaker, error) {
+	if strings.TrimSpace(raw) == "" {
+		return slices.Clone(DefaultTiebreakers), nil
+	}
+
+	var tiebreakers []Tiebreaker
+	for _, part := range strings.Split(raw, ",") {
+		t := Tiebreaker(strings.TrimSpace(part))
+		if !knownTiebreakers[t] {
+			return nil, fmt.Errorf("unknown tiebreaker %q", t)
+		}
+		if slices.Contains(tiebreakers, t) {
+			return nil, fmt.Errorf("duplicate tiebreaker %q", t)
+		}
+		tiebreakers = append(tiebreakers, t)
+	}
+	return tiebreakers, nil
+}
+
+// Rules bir ligin sıralama kurallarıdır. Puan her zaman ilk ölçüttür; Tiebreakers sırayla uygulanır.
+// Hiçbir ölçüt eşitliği bozamazsa takımlar kimlik sırasında kalır.
+type Rules struct {
+	Tiebreakers []Tiebreaker
+	LotSeed     int64 // Kura çekimi için tohum; aynı tohum aynı kurayı verir
+}
+
+// Row puan durumundaki tek bir takımın istatistikleridir.
+type Row struct {
+	TeamID         int
+	Played         int
+	Wins           int
+	Draws          int
+	Losses         int
+	GoalsFor       int
+	GoalsAgainst   int
+	AwayGoals      int
+	AwayWins       int
+	Points         int
+	FairPlayPoints int // Disiplin (kart) puanı; düşük olan öndedir
+}
+
+func (r *Row) GoalDifference() int {
+	return r.GoalsFor - r.GoalsAgainst
+}
+
+// Result ikili averaj gibi ölçütlerde kullanılan oynanmış bir maç sonucudur.
+type Result struct {
+	HomeTeamID int
+	AwayTeamID int
+	HomeGoals  int
+	AwayGoals  int
+}
+
+// Compute verilen takımların satırlarını yalnızca maç sonuçlarından hesaplar. Satırlar teamIDs
+// sırasındadır; listede olmayan takımları içeren sonuçlar yok sayılır.
+func Compute(teamIDs []int, results []Result) []Row {
+	rows := make([]Row, len(teamIDs))
+	index := make(map[int]int, len(teamIDs))
+	for i, id := range teamIDs {
+		rows[i].TeamID = id
+		index[id] = i
+	}
+	for _, res := range results {
+		home, okHome := index[res.HomeTeamID]
+		away, okAway := index[res.AwayTeamID]
+		if !okHome || !okAway {
+			continue
+		}
+		Apply(&rows[home], &rows[away], res)
+	}
+	return rows
+}
+
+// Apply bir maç sonucunu ev sahibi ve deplasman takımlarının satırlarına işler.
+func Apply(home, away *Row, res Result) {
+	home.record(res.HomeGoals, res.AwayGoals)
+	away.record(res.AwayGoals, res.HomeGoals)
+	away.AwayGoals += res.AwayGoals
+	if res.AwayGoals > res.HomeGoals {
+		away.AwayWins++
+	}
+}
+
+func (r *Row) record(goalsFor, goalsAgainst int) {
+	r.Played++
+	r.GoalsFor += goalsFor
+	r.GoalsAgainst += goalsAgainst
+	r.Points += Points(goalsFor, goalsAgainst)
+	switch {
+	case goalsFor > goalsAgainst:
+		r.Wins++
+	case goalsFor == goalsAgainst:
+		r.Draws++
+	default:
+		r.Losses++
+	}
+}
+
+// Sort satırları puana ve ardından kurallardaki ölçütlere göre yerinde sıralar.
+// İkili ölçütler (head_to_head_*) yalnızca o noktada hâlâ eşit olan takımlar arasındaki maçlara bakar.
+func Sort(rows []Row, results []Result, rules Rules) {
+	slices.SortFunc(rows, func(a, b Row) int { return cmp.Compare(a.TeamID, b.TeamID) })
+	slices.SortStableFunc(rows, func(a, b Row) int { return cmp.Compare(b.Points, a.Points) })
+
+	forEachTie(rows, func(r *Row) int { return r.Points }, func(group []Row) {
+		resolve(group, results, rules.Tiebreakers, rules)
+	})
+}
+
+// resolve eşit grubu sıradaki ölçüte göre sıralar ve hâlâ eşit kalan alt grupları bir sonraki ölçütle çözer.
+func resolve(group []Row, results []Result, tiebreakers []Tiebreaker, rules Rules) {
+	if len(group) < 2 || len(tiebreakers) == 0 {
+		return
+	}
+
+	keys := criterionKeys(tiebreakers[0], group, results, rules)
+	slices.SortStableFunc(group, func(a, b Row) int { return cmp.Compare(keys[b.TeamID], keys[a.TeamID]) })
+
+	forEachTie(group, func(r *Row) int { return keys[r.TeamID] }, func(sub []Row) {
+		resolve(sub, results, tiebreakers[1:], rules)
+	})
+}
+
+// forEachTie sıralı satırlarda aynı anahtara sahip ardışık ve birden fazla elemanlı grupları fn'e verir.
+func forEachTie(rows []Row, key func(*Row) int, fn func([]Row)) {
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && key(&rows[end]) == key(&rows[start]) {
+			end++
+		}
+		if end-start > 1 {
+			fn(rows[start:end])
+		}
+		start = end
+	}
+}
+
+// criterionKeys gruptaki her takım için ölçütün değerini döndürür; büyük değer üst sıradır.
+func criterionKeys(t Tiebreaker, group []Row, results []Result, rules Rules) map[int]int {
+	keys := make(map[int]int, len(group))
+	switch t {
+	case HeadToHeadPoints, HeadToHeadGoalDifference:
+		inGroup := make(map[int]bool, len(group))
+		for _, r := range group {
+			inGroup[r.TeamID] = true
+			keys[r.TeamID] = 0
+		}
+		for _, res := range results {
+			if !inGroup[res.HomeTeamID] || !inGroup[res.AwayTeamID] {
+				continue
+			}
+			if t == HeadToHeadPoints {
+				keys[res.HomeTeamID] += Points(res.HomeGoals, res.AwayGoals)
+				keys[res.AwayTeamID] += Points(res.AwayGoals, res.HomeGoals)
+			} else {
+				keys[res.HomeTeamID] += res.HomeGoals - res.AwayGoals
+				keys[res.AwayTeamID] += res.AwayGoals - res.HomeGoals
+			}
+		}
+	case OpponentPoints, OpponentGoalDifference, OpponentGoalsFor:
+		// Rakiplerin değerleri yalnızca gruptakilerden değil, tüm sonuçlardan hesaplanır.
+		totals := make(map[int]int)
+		for _, res := range results {
+			switch t {
+			case OpponentPoints:
+				totals[res.HomeTeamID] += Points(res.HomeGoals, res.AwayGoals)
+				totals[res.AwayTeamID] += Points(res.AwayGoals, res.HomeGoals)
+			case OpponentGoalDifference:
+				totals[res.HomeTeamID] += res.HomeGoals - res.AwayGoals
+				totals[res.AwayTeamID] += res.AwayGoals - res.HomeGoals
+			case OpponentGoalsFor:
+				totals[res.HomeTeamID] += res.HomeGoals
+				totals[res.AwayTeamID] += res.AwayGoals
+			}
+		}
+		for _, r := range group {
+			keys[r.TeamID] = 0
+		}
+		for _, res := range results {
+			if _, ok := keys[res.HomeTeamID]; ok {
+				keys[res.HomeTeamID] += totals[res.AwayTeamID]
+			}
+			if _, ok := keys[res.AwayTeamID]; ok {
+				keys[res.AwayTeamID] += totals[res.HomeTeamID]
+			}
+		}
+	default:
+		for i := range group {
+			r := &group[i]
+			switch t {
+			case GoalDifference:
+				keys[r.TeamID] = r.GoalDifference()
+			case GoalsFor:
+				keys[r.TeamID] = r.GoalsFor
+			case AwayGoals:
+				keys[r.TeamID] = r.AwayGoals
+			case Wins:
+				keys[r.TeamID] = r.Wins
+			case AwayWins:
+				keys[r.TeamID] = r.AwayWins
+			case FairPlay:
+				keys[r.TeamID] = -r.FairPlayPoints
+			case DrawingOfLots:
+				keys[r.TeamID] = lot(rules.LotSeed, r.TeamID)
+			}
+		}
+	}
+	return keys
+}
+
+// Points bir takımın attığı ve yediği gole göre maçtan aldığı puanı döndürür.
+func Points(goalsFor, goalsAgainst int) int {
+	switch {
+	case goalsFor > goalsAgainst:
+		return 3
+	case goalsFor == goalsAgainst:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// lot kura çekimi için tohuma ve takıma bağlı, tekrarlanabilir bir sayı üretir (SplitMix64).
+func lot(seed int64, teamID int) int {
+	x := uint64(seed) ^ (uint64(teamID) * 0x9e3779b97f4a7c15)
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return int(x >> 1)
+}
@@ -0,0 +1,266 @@
+package standings
+
+import (
+	"slices"
+	"testing"
+)
+
+// rank sonuçlardan puan durumunu hesaplar, kurallara göre sıralar ve takım kimliklerini sırasıyla döndürür.
+func rank(teamIDs []int, results []Result, rules Rules) []int {
+	rows := Compute(teamIDs, results)
+	Sort(rows, results, rules)
+	return order(rows)
+}
+
+func order(rows []Row) []int {
+	ids := make([]int, len(rows))
+	for i, r := range rows {
+		ids[i] = r.TeamID
+	}
+	return ids
+}
+
+func TestCompute(t *testing.T) {
+	results := []Result{
+		{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 1, AwayGoals: 3},
+		{HomeTeamID: 2, AwayTeamID: 1, HomeGoals: 2, AwayGoals: 2},
+		{HomeTeamID: 1, AwayTeamID: 9, HomeGoals: 5, AwayGoals: 0}, // 9 listede olmadığından yok sayılır
+	}
+	rows := Compute([]int{1, 2}, results)
+
+	want := []Row{
+		{TeamID: 1, Played: 2, Draws: 1, Losses: 1, GoalsFor: 3, GoalsAgainst: 5, AwayGoals: 2, Points: 1},
+		{TeamID: 2, Played: 2, Wins: 1, Draws: 1, GoalsFor: 5, GoalsAgainst: 3, AwayGoals: 3, AwayWins: 1, Points: 4},
+	}
+	if !slices.Equal(rows, want) {
+		t.Errorf("Compute() = %+v, want %+v", rows, want)
+	}
+	if gd := rows[1].GoalDifference(); gd != 2 {
+		t.Errorf("GoalDifference() = %d, want 2", gd)
+	}
+}
+
+func TestParseTiebreakers(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []Tiebreaker
+		wantErr bool
+	}{
+		{raw: "", want: DefaultTiebreakers},
+		{raw: " head_to_head_points , goal_difference ", want: []Tiebreaker{HeadToHeadPoints, GoalDifference}},
+		{raw: "fair_play,drawing_of_lots", want: []Tiebreaker{FairPlay, DrawingOfLots}},
+		{raw: "goal_difference,goals_scored", wantErr: true},
+		{raw: "wins,wins", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTiebreakers(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTiebreakers(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !slices.Equal(got, tt.want) {
+			t.Errorf("ParseTiebreakers(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestSortCriteria(t *testing.T) {
+	tests := []struct {
+		name        string
+		teams       []int
+		results     []Result
+		tiebreakers []Tiebreaker
+		want        []int
+	}{
+		{
+			name:  "points come first",
+			teams: []int{1, 2, 3},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 0, AwayGoals: 1},
+				{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 0, AwayGoals: 5},
+			},
+			tiebreakers: DefaultTiebreakers,
+			want:        []int{1, 2, 3},
+		},
+		{
+			name:  "goal difference before goals for",
+			teams: []int{1, 2, 3, 4},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 4, AwayGoals: 3},
+				{HomeTeamID: 2, AwayTeamID: 4, HomeGoals: 2, AwayGoals: 0},
+			},
+			tiebreakers: []Tiebreaker{GoalDifference, GoalsFor},
+			want:        []int{2, 1, 3, 4},
+		},
+		{
+			name:  "goals for when goal difference is level",
+			teams: []int{1, 2, 3, 4},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+				{HomeTeamID: 2, AwayTeamID: 4, HomeGoals: 3, AwayGoals: 2},
+			},
+			tiebreakers: []Tiebreaker{GoalDifference, GoalsFor},
+			want:        []int{2, 1, 4, 3},
+		},
+		{
+			name:  "away goals",
+			teams: []int{1, 2, 3},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 2, AwayGoals: 0},
+				{HomeTeamID: 3, AwayTeamID: 2, HomeGoals: 0, AwayGoals: 2},
+			},
+			tiebreakers: []Tiebreaker{GoalDifference, GoalsFor, AwayGoals},
+			want:        []int{2, 1, 3},
+		},
+		{
+			name:  "wins",
+			teams: []int{1, 2, 3, 4, 5},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+				{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 0},
+				{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 1},
+				{HomeTeamID: 2, AwayTeamID: 4, HomeGoals: 0, AwayGoals: 0},
+				{HomeTeamID: 5, AwayTeamID: 2, HomeGoals: 0, AwayGoals: 0},
+			},
+			tiebreakers: []Tiebreaker{GoalDifference, GoalsFor, Wins},
+			want:        []int{4, 1, 2, 5, 3},
+		},
+		{
+			name:  "away wins",
+			teams: []int{1, 2, 3, 4},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+				{HomeTeamID: 4, AwayTeamID: 2, HomeGoals: 0, AwayGoals: 1},
+			},
+			tiebreakers: []Tiebreaker{GoalDifference, GoalsFor, Wins, AwayWins},
+			want:        []int{2, 1, 3, 4},
+		},
+		{
+			name:  "opponent points measure the strength of schedule",
+			teams: []int{1, 2, 3, 4, 5},
+			results: []Result{
+				{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+				{HomeTeamID: 2, AwayTeamID: 4, HomeGoals: 1, AwayGoals: 0},
+				{HomeTeamID: 3, AwayTeamID: 5, HomeGoals: 2, AwayGoals: 0},
+			},
+			tiebreakers: []Tiebreaker{OpponentPoints},
+			want:        []int{1, 3, 2, 4, 5},
+		},
+		{
+			name:  "without tiebreakers level teams stay in ID order",
+			teams: []int{3, 1, 2},
+			results: []Result{
+				{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 5, AwayGoals: 5},
+			},
+			want: []int{1, 3, 2},
+		},
+	}
+	for _, tt := range tests {
+		got := rank(tt.teams, tt.results, Rules{Tiebreakers: tt.tiebreakers})
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// headToHeadResults 1, 2 ve 3'ü 4 puanda eşitler. Aralarındaki mini tabloda 1 önde (4 puan), 2 ikinci
+// (3 puan), 3 sonuncudur (1 puan); genel averajda ise 3 açık ara öndedir.
+var headToHeadResults = []Result{
+	{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 1, AwayGoals: 0},
+	{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+	{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 0, AwayGoals: 0},
+	{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 0},
+	{HomeTeamID: 2, AwayTeamID: 5, HomeGoals: 0, AwayGoals: 0},
+	{HomeTeamID: 3, AwayTeamID: 5, HomeGoals: 5, AwayGoals: 0},
+}
+
+func TestHeadToHeadMiniTable(t *testing.T) {
+	teams := []int{1, 2, 3, 4, 5}
+
+	got := rank(teams, headToHeadResults, Rules{Tiebreakers: []Tiebreaker{HeadToHeadPoints, GoalDifference}})
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("head-to-head first: got %v, want %v", got, want)
+	}
+
+	// Ölçütlerin sırası değişince tablo da değişir: önce genel averaja bakılır.
+	got = rank(teams, headToHeadResults, Rules{Tiebreakers: []Tiebreaker{GoalDifference, HeadToHeadPoints}})
+	if want := []int{3, 1, 2, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("goal difference first: got %v, want %v", got, want)
+	}
+}
+
+func TestHeadToHeadCircularTie(t *testing.T) {
+	// Üç takım birbirini yener: mini tabloda herkes 3 puandadır ve ikili averaj sırayı belirler.
+	results := []Result{
+		{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 2, AwayGoals: 0},
+		{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+		{HomeTeamID: 3, AwayTeamID: 1, HomeGoals: 3, AwayGoals: 2},
+	}
+	got := rank([]int{1, 2, 3}, results, Rules{Tiebreakers: []Tiebreaker{HeadToHeadPoints, HeadToHeadGoalDifference}})
+	if want := []int{1, 3, 2}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestHeadToHeadSubgroup(t *testing.T) {
+	// 1, 2 ve 3 üçü de 4 puandadır. Mini tabloda 1 ayrılır (6 puan); 2 ile 3 arasındaki ikili averaj
+	// yalnızca ikisinin maçına bakar (1-1), bu yüzden eşitlik atılan gole kalır. Üçlü gruptaki averaja
+	// bakılsaydı 3 (-1) 2'nin (-3) önüne geçerdi.
+	results := []Result{
+		{HomeTeamID: 1, AwayTeamID: 2, HomeGoals: 3, AwayGoals: 0},
+		{HomeTeamID: 1, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 0},
+		{HomeTeamID: 2, AwayTeamID: 3, HomeGoals: 1, AwayGoals: 1},
+		{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 2, AwayGoals: 0},
+		{HomeTeamID: 4, AwayTeamID: 1, HomeGoals: 1, AwayGoals: 0},
+		{HomeTeamID: 2, AwayTeamID: 5, HomeGoals: 4, AwayGoals: 0},
+		{HomeTeamID: 3, AwayTeamID: 5, HomeGoals: 1, AwayGoals: 0},
+	}
+	rules := Rules{Tiebreakers: []Tiebreaker{HeadToHeadPoints, HeadToHeadGoalDifference, GoalsFor}}
+	got := rank([]int{1, 2, 3, 4, 5}, results, rules)
+	if want := []int{4, 1, 2, 3, 5}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFairPlay(t *testing.T) {
+	rows := []Row{
+		{TeamID: 1, Points: 3, FairPlayPoints: 5},
+		{TeamID: 2, Points: 3, FairPlayPoints: 2},
+		{TeamID: 3, Points: 3, FairPlayPoints: 9},
+	}
+	Sort(rows, nil, Rules{Tiebreakers: []Tiebreaker{GoalDifference, FairPlay}})
+	if got, want := order(rows), []int{2, 1, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDrawingOfLots(t *testing.T) {
+	sortWithSeed := func(seed int64) []int {
+		rows := []Row{{TeamID: 1, Points: 1}, {TeamID: 2, Points: 1}, {TeamID: 3, Points: 1}}
+		Sort(rows, nil, Rules{Tiebreakers: []Tiebreaker{DrawingOfLots}, LotSeed: seed})
+		return order(rows)
+	}
+
+	// Aynı tohum her zaman aynı kurayı verir.
+	if a, b := sortWithSeed(42), sortWithSeed(42); !slices.Equal(a, b) {
+		t.Errorf("seed 42 gave %v and %v", a, b)
+	}
+
+	// Farklı tohumlarla her takım en az bir kez birinci olur.
+	winners := make(map[int]bool)
+	for seed := int64(0); seed < 100; seed++ {
+		winners[sortWithSeed(seed)[0]] = true
+	}
+	if len(winners) != 3 {
+		t.Errorf("drawing of lots favours some teams: winners %v", winners)
+	}
+
+	// Kura yalnızca önceki ölçütler eşitliği bozamadığında çekilir.
+	rows := []Row{{TeamID: 1, Points: 1, GoalsFor: 1}, {TeamID: 2, Points: 1, GoalsFor: 2}}
+	for seed := int64(0); seed < 20; seed++ {
+		Sort(rows, nil, Rules{Tiebreakers: []Tiebreaker{GoalsFor, DrawingOfLots}, LotSeed: seed})
+		if rows[0].TeamID != 2 {
+			t.Fatalf("seed %d: drawing of lots overrode goals for", seed)
+		}
+	}
+}
@@ -5,8 +5,58 @@ import "net/http"
 // LeagueHandlerContract router'ın LeagueHandler'dan beklediği metotları tanımlar.
 // Bu arayüz, platform paketinin doğrudan handlers paketine bağımlılığını kırar.
 type LeagueHandlerContract interface {
+	GetLeagues(w http.ResponseWriter, r *http.Request)
+	CreateLeague(w http.ResponseWriter, r *http.Request)
 	GetLeagueTable(w http.ResponseWriter, r *http.Request)
+	GetFixtures(w http.ResponseWriter, r *http.Request)
+	SetMatchResult(w http.ResponseWriter, r *http.Request)
 	PlayWeek(w http.ResponseWriter, r *http.Request)
 	ResetLeague(w http.ResponseWriter, r *http.Request)
 	SimulateAllWeeks(w http.ResponseWriter, r *http.Request)
+	GetPredictions(w http.ResponseWriter, r *http.Request)
+	GetSeasons(w http.ResponseWriter, r *http.Request)
+	GetSeasonTable(w http.ResponseWriter, r *http.Request)
+	GetAllTimeTable(w http.ResponseWriter, r *http.Request)
+	SetLeagueTier(w http.ResponseWriter, r *http.Request)
+	SetLeaguePlayoffs(w http.ResponseWriter, r *http.Request)
+	DeleteLeaguePlayoffs(w http.ResponseWriter, r *http.Request)
+	SetLeagueRoster(w http.ResponseWriter, r *http.Request)
+	DeleteLeagueRoster(w http.ResponseWriter, r *http.Request)
+	SetLeagueMatchEngine(w http.ResponseWriter, r *http.Request)
+	RolloverSeason(w http.ResponseWriter, r *http.Request)
+}
+
+// CupHandlerContract router'ın CupHandler'dan beklediği metotları tanımlar.
+type CupHandlerContract interface {
+	GetCups(w http.ResponseWriter, r *http.Request)
+	CreateCup(w http.ResponseWriter, r *http.Request)
+	GetBracket(w http.ResponseWriter, r *http.Request)
+	DrawNextRound(w http.ResponseWriter, r *http.Request)
+	PlayRound(w http.ResponseWriter, r *http.Request)
+}
+
+// TournamentHandlerContract router'ın TournamentHandler'dan beklediği metotları tanımlar.
+type TournamentHandlerContract interface {
+	GetTournaments(w http.ResponseWriter, r *http.Request)
+	CreateTournament(w http.ResponseWriter, r *http.Request)
+	GetTournament(w http.ResponseWriter, r *http.Request)
+	PlayNextRound(w http.ResponseWriter, r *http.Request)
+	GetPredictions(w http.ResponseWriter, r *http.Request)
+}
+
+// TeamHandlerContract router'ın TeamHandler'dan beklediği metotları tanımlar.
+type TeamHandlerContract interface {
+	GetTeams(w http.ResponseWriter, r *http.Request)
+	CreateTeam(w http.ResponseWriter, r *http.Request)
+	GetTeam(w http.ResponseWriter, r *http.Request)
+	UpdateTeam(w http.ResponseWriter, r *http.Request)
+	DeleteTeam(w http.ResponseWriter, r *http.Request)
+}
+
+// ProblemHandlerContract router'ın kendi hatalarını (bilinmeyen yol, desteklenmeyen metot, panik)
+// problem+json olarak yanıtlayan ProblemHandler'dan beklediği metotları tanımlar.
+type ProblemHandlerContract interface {
+	NotFound(w http.ResponseWriter, r *http.Request)
+	MethodNotAllowed(w http.ResponseWriter, r *http.Request)
+	Recoverer(next http.Handler) http.Handler
 }
@@ -11,11 +11,23 @@ import (
 )
 
 // NewRouter fonksiyonunun LeagueHandlerContract arayüzünü alması gerekiyor.
-func NewRouter(leagueHandler LeagueHandlerContract) http.Handler { // <--- Düzeltildi: *handlers.LeagueHandler yerine LeagueHandlerContract
+func NewRouter(leagueHandler LeagueHandlerContract, cupHandler CupHandlerContract, tournamentHandler TournamentHandlerContract, teamHandler TeamHandlerContract, problemHandler ProblemHandlerContract) http.Handler { // <--- Düzeltildi: *handlers.LeagueHandler yerine LeagueHandlerContract
 	r := chi.NewRouter()
 
 	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(problemHandler.Recoverer)
+
+	// Yönlendiricinin kendi hataları da problem+json olarak yanıtlanır. Özel 405 handler'ı verildiğinde chi
+	// Allow başlığını yazmadığından desteklenen metotlar yönlendiriciden bulunur.
+	r.NotFound(problemHandler.NotFound)
+	r.MethodNotAllowed(func(w http.ResponseWriter, req *http.Request) {
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			if r.Match(chi.NewRouteContext(), method, req.URL.Path) {
+				w.Header().Add("Allow", method)
+			}
+		}
+		problemHandler.MethodNotAllowed(w, req)
+	})
 
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
@@ -24,8 +36,65 @@ func NewRouter(leagueHandler LeagueHandlerContract) http.Handler { // <--- Düze
 	r.Get("/league-table", leagueHandler.GetLeagueTable)
 	r.Post("/play-week", leagueHandler.PlayWeek)
 	r.Post("/reset-league", leagueHandler.ResetLeague)
-	// r.Get("/fixture", leagueHandler.GetFixture)
+	r.Get("/fixtures", leagueHandler.GetFixtures)
 	r.Post("/simulate-all-weeks", leagueHandler.SimulateAllWeeks)
+	r.Get("/predictions", leagueHandler.GetPredictions)
+
+	// Lig kimliği içermeyen yukarıdaki uç noktalar varsayılan lig için korunur.
+	r.Route("/leagues", func(r chi.Router) {
+		r.Get("/", leagueHandler.GetLeagues)
+		r.Post("/", leagueHandler.CreateLeague)
+		r.Route("/{leagueID}", func(r chi.Router) {
+			r.Get("/table", leagueHandler.GetLeagueTable)
+			r.Get("/fixtures", leagueHandler.GetFixtures)
+			r.Post("/play-week", leagueHandler.PlayWeek)
+			r.Post("/reset", leagueHandler.ResetLeague)
+			r.Post("/simulate-all-weeks", leagueHandler.SimulateAllWeeks)
+			r.Get("/predictions", leagueHandler.GetPredictions)
+			r.Get("/seasons", leagueHandler.GetSeasons)
+			r.Get("/seasons/{season}/table", leagueHandler.GetSeasonTable)
+			r.Get("/all-time-table", leagueHandler.GetAllTimeTable)
+			r.Put("/tier", leagueHandler.SetLeagueTier)
+			r.Put("/playoffs", leagueHandler.SetLeaguePlayoffs)
+			r.Delete("/playoffs", leagueHandler.DeleteLeaguePlayoffs)
+			r.Put("/roster", leagueHandler.SetLeagueRoster)
+			r.Delete("/roster", leagueHandler.DeleteLeagueRoster)
+			r.Put("/engine", leagueHandler.SetLeagueMatchEngine)
+			r.Post("/rollover", leagueHandler.RolloverSeason)
+		})
+	})
+
+	r.Put("/matches/{matchID}/result", leagueHandler.SetMatchResult)
+
+	r.Route("/teams", func(r chi.Router) {
+		r.Get("/", teamHandler.GetTeams)
+		r.Post("/", teamHandler.CreateTeam)
+		r.Route("/{teamID}", func(r chi.Router) {
+			r.Get("/", teamHandler.GetTeam)
+			r.Put("/", teamHandler.UpdateTeam)
+			r.Delete("/", teamHandler.DeleteTeam)
+		})
+	})
+
+	r.Route("/cups", func(r chi.Router) {
+		r.Get("/", cupHandler.GetCups)
+		r.Post("/", cupHandler.CreateCup)
+		r.Route("/{cupID}", func(r chi.Router) {
+			r.Get("/", cupHandler.GetBracket)
+			r.Post("/draw", cupHandler.DrawNextRound)
+			r.Post("/play-round", cupHandler.PlayRound)
+		})
+	})
+
+	r.Route("/tournaments", func(r chi.Router) {
+		r.Get("/", tournamentHandler.GetTournaments)
+		r.Post("/", tournamentHandler.CreateTournament)
+		r.Route("/{tournamentID}", func(r chi.Router) {
+			r.Get("/", tournamentHandler.GetTournament)
+			r.Post("/play-round", tournamentHandler.PlayNextRound)
+			r.Get("/predictions", tournamentHandler.GetPredictions)
+		})
+	})
 
 	return r
 }
@@ -0,0 +1,2 @@
+ALTER TABLE Teams DROP CONSTRAINT DF_Teams_Attack, DF_Teams_Defense;
+ALTER TABLE Teams DROP COLUMN Attack, Defense;
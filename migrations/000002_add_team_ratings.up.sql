@@ -0,0 +1,4 @@
+-- Maç motorunun kullandığı hücum/savunma güçleri. 0 ise takımın genel gücü (Strength) kullanılır.
+ALTER TABLE Teams ADD
+    Attack INT NOT NULL CONSTRAINT DF_Teams_Attack DEFAULT 0,
+    Defense INT NOT NULL CONSTRAINT DF_Teams_Defense DEFAULT 0;
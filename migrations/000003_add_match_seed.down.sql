@@ -0,0 +1 @@
+ALTER TABLE Matches DROP COLUMN Seed;
@@ -0,0 +1,2 @@
+-- Oynanan maçın skorunu üreten rastgele sayı tohumu. Aynı tohumla maç birebir tekrar oynatılabilir.
+ALTER TABLE Matches ADD Seed BIGINT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE Teams DROP CONSTRAINT DF_Teams_FairPlayPoints;
+ALTER TABLE Teams DROP COLUMN FairPlayPoints;
@@ -0,0 +1,3 @@
+-- Fair-play eşitlik ölçütünde kullanılan disiplin puanı (sarı kart 1, kırmızı kart 3 vb.). Düşük olan öndedir.
+ALTER TABLE Teams ADD
+    FairPlayPoints INT NOT NULL CONSTRAINT DF_Teams_FairPlayPoints DEFAULT 0;
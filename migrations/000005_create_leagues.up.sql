@@ -0,0 +1,10 @@
+-- Ligin kalıcı durumu: güncel hafta, sezon durumu, sıralama kuralları ve rastgele sayı tohumu.
+-- Mevcut maçlar için varsayılan lig satırını 000007_scope_by_league ekler.
+CREATE TABLE Leagues (
+    ID INT IDENTITY(1,1) PRIMARY KEY,
+    Name NVARCHAR(100) NOT NULL,
+    CurrentWeek INT NOT NULL CONSTRAINT DF_Leagues_CurrentWeek DEFAULT 1,
+    Status NVARCHAR(20) NOT NULL CONSTRAINT DF_Leagues_Status DEFAULT 'not_started',
+    Tiebreakers NVARCHAR(255) NOT NULL CONSTRAINT DF_Leagues_Tiebreakers DEFAULT '', -- Virgülle ayrılmış eşitlik ölçütleri
+    Seed BIGINT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE Leagues DROP CONSTRAINT DF_Leagues_Version;
+ALTER TABLE Leagues DROP COLUMN Version;
@@ -0,0 +1,3 @@
+-- İyimser eşzamanlılık denetimi için sürüm numarası; her güncellemede bir artar.
+ALTER TABLE Leagues ADD
+    Version INT NOT NULL CONSTRAINT DF_Leagues_Version DEFAULT 0;
@@ -0,0 +1,6 @@
+DROP INDEX IX_Matches_LeagueID_Week ON Matches;
+DROP INDEX IX_Teams_LeagueID ON Teams;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Leagues;
+ALTER TABLE Teams DROP CONSTRAINT FK_Teams_Leagues;
+ALTER TABLE Matches DROP COLUMN LeagueID;
+ALTER TABLE Teams DROP COLUMN LeagueID;
@@ -0,0 +1,35 @@
+-- Takımlar ve maçlar bir lige bağlanır. Hiç lig yoksa mevcut kayıtlar için varsayılan lig oluşturulur;
+-- güncel hafta oynanmış maçlardan çıkarılır. Yeni sütunlara aynı toplu işte (batch) başvurulabilmesi
+-- için bu sütunları kullanan ifadeler EXEC ile çalıştırılır.
+IF NOT EXISTS (SELECT 1 FROM Leagues)
+BEGIN
+    DECLARE @maxPlayedWeek INT = (SELECT ISNULL(MAX(Week), 0) FROM Matches WHERE Played = 1);
+    DECLARE @totalWeeks INT = (SELECT ISNULL(MAX(Week), 0) FROM Matches);
+    DECLARE @currentWeek INT = CASE
+        WHEN @maxPlayedWeek = 0 THEN 1
+        WHEN EXISTS (SELECT 1 FROM Matches WHERE Week = @maxPlayedWeek AND Played = 0) THEN @maxPlayedWeek
+        ELSE @maxPlayedWeek + 1
+    END;
+
+    INSERT INTO Leagues (Name, CurrentWeek, Status)
+    VALUES ('Premier League', @currentWeek, CASE
+        WHEN @totalWeeks > 0 AND @currentWeek > @totalWeeks THEN 'completed'
+        WHEN @currentWeek > 1 THEN 'in_progress'
+        ELSE 'not_started'
+    END);
+END;
+
+ALTER TABLE Teams ADD LeagueID INT NULL;
+ALTER TABLE Matches ADD LeagueID INT NULL;
+
+EXEC(N'UPDATE Teams SET LeagueID = (SELECT MIN(ID) FROM Leagues) WHERE LeagueID IS NULL');
+EXEC(N'UPDATE Matches SET LeagueID = (SELECT MIN(ID) FROM Leagues) WHERE LeagueID IS NULL');
+
+EXEC(N'ALTER TABLE Teams ALTER COLUMN LeagueID INT NOT NULL');
+EXEC(N'ALTER TABLE Matches ALTER COLUMN LeagueID INT NOT NULL');
+
+EXEC(N'ALTER TABLE Teams ADD CONSTRAINT FK_Teams_Leagues FOREIGN KEY (LeagueID) REFERENCES Leagues(ID)');
+EXEC(N'ALTER TABLE Matches ADD CONSTRAINT FK_Matches_Leagues FOREIGN KEY (LeagueID) REFERENCES Leagues(ID)');
+
+EXEC(N'CREATE INDEX IX_Teams_LeagueID ON Teams (LeagueID)');
+EXEC(N'CREATE INDEX IX_Matches_LeagueID_Week ON Matches (LeagueID, Week)');
@@ -0,0 +1,8 @@
+-- Yalnızca her ligin en son sezonunun maçları korunur; arşivlenmiş sezonların maçları silinir.
+DELETE m FROM Matches m
+WHERE m.SeasonID <> (SELECT MAX(s.ID) FROM Seasons s WHERE s.LeagueID = m.LeagueID);
+
+DROP INDEX IX_Matches_SeasonID_Week ON Matches;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Seasons;
+ALTER TABLE Matches DROP COLUMN SeasonID;
+DROP TABLE Seasons;
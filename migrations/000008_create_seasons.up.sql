@@ -0,0 +1,22 @@
+-- Sezonlar: ligin sıfırlanması yeni bir sezon başlatır, önceki sezonların maçları arşivde kalır.
+-- Mevcut her lig için ilk sezon oluşturulur ve ligin mevcut maçları bu sezona bağlanır.
+CREATE TABLE Seasons (
+    ID INT IDENTITY(1,1) PRIMARY KEY,
+    LeagueID INT NOT NULL CONSTRAINT FK_Seasons_Leagues REFERENCES Leagues(ID),
+    Number INT NOT NULL,
+    ChampionTeamID INT NULL CONSTRAINT FK_Seasons_Teams REFERENCES Teams(ID),
+    Tiebreakers NVARCHAR(255) NULL, -- Sezon kapanırken geçerli olan eşitlik ölçütleri
+    StartedAt DATETIME2 NOT NULL CONSTRAINT DF_Seasons_StartedAt DEFAULT SYSUTCDATETIME(),
+    EndedAt DATETIME2 NULL,
+    CONSTRAINT UQ_Seasons_LeagueID_Number UNIQUE (LeagueID, Number)
+);
+
+INSERT INTO Seasons (LeagueID, Number)
+SELECT ID, 1 FROM Leagues;
+
+ALTER TABLE Matches ADD SeasonID INT NULL;
+
+EXEC(N'UPDATE m SET SeasonID = s.ID FROM Matches m JOIN Seasons s ON s.LeagueID = m.LeagueID');
+EXEC(N'ALTER TABLE Matches ALTER COLUMN SeasonID INT NOT NULL');
+EXEC(N'ALTER TABLE Matches ADD CONSTRAINT FK_Matches_Seasons FOREIGN KEY (SeasonID) REFERENCES Seasons(ID)');
+EXEC(N'CREATE INDEX IX_Matches_SeasonID_Week ON Matches (SeasonID, Week)');
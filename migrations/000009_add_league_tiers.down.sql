@@ -0,0 +1,5 @@
+DROP INDEX UX_Leagues_ParentLeagueID ON Leagues;
+ALTER TABLE Leagues DROP CONSTRAINT FK_Leagues_ParentLeague;
+ALTER TABLE Leagues DROP CONSTRAINT DF_Leagues_PromotionPlaces;
+ALTER TABLE Leagues DROP CONSTRAINT DF_Leagues_RelegationPlaces;
+ALTER TABLE Leagues DROP COLUMN ParentLeagueID, PromotionPlaces, RelegationPlaces;
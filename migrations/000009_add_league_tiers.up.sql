@@ -0,0 +1,8 @@
+-- Lig piramidi: her lig en fazla bir üst lige bağlanır ve her ligin en fazla bir alt ligi olur.
+-- Sezon geçişinde alt ligin ilk PromotionPlaces takımı üst lige çıkar, üst ligin son RelegationPlaces takımı alt lige düşer.
+ALTER TABLE Leagues ADD
+    ParentLeagueID INT NULL CONSTRAINT FK_Leagues_ParentLeague REFERENCES Leagues(ID),
+    PromotionPlaces INT NOT NULL CONSTRAINT DF_Leagues_PromotionPlaces DEFAULT 0,
+    RelegationPlaces INT NOT NULL CONSTRAINT DF_Leagues_RelegationPlaces DEFAULT 0;
+
+EXEC(N'CREATE UNIQUE INDEX UX_Leagues_ParentLeagueID ON Leagues (ParentLeagueID) WHERE ParentLeagueID IS NOT NULL');
@@ -0,0 +1,21 @@
+DROP TABLE CupTies;
+
+DROP INDEX IX_Matches_CupID ON Matches;
+ALTER TABLE Matches DROP CONSTRAINT CK_Matches_Competition;
+DELETE FROM Matches WHERE CupID IS NOT NULL;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Cups;
+ALTER TABLE Matches DROP COLUMN CupID;
+
+DROP INDEX IX_Matches_LeagueID_Week ON Matches;
+DROP INDEX IX_Matches_SeasonID_Week ON Matches;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Leagues;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Seasons;
+ALTER TABLE Matches ALTER COLUMN LeagueID INT NOT NULL;
+ALTER TABLE Matches ALTER COLUMN SeasonID INT NOT NULL;
+ALTER TABLE Matches ADD CONSTRAINT FK_Matches_Leagues FOREIGN KEY (LeagueID) REFERENCES Leagues(ID);
+ALTER TABLE Matches ADD CONSTRAINT FK_Matches_Seasons FOREIGN KEY (SeasonID) REFERENCES Seasons(ID);
+CREATE INDEX IX_Matches_LeagueID_Week ON Matches (LeagueID, Week);
+CREATE INDEX IX_Matches_SeasonID_Week ON Matches (SeasonID, Week);
+
+DROP TABLE CupTeams;
+DROP TABLE Cups;
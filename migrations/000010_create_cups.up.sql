@@ -0,0 +1,57 @@
+-- Eleme usulü kupalar. Kupa maçları da Matches tablosunda tutulur; bu yüzden lig ve sezon sütunları
+-- boş bırakılabilir hale getirilir ve her maçın ya bir sezona ya da bir kupaya ait olması zorunlu tutulur.
+CREATE TABLE Cups (
+    ID INT IDENTITY(1,1) PRIMARY KEY,
+    Name NVARCHAR(100) NOT NULL,
+    Legs INT NOT NULL CONSTRAINT DF_Cups_Legs DEFAULT 1,
+    DrawMode NVARCHAR(20) NOT NULL CONSTRAINT DF_Cups_DrawMode DEFAULT 'random',
+    Seed BIGINT NULL,
+    Status NVARCHAR(20) NOT NULL CONSTRAINT DF_Cups_Status DEFAULT 'not_started',
+    CurrentRound INT NOT NULL CONSTRAINT DF_Cups_CurrentRound DEFAULT 0,
+    WinnerTeamID INT NULL CONSTRAINT FK_Cups_Teams REFERENCES Teams(ID),
+    Version INT NOT NULL CONSTRAINT DF_Cups_Version DEFAULT 0
+);
+
+CREATE TABLE CupTeams (
+    CupID INT NOT NULL CONSTRAINT FK_CupTeams_Cups REFERENCES Cups(ID),
+    TeamID INT NOT NULL CONSTRAINT FK_CupTeams_Teams REFERENCES Teams(ID),
+    SeedRank INT NOT NULL, -- Seri başı sırası; 1 en güçlü seri başıdır
+    CONSTRAINT PK_CupTeams PRIMARY KEY (CupID, TeamID)
+);
+
+CREATE TABLE CupTies (
+    ID INT IDENTITY(1,1) PRIMARY KEY,
+    CupID INT NOT NULL CONSTRAINT FK_CupTies_Cups REFERENCES Cups(ID),
+    Round INT NOT NULL,
+    Position INT NOT NULL,
+    HomeTeamID INT NOT NULL CONSTRAINT FK_CupTies_HomeTeams REFERENCES Teams(ID),
+    AwayTeamID INT NULL CONSTRAINT FK_CupTies_AwayTeams REFERENCES Teams(ID), -- NULL ise bay
+    FirstLegMatchID INT NULL CONSTRAINT FK_CupTies_FirstLeg REFERENCES Matches(ID),
+    SecondLegMatchID INT NULL CONSTRAINT FK_CupTies_SecondLeg REFERENCES Matches(ID),
+    ExtraTimeHomeGoals INT NULL,
+    ExtraTimeAwayGoals INT NULL,
+    PenaltiesHome INT NULL,
+    PenaltiesAway INT NULL,
+    WinnerTeamID INT NULL CONSTRAINT FK_CupTies_Winners REFERENCES Teams(ID),
+    CONSTRAINT UQ_CupTies_CupID_Round_Position UNIQUE (CupID, Round, Position)
+);
+
+-- Sütunlara bağlı indeks ve kısıtlar sütunlar değiştirilmeden önce kaldırılıp sonra yeniden oluşturulur.
+DROP INDEX IX_Matches_LeagueID_Week ON Matches;
+DROP INDEX IX_Matches_SeasonID_Week ON Matches;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Leagues;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_Seasons;
+
+ALTER TABLE Matches ALTER COLUMN LeagueID INT NULL;
+ALTER TABLE Matches ALTER COLUMN SeasonID INT NULL;
+ALTER TABLE Matches ADD CupID INT NULL CONSTRAINT FK_Matches_Cups REFERENCES Cups(ID);
+
+ALTER TABLE Matches ADD CONSTRAINT FK_Matches_Leagues FOREIGN KEY (LeagueID) REFERENCES Leagues(ID);
+ALTER TABLE Matches ADD CONSTRAINT FK_Matches_Seasons FOREIGN KEY (SeasonID) REFERENCES Seasons(ID);
+CREATE INDEX IX_Matches_LeagueID_Week ON Matches (LeagueID, Week);
+CREATE INDEX IX_Matches_SeasonID_Week ON Matches (SeasonID, Week);
+
+EXEC(N'ALTER TABLE Matches ADD CONSTRAINT CK_Matches_Competition CHECK (
+    (SeasonID IS NOT NULL AND LeagueID IS NOT NULL AND CupID IS NULL)
+    OR (SeasonID IS NULL AND LeagueID IS NULL AND CupID IS NOT NULL))');
+EXEC(N'CREATE INDEX IX_Matches_CupID ON Matches (CupID)');
@@ -0,0 +1,12 @@
+DROP INDEX IX_Matches_GroupID_Week ON Matches;
+ALTER TABLE Matches DROP CONSTRAINT CK_Matches_Competition;
+DELETE FROM Matches WHERE GroupID IS NOT NULL;
+ALTER TABLE Matches DROP CONSTRAINT FK_Matches_TournamentGroups;
+ALTER TABLE Matches DROP COLUMN GroupID;
+EXEC(N'ALTER TABLE Matches ADD CONSTRAINT CK_Matches_Competition CHECK (
+    (SeasonID IS NOT NULL AND LeagueID IS NOT NULL AND CupID IS NULL)
+    OR (SeasonID IS NULL AND LeagueID IS NULL AND CupID IS NOT NULL))');
+
+DROP TABLE TournamentGroupTeams;
+DROP TABLE TournamentGroups;
+DROP TABLE Tournaments;
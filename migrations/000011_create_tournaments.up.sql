@@ -0,0 +1,35 @@
+-- Grup aşaması ve eleme aşamasıyla oynanan turnuvalar. Grup maçları Matches tablosunda grubun kimliğiyle,
+-- eleme aşaması ise bir kupa olarak tutulur.
+CREATE TABLE Tournaments (
+    ID INT IDENTITY(1,1) PRIMARY KEY,
+    Name NVARCHAR(100) NOT NULL,
+    GroupLegs INT NOT NULL CONSTRAINT DF_Tournaments_GroupLegs DEFAULT 1,
+    KnockoutLegs INT NOT NULL CONSTRAINT DF_Tournaments_KnockoutLegs DEFAULT 1,
+    Tiebreakers NVARCHAR(200) NULL,
+    Seed BIGINT NULL,
+    Status NVARCHAR(20) NOT NULL CONSTRAINT DF_Tournaments_Status DEFAULT 'not_started',
+    CupID INT NULL CONSTRAINT FK_Tournaments_Cups REFERENCES Cups(ID),
+    Version INT NOT NULL CONSTRAINT DF_Tournaments_Version DEFAULT 0
+);
+
+CREATE TABLE TournamentGroups (
+    ID INT IDENTITY(1,1) PRIMARY KEY,
+    TournamentID INT NOT NULL CONSTRAINT FK_TournamentGroups_Tournaments REFERENCES Tournaments(ID),
+    Name NVARCHAR(10) NOT NULL,
+    CONSTRAINT UQ_TournamentGroups_TournamentID_Name UNIQUE (TournamentID, Name)
+);
+
+CREATE TABLE TournamentGroupTeams (
+    GroupID INT NOT NULL CONSTRAINT FK_TournamentGroupTeams_Groups REFERENCES TournamentGroups(ID),
+    TeamID INT NOT NULL CONSTRAINT FK_TournamentGroupTeams_Teams REFERENCES Teams(ID),
+    CONSTRAINT PK_TournamentGroupTeams PRIMARY KEY (GroupID, TeamID)
+);
+
+ALTER TABLE Matches ADD GroupID INT NULL CONSTRAINT FK_Matches_TournamentGroups REFERENCES TournamentGroups(ID);
+
+ALTER TABLE Matches DROP CONSTRAINT CK_Matches_Competition;
+EXEC(N'ALTER TABLE Matches ADD CONSTRAINT CK_Matches_Competition CHECK (
+    (SeasonID IS NOT NULL AND LeagueID IS NOT NULL AND CupID IS NULL AND GroupID IS NULL)
+    OR (SeasonID IS NULL AND LeagueID IS NULL AND CupID IS NOT NULL AND GroupID IS NULL)
+    OR (SeasonID IS NULL AND LeagueID IS NULL AND CupID IS NULL AND GroupID IS NOT NULL))');
+EXEC(N'CREATE INDEX IX_Matches_GroupID_Week ON Matches (GroupID, Week)');
@@ -0,0 +1,3 @@
+ALTER TABLE Seasons DROP CONSTRAINT FK_Seasons_PlayoffCup;
+ALTER TABLE Seasons DROP COLUMN PlayoffCupID, PlayoffPrize;
+ALTER TABLE Leagues DROP COLUMN PlayoffFirstPlace, PlayoffLastPlace, PlayoffLegs, PlayoffPrize;
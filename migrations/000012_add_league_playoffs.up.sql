@@ -0,0 +1,11 @@
+-- Sezon sonu play-off'ları: ligin play-off biçimi Leagues tablosunda, her sezonun play-off'unun oynandığı
+-- kupa ve başladığı andaki ödülü (şampiyonluk ya da üst lige çıkma) Seasons tablosunda tutulur.
+ALTER TABLE Leagues ADD
+    PlayoffFirstPlace INT NULL,
+    PlayoffLastPlace INT NULL,
+    PlayoffLegs INT NULL,
+    PlayoffPrize NVARCHAR(20) NULL;
+
+ALTER TABLE Seasons ADD
+    PlayoffCupID INT NULL CONSTRAINT FK_Seasons_PlayoffCup REFERENCES Cups(ID),
+    PlayoffPrize NVARCHAR(20) NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE Teams DROP COLUMN Pot, Country;
+ALTER TABLE Leagues DROP COLUMN SwissOpponentsPerPot;
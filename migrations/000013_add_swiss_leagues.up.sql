@@ -0,0 +1,8 @@
+-- İsviçre usulü ligler: ligin torba başına rakip sayısı Leagues tablosunda, takımların torbası ve ülkesi
+-- Teams tablosunda tutulur. Çift devreli liglerde tümü NULL'dur.
+ALTER TABLE Leagues ADD
+    SwissOpponentsPerPot INT NULL;
+
+ALTER TABLE Teams ADD
+    Pot INT NULL,
+    Country NVARCHAR(60) NULL;
@@ -0,0 +1,10 @@
+DROP INDEX UX_Teams_Name ON Teams;
+ALTER TABLE Teams ALTER COLUMN Name NVARCHAR(100) COLLATE DATABASE_DEFAULT NOT NULL;
+ALTER TABLE Leagues DROP COLUMN NextRoster;
+
+UPDATE Teams SET LeagueID = (SELECT MIN(ID) FROM Leagues) WHERE LeagueID IS NULL;
+DROP INDEX IX_Teams_LeagueID ON Teams;
+ALTER TABLE Teams DROP CONSTRAINT FK_Teams_Leagues;
+ALTER TABLE Teams ALTER COLUMN LeagueID INT NOT NULL;
+ALTER TABLE Teams ADD CONSTRAINT FK_Teams_Leagues FOREIGN KEY (LeagueID) REFERENCES Leagues(ID);
+CREATE INDEX IX_Teams_LeagueID ON Teams (LeagueID);
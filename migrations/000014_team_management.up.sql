@@ -0,0 +1,21 @@
+-- Takımlar bir lige bağlı olmadan da kaydedilebilir (kupalarda, turnuvalarda ya da bir ligin sonraki
+-- kadrosunda kullanılmak üzere). Sütuna bağlı dizin ve yabancı anahtar, sütun değiştirilirken yeniden oluşturulur.
+DROP INDEX IX_Teams_LeagueID ON Teams;
+ALTER TABLE Teams DROP CONSTRAINT FK_Teams_Leagues;
+ALTER TABLE Teams ALTER COLUMN LeagueID INT NULL;
+ALTER TABLE Teams ADD CONSTRAINT FK_Teams_Leagues FOREIGN KEY (LeagueID) REFERENCES Leagues(ID);
+CREATE INDEX IX_Teams_LeagueID ON Teams (LeagueID);
+
+-- Ligin bir sonraki sıfırlamadan itibaren oynayacak takımlarının virgülle ayrılmış kimlikleri; NULL ise kadro değişmez.
+ALTER TABLE Leagues ADD NextRoster NVARCHAR(MAX) NULL;
+
+-- Takım adları büyük/küçük harf farkı gözetmeden benzersizdir. Dizin oluşturulmadan önce aynı adı taşıyan
+-- takımlardan kimliği küçük olan adını korur, diğerlerinin adına kimlikleri eklenir.
+UPDATE t SET Name = LEFT(t.Name, 88) + N' (' + CAST(t.ID AS NVARCHAR(10)) + N')'
+FROM Teams t
+WHERE EXISTS (
+    SELECT 1 FROM Teams o
+    WHERE o.ID < t.ID AND o.Name COLLATE Latin1_General_CI_AS = t.Name COLLATE Latin1_General_CI_AS
+);
+ALTER TABLE Teams ALTER COLUMN Name NVARCHAR(100) COLLATE Latin1_General_CI_AS NOT NULL;
+CREATE UNIQUE INDEX UX_Teams_Name ON Teams (Name);
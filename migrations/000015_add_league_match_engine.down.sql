@@ -0,0 +1 @@
+ALTER TABLE Leagues DROP COLUMN MatchEngine;
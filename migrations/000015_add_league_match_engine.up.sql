@@ -0,0 +1,3 @@
+-- Ligin maç motoru. NULL ise sunucunun varsayılan motoru (MATCH_ENGINE) kullanılır.
+ALTER TABLE Leagues ADD
+    MatchEngine NVARCHAR(32) NULL;
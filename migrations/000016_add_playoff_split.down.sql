@@ -0,0 +1,2 @@
+ALTER TABLE Seasons DROP COLUMN SplitWeek, SplitTeamIDs;
+ALTER TABLE Leagues DROP COLUMN PlayoffKind, PlayoffSplitPlace;
@@ -0,0 +1,10 @@
+-- Bölünme biçimindeki play-off'lar: ligin play-off türü ve üst gruba kalan son sıra Leagues tablosunda,
+-- başlamış bir bölünmenin ilk haftası ve üst gruptaki takımlar Seasons tablosunda tutulur. Eski kayıtlarda
+-- PlayoffKind NULL'dur ve eleme play-off'u anlamına gelir.
+ALTER TABLE Leagues ADD
+    PlayoffKind NVARCHAR(16) NULL,
+    PlayoffSplitPlace INT NULL;
+
+ALTER TABLE Seasons ADD
+    SplitWeek INT NULL,
+    SplitTeamIDs NVARCHAR(MAX) NULL;
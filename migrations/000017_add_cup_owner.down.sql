@@ -0,0 +1 @@
+ALTER TABLE Cups DROP COLUMN Owner;
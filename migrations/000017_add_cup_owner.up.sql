@@ -0,0 +1,8 @@
+-- Kupayı oynatan organizasyon: play-off kupaları ligin haftalarıyla, eleme aşamaları turnuvanın turlarıyla
+-- oynanır ve kupa uç noktalarıyla oynatılamaz. Bağımsız kupalarda Owner NULL'dur. Mevcut kupaların sahibi
+-- sezonlardan ve turnuvalardan bulunur; yeni sütuna aynı toplu işte başvurulabilmesi için güncellemeler
+-- EXEC ile çalıştırılır.
+ALTER TABLE Cups ADD Owner NVARCHAR(16) NULL;
+
+EXEC(N'UPDATE Cups SET Owner = ''league'' WHERE ID IN (SELECT PlayoffCupID FROM Seasons WHERE PlayoffCupID IS NOT NULL)');
+EXEC(N'UPDATE Cups SET Owner = ''tournament'' WHERE ID IN (SELECT CupID FROM Tournaments WHERE CupID IS NOT NULL)');